@@ -67,6 +67,16 @@ func generateSDK() error {
 		return err
 	}
 
+	operationsFile := "../../oxide/operations.go"
+	if err := generateOperations(operationsFile, spec); err != nil {
+		return err
+	}
+
+	waitersFile := "../../oxide/waiters.go"
+	if err := generateWaiters(waitersFile, spec); err != nil {
+		return err
+	}
+
 	versionFile := "../../oxide/version.go"
 	if err := generateVersion(versionFile, spec, sdkVersion); err != nil {
 		return err
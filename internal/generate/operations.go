@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+type operationTemplate struct {
+	ID     string
+	Method string
+	Path   string
+}
+
+// generateOperations generates the operations.go file, which lists the method and path template of
+// every operation that has a generated method on the client.
+func generateOperations(file string, spec *openapi3.T) error {
+	f, err := openGeneratedFile(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	operations := make([]operationTemplate, 0)
+	for _, path := range sortedKeys(spec.Paths.Map()) {
+		p := spec.Paths.Map()[path]
+		if p.Ref != "" {
+			continue
+		}
+
+		// Keep the same order as the methods in paths.go.
+		methods := []struct {
+			method    string
+			operation *openapi3.Operation
+		}{
+			{http.MethodGet, p.Get},
+			{http.MethodPost, p.Post},
+			{http.MethodPut, p.Put},
+			{http.MethodDelete, p.Delete},
+			{http.MethodPatch, p.Patch},
+			{http.MethodHead, p.Head},
+			{http.MethodOptions, p.Options},
+		}
+		for _, m := range methods {
+			if m.operation == nil || !hasGeneratedMethod(m.operation) {
+				continue
+			}
+			operations = append(operations, operationTemplate{
+				ID:     m.operation.OperationID,
+				Method: m.method,
+				Path:   path,
+			})
+		}
+	}
+
+	t, err := template.ParseFiles("./templates/operations.go.tpl")
+	if err != nil {
+		return fmt.Errorf("failed generating %s: %w", file, err)
+	}
+	if err := t.Execute(f, operations); err != nil {
+		return fmt.Errorf("failed generating %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// hasGeneratedMethod reports whether buildMethod generates a client method for the operation.
+func hasGeneratedMethod(o *openapi3.Operation) bool {
+	return len(o.Tags) > 0 && !slices.Contains(o.Tags, "console-auth")
+}
@@ -23,6 +23,7 @@ type methodTemplate struct {
 	Description     string
 	HTTPMethod      string
 	FunctionName    string
+	OperationID     string
 	WrappedFunction string
 	ResponseType    string
 	Summary         string
@@ -32,6 +33,8 @@ type methodTemplate struct {
 	QueryParams     []string
 	IsList          bool
 	IsListAll       bool
+	IsListIter      bool
+	ItemType        string
 	HasDescription  bool
 	HasParams       bool
 	HasBody         bool
@@ -143,6 +146,8 @@ func buildMethod(
 		return nil
 	}
 
+	// Console authentication endpoints use form-encoded bodies and untyped responses. The device
+	// authorization flow is implemented by hand in oxide/device_auth.go instead.
 	if slices.Contains(o.Tags, "console-auth") {
 		fmt.Printf(
 			"[WARN] TODO: skipping operation %q, since it is for console authentication\n",
@@ -196,6 +201,7 @@ func buildMethod(
 		Description:     splitDocString(sanitisedDescription),
 		HTTPMethod:      method,
 		FunctionName:    methodName,
+		OperationID:     o.OperationID,
 		WrappedFunction: ogmethodName,
 		ResponseType:    respType,
 		Summary:         o.Summary,
@@ -233,6 +239,18 @@ func buildMethod(
 		return err
 	}
 
+	// Also generate an iterator alongside every ListAll method.
+	if isGetAllPages {
+		iterConfig := config
+		iterConfig.FunctionName = ogmethodName + "Iter"
+		iterConfig.IsListAll = false
+		iterConfig.IsListIter = true
+		iterConfig.ItemType = strings.TrimPrefix(respType, "[]")
+		if err := writeTpl(f, iterConfig); err != nil {
+			return err
+		}
+	}
+
 	if pInfo.isPageResult && !isGetAllPages {
 		// Run the method again with get all pages for ListAll methods.
 		err := buildMethod(f, method, path, o, true)
@@ -339,7 +357,15 @@ func writeTpl(f *os.File, config methodTemplate) error {
 	var t *template.Template
 	var err error
 
-	if config.IsListAll {
+	if config.IsListIter {
+		t, err = template.ParseFiles(
+			"./templates/listiter_method.go.tpl",
+			"./templates/description.go.tpl",
+		)
+		if err != nil {
+			return err
+		}
+	} else if config.IsListAll {
 		t, err = template.ParseFiles(
 			"./templates/listall_method.go.tpl",
 			"./templates/description.go.tpl",
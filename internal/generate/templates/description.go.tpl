@@ -4,7 +4,11 @@
 // {{.Description}}{{end}}{{if .IsListAll}}
 //
 // This method is a wrapper around the `{{.WrappedFunction}}` method.
-// This method returns all the pages at once.{{end}}{{if .IsList}}
+// This method returns all the pages at once.{{end}}{{if .IsListIter}}
 //
-// To iterate over all pages, use the `{{.FunctionName}}AllPages` method, instead.{{end}}
+// This method is a wrapper around the `{{.WrappedFunction}}` method.
+// This method returns an iterator that fetches the pages lazily.{{end}}{{if .IsList}}
+//
+// To iterate over all pages, use the `{{.FunctionName}}Iter` or `{{.FunctionName}}AllPages`
+// methods, instead.{{end}}
 {{end}}
\ No newline at end of file
@@ -0,0 +1,12 @@
+{{template "description" .}}func (c *Client) {{.FunctionName}}(ctx context.Context, {{.ParamsString}}opts ...ListOption) iter.Seq2[{{.ItemType}}, error] {
+	return listIter(params.PageToken, params.Limit, opts, func(pageToken string, limit *int) ([]{{.ItemType}}, string, error) {
+		params.PageToken = pageToken
+		params.Limit = limit
+		page, err := c.{{.WrappedFunction}}(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		return page.Items, page.NextPage, nil
+	})
+}
+
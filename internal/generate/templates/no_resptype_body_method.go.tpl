@@ -11,7 +11,7 @@
 
     // Create the request
     req, err := c.buildRequest(
-        ctx,
+        withOperationID(ctx, "{{.OperationID}}"),
         b, 
         "{{.HTTPMethod}}", 
         resolveRelative(c.host, "{{.Path}}"), 
@@ -27,7 +27,7 @@
 	}
 
     // Send the request.
-    resp, err := c.client.Do(req)
+    resp, err := c.do(req)
     if err != nil {
         return fmt.Errorf("error sending request: %v", err)
     }
@@ -0,0 +1,7 @@
+// operations lists the method and path template of every operation that has a generated method on
+// [Client], in the same order as the methods.
+var operations = []Operation{
+{{- range .}}
+	{ID: "{{.ID}}", Method: "{{.Method}}", PathTemplate: "{{.Path}}"},
+{{- end}}
+}
@@ -4,7 +4,7 @@
 	}{{end}}
     // Create the request
     req, err := c.buildRequest(
-        ctx,
+        withOperationID(ctx, "{{.OperationID}}"),
         nil, 
         "{{.HTTPMethod}}", 
         resolveRelative(c.host, "{{.Path}}"), 
@@ -20,7 +20,7 @@
 	}
 
     // Send the request.
-    resp, err := c.client.Do(req)
+    resp, err := c.do(req)
     if err != nil {
         return nil, fmt.Errorf("error sending request: %v", err)
     }
@@ -0,0 +1,18 @@
+{{- range .}}
+{{if .IsExperimental}}// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+{{end}}// {{.FunctionName}} waits until the {{.ResourceType}} reaches the given state.
+//
+// It polls `{{.ViewFunction}}` with backoff, and returns a *TerminalStateError
+// if the {{.ResourceType}} reaches a state it can't leave instead.
+func (c *Client) {{.FunctionName}}(ctx context.Context, params {{.ParamsType}}, state {{.StateType}}, opts ...WaitOption) (*{{.ResourceType}}, error) {
+	terminal := []{{.StateType}}{ {{- range $i, $s := .TerminalStates}}{{if $i}}, {{end}}{{$s}}{{end -}} }
+	return waitForState(ctx, "{{.ResourceType}}", state, terminal, opts, func(ctx context.Context) (*{{.ResourceType}}, {{.StateType}}, error) {
+		resource, err := c.{{.ViewFunction}}(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resource, resource.{{.StateAccessor}}, nil
+	})
+}
+{{end -}}
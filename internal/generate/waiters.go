@@ -0,0 +1,185 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/iancoleman/strcase"
+)
+
+// stateProperties lists the properties that hold the lifecycle state of a resource, in order of
+// precedence.
+var stateProperties = []string{"run_state", "state"}
+
+// terminalStates lists the states a resource can't leave to reach another state.
+var terminalStates = []string{"failed", "faulted", "destroying", "destroyed", "decommissioned"}
+
+type waiterTemplate struct {
+	FunctionName   string
+	ViewFunction   string
+	ParamsType     string
+	ResourceType   string
+	StateAccessor  string
+	StateType      string
+	TerminalStates []string
+	IsExperimental bool
+}
+
+// generateWaiters generates the waiters.go file, which has a method that waits for a resource to
+// reach a state for every view operation whose response has a state enum.
+func generateWaiters(file string, spec *openapi3.T) error {
+	f, err := openGeneratedFile(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	waiters := make([]waiterTemplate, 0)
+	for _, path := range sortedKeys(spec.Paths.Map()) {
+		p := spec.Paths.Map()[path]
+		if p.Ref != "" || p.Get == nil {
+			continue
+		}
+		if waiter, ok := buildWaiter(p.Get); ok {
+			waiters = append(waiters, waiter)
+		}
+	}
+
+	t, err := template.ParseFiles("./templates/waiters.go.tpl")
+	if err != nil {
+		return fmt.Errorf("failed generating %s: %w", file, err)
+	}
+	if err := t.Execute(f, waiters); err != nil {
+		return fmt.Errorf("failed generating %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// buildWaiter returns the waiter for a view operation, if its response has a state enum.
+func buildWaiter(o *openapi3.Operation) (waiterTemplate, bool) {
+	if !hasGeneratedMethod(o) || !strings.HasSuffix(o.OperationID, "_view") {
+		return waiterTemplate{}, false
+	}
+
+	resp := o.Responses.Status(200)
+	if resp == nil || resp.Value == nil {
+		return waiterTemplate{}, false
+	}
+	content := resp.Value.Content.Get("application/json")
+	if content == nil || content.Schema == nil || content.Schema.Ref == "" {
+		return waiterTemplate{}, false
+	}
+
+	for _, prop := range stateProperties {
+		stateRef, ok := content.Schema.Value.Properties[prop]
+		if !ok {
+			continue
+		}
+		if stateRef.Ref == "" && len(stateRef.Value.AllOf) == 1 {
+			stateRef = stateRef.Value.AllOf[0]
+		}
+		if stateRef.Ref == "" {
+			continue
+		}
+
+		stateType := getReferenceSchema(stateRef)
+		accessor := strcase.ToCamel(prop)
+		states := enumValues(stateRef.Value)
+		if len(states) == 0 {
+			// Tagged unions such as DiskState expose their discriminator through a method.
+			key, values := discriminatorValues(stateRef.Value)
+			if key == "" {
+				continue
+			}
+			stateType += strcase.ToCamel(key)
+			accessor += "." + strcase.ToCamel(key) + "()"
+			states = values
+		}
+
+		isExperimental := slices.Contains(o.Tags, "experimental")
+		name := strcase.ToCamel(strings.TrimSuffix(o.OperationID, "_view"))
+		waiter := waiterTemplate{
+			FunctionName:   "WaitFor" + name + "State",
+			ViewFunction:   strcase.ToCamel(o.OperationID),
+			ParamsType:     strcase.ToCamel(o.OperationID) + "Params",
+			ResourceType:   getReferenceSchema(content.Schema),
+			StateAccessor:  accessor,
+			StateType:      stateType,
+			IsExperimental: isExperimental,
+		}
+		if isExperimental {
+			waiter.FunctionName = "Experimental" + waiter.FunctionName
+			waiter.ViewFunction = "Experimental" + waiter.ViewFunction
+		}
+		for _, state := range states {
+			constName := strcase.ToCamel(fmt.Sprintf("%s_%s", stateType, state))
+			if slices.Contains(terminalStates, state) {
+				waiter.TerminalStates = append(waiter.TerminalStates, constName)
+			}
+		}
+		return waiter, true
+	}
+
+	return waiterTemplate{}, false
+}
+
+// enumValues returns the values of a string enum schema. Enums whose values are documented are
+// described as a oneOf of single-value string enums.
+func enumValues(s *openapi3.Schema) []string {
+	if s.Type.Is("string") {
+		return stringValues(s.Enum)
+	}
+
+	var values []string
+	for _, variantRef := range s.OneOf {
+		if !variantRef.Value.Type.Is("string") || len(variantRef.Value.Enum) == 0 {
+			return nil
+		}
+		values = append(values, stringValues(variantRef.Value.Enum)...)
+	}
+	return values
+}
+
+// stringValues returns the string values of an enum.
+func stringValues(enum []any) []string {
+	values := make([]string, 0, len(enum))
+	for _, v := range enum {
+		if value, ok := v.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// discriminatorValues returns the discriminator property of a tagged union schema and the values
+// it takes across variants.
+func discriminatorValues(s *openapi3.Schema) (string, []string) {
+	var (
+		key    string
+		values []string
+	)
+	for _, variantRef := range s.OneOf {
+		for _, propName := range sortedKeys(variantRef.Value.Properties) {
+			propRef := variantRef.Value.Properties[propName]
+			if len(propRef.Value.Enum) != 1 {
+				continue
+			}
+			if key != "" && key != propName {
+				return "", nil
+			}
+			key = propName
+			if value, ok := propRef.Value.Enum[0].(string); ok {
+				values = append(values, value)
+			}
+		}
+	}
+	return key, values
+}
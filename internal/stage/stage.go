@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package stage runs the steps of multi-step operations, such as deleting a project or applying a
+// spec, a stage at a time.
+package stage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Step is a step of a stage.
+type Step struct {
+	// Name describes the step in errors, e.g. "delete disk data".
+	Name string
+
+	// Run makes the step.
+	Run func(ctx context.Context) error
+}
+
+// Run runs the steps of a stage, at most concurrency at a time, and returns the errors of the
+// steps that failed, prefixed with their names. No step is started once ctx is done.
+func Run(ctx context.Context, steps []Step, concurrency int) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, max(concurrency, 1))
+	for _, s := range steps {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			wg.Wait()
+			return errors.Join(append(errs, err)...)
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			if err := s.Run(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", s.Name, ContextErr(ctx, err)))
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// ContextErr returns the error of ctx if it's done, and err otherwise. The generated methods
+// don't wrap the context error when a request is interrupted, so the error of a call made with a
+// done context doesn't match context.Canceled or context.DeadlineExceeded.
+func ContextErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package stage
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Run(t *testing.T) {
+	t.Run("runs at most concurrency steps at a time", func(t *testing.T) {
+		var running, peak atomic.Int32
+		steps := make([]Step, 8)
+		for i := range steps {
+			steps[i] = Step{Name: "step", Run: func(ctx context.Context) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				return nil
+			}}
+		}
+
+		assert.NoError(t, Run(context.Background(), steps, 2))
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+	})
+
+	t.Run("joins the errors of the failed steps", func(t *testing.T) {
+		err := Run(context.Background(), []Step{
+			{Name: "delete disk a", Run: func(context.Context) error { return errors.New("boom") }},
+			{Name: "delete disk b", Run: func(context.Context) error { return nil }},
+			{Name: "delete disk c", Run: func(context.Context) error { return errors.New("bang") }},
+		}, 1)
+		assert.EqualError(t, err, "delete disk a: boom\ndelete disk c: bang")
+	})
+
+	t.Run("reports the context error of interrupted steps", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var ran []string
+		err := Run(ctx, []Step{
+			{Name: "stop instance a", Run: func(context.Context) error {
+				ran = append(ran, "a")
+				cancel()
+				return errors.New("request interrupted")
+			}},
+			{Name: "stop instance b", Run: func(context.Context) error {
+				ran = append(ran, "b")
+				return nil
+			}},
+		}, 1)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "stop instance a: context canceled")
+		assert.Equal(t, []string{"a"}, ran)
+	})
+}
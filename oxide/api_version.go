@@ -0,0 +1,282 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+)
+
+// compatibilityProbeConcurrency is the number of operation paths probed concurrently by
+// [Client.CheckCompatibility].
+const compatibilityProbeConcurrency = 8
+
+var (
+	// apiVersionRegexp matches the semantic versions used by the Oxide API (e.g., 2026060800.0.0).
+	apiVersionRegexp = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+	// pathParamRegexp matches the parameters in a path template.
+	pathParamRegexp = regexp.MustCompile(`\{[^}]+\}`)
+)
+
+// APIVersionError is returned when the server doesn't support the API version sent by the client,
+// which usually means that the rack runs an older release than the one the SDK was generated from.
+type APIVersionError struct {
+	// Version is the API version sent by the client.
+	Version string
+
+	// Message is the error message returned by the server.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *APIVersionError) Error() string {
+	return fmt.Sprintf("server does not support API version %s: %s", e.Version, e.Message)
+}
+
+// Compatibility describes whether the server supports the API version used by [Client].
+type Compatibility struct {
+	// APIVersion is the API version sent by the client in the API-Version header.
+	APIVersion string
+
+	// GeneratedAPIVersion is the API version the SDK was generated from.
+	GeneratedAPIVersion string
+
+	// Supported reports whether the server accepts APIVersion.
+	Supported bool
+
+	// Message is the error message returned by the server when APIVersion isn't supported.
+	Message string
+
+	// UnroutedOperations lists the operations with a method on [Client] whose path the server
+	// doesn't route. It's only populated when APIVersion is supported. Paths are probed rather
+	// than operations, so an operation added as a new method on a path that the server already
+	// routes isn't listed, and fails with 405 Method Not Allowed when called.
+	UnroutedOperations []Operation
+}
+
+// WithAPIVersion overrides the API version sent in the API-Version header, which defaults to the
+// version the SDK was generated from. Use it to talk to a rack running an older release, then call
+// [Client.CheckCompatibility] to find out which operations are unavailable.
+func WithAPIVersion(version string) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		if !apiVersionRegexp.MatchString(version) {
+			return fmt.Errorf("invalid API version %q: must be a semantic version", version)
+		}
+		cfg.apiVersion = version
+		return nil
+	})
+}
+
+// WithStrictAPIVersion makes [Client] check whether the server supports its API version before
+// sending the first API call. When it doesn't, that call and every later call fail right away with
+// an [APIVersionError] instead of an obscure 400 response.
+func WithStrictAPIVersion() ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		cfg.strictAPIVersion = true
+		return nil
+	})
+}
+
+// APIVersion returns the API version sent in the API-Version header.
+func (c *Client) APIVersion() string {
+	if c.apiVersion != "" {
+		return c.apiVersion
+	}
+	return openAPIVersion
+}
+
+// CheckCompatibility checks whether the server supports the client's API version. When it does, it
+// also probes the path of every generated operation and reports the operations whose path the
+// server doesn't route. Paths are probed with OPTIONS requests, which the Oxide API never handles,
+// so the probes don't change anything on the server. Probing the methods themselves would call
+// the operations. The probes bypass the middleware and the logger of the client, since there is
+// one per path.
+//
+// An unsupported API version is reported in the returned [Compatibility] rather than as an error.
+func (c *Client) CheckCompatibility(ctx context.Context) (*Compatibility, error) {
+	compat := &Compatibility{
+		APIVersion:          c.APIVersion(),
+		GeneratedAPIVersion: openAPIVersion,
+	}
+
+	versionErr, err := c.pingAPIVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if versionErr != nil {
+		compat.Message = versionErr.Message
+		return compat, nil
+	}
+	compat.Supported = true
+
+	// Probe each path once, since operations often share a path.
+	paths := make([]string, 0)
+	available := make(map[string]bool)
+	for _, op := range operations {
+		if _, ok := available[op.PathTemplate]; !ok {
+			available[op.PathTemplate] = true
+			paths = append(paths, op.PathTemplate)
+		}
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+		sem  = make(chan struct{}, compatibilityProbeConcurrency)
+	)
+	for _, path := range paths {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			found, err := c.probePath(ctx, path)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			available[path] = found
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("error probing operations:\n%w", err)
+	}
+
+	for _, op := range operations {
+		if !available[op.PathTemplate] {
+			compat.UnroutedOperations = append(compat.UnroutedOperations, op)
+		}
+	}
+	return compat, nil
+}
+
+// pingAPIVersion sends a ping with the client's API version. It returns an [APIVersionError] when
+// the server rejects the version.
+func (c *Client) pingAPIVersion(ctx context.Context) (*APIVersionError, error) {
+	req, err := c.buildRequest(
+		withOperationID(ctx, "ping"),
+		nil,
+		http.MethodGet,
+		resolveRelative(c.host, "/v1/ping"),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %v", err)
+	}
+
+	resp, err := c.chain().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	httpErr := NewHTTPError(resp)
+	var herr *HTTPError
+	if errors.As(httpErr, &herr) && isAPIVersionError(herr) {
+		return &APIVersionError{Version: c.APIVersion(), Message: herr.ErrorResponse.Message}, nil
+	}
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	return nil, nil
+}
+
+// isAPIVersionError reports whether an error response to a ping rejects the API-Version header.
+// The web server checks the header before routing the request, and rejects versions it doesn't
+// support with a 400 Bad Request that has no error code. The errors returned by the API itself
+// always have one.
+func isAPIVersionError(herr *HTTPError) bool {
+	return herr.HTTPResponse.StatusCode == http.StatusBadRequest &&
+		herr.ErrorResponse != nil && herr.ErrorResponse.ErrorCode == ""
+}
+
+// probePath reports whether the server routes the given path template. The server returns 404 Not
+// Found for unknown paths and 405 Method Not Allowed for known paths, since no operation uses the
+// OPTIONS method.
+func (c *Client) probePath(ctx context.Context, path string) (bool, error) {
+	req, err := c.buildRequest(
+		ctx,
+		nil,
+		http.MethodOptions,
+		resolveRelative(c.host, pathParamRegexp.ReplaceAllString(path, "probe")),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return false, fmt.Errorf("error building request: %v", err)
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return false, fmt.Errorf("error probing %s: %v", path, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode != http.StatusNotFound, nil
+}
+
+// apiVersionGate fails API calls when the server doesn't support the client's API version. The
+// version is checked once, on the first call.
+type apiVersionGate struct {
+	mu      sync.Mutex
+	checked bool
+	err     error
+	// pending is closed when the check in flight finishes, and is nil when there is none.
+	pending chan struct{}
+}
+
+// check checks the API version the first time it's called and returns the result of that check.
+// Calls made while the check is in flight wait for it, or for their own context to be done.
+// Failures to reach the server aren't remembered, so the next call checks again.
+func (g *apiVersionGate) check(ctx context.Context, c *Client) error {
+	for {
+		g.mu.Lock()
+		if g.checked {
+			g.mu.Unlock()
+			return g.err
+		}
+		pending := g.pending
+		if pending == nil {
+			break
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error checking API version: %w", ctx.Err())
+		case <-pending:
+		}
+	}
+	pending := make(chan struct{})
+	g.pending = pending
+	g.mu.Unlock()
+
+	versionErr, err := c.pingAPIVersion(ctx)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pending = nil
+	close(pending)
+	if err != nil {
+		return fmt.Errorf("error checking API version: %w", err)
+	}
+	g.checked = true
+	if versionErr != nil {
+		g.err = versionErr
+	}
+	return g.err
+}
@@ -0,0 +1,241 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVersionedServer returns a server that behaves like Nexus: it rejects API versions newer than
+// maxVersion, returns 404 for paths it doesn't know, and 405 for OPTIONS requests on known paths.
+func newVersionedServer(
+	t *testing.T,
+	maxVersion string,
+	unknownPrefix string,
+) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var pings atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if version := r.Header.Get("API-Version"); version > maxVersion {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(
+				w,
+				`{"request_id":"1","message":"server does not support this API version: %s"}`,
+				version,
+			)
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, unknownPrefix):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"request_id":"2","error_code":"Not Found","message":"Not Found"}`))
+		case r.Method == http.MethodOptions:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"request_id":"3","message":"Method Not Allowed"}`))
+		case r.URL.Path == "/v1/ping":
+			pings.Add(1)
+			w.Write([]byte(`{"status":"ok"}`))
+		default:
+			w.Write([]byte(`{"name":"my-project"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &pings
+}
+
+func Test_CheckCompatibility(t *testing.T) {
+	server, _ := newVersionedServer(t, openAPIVersion, "/experimental/v1/probes")
+
+	t.Run("supported version", func(t *testing.T) {
+		client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+		require.NoError(t, err)
+
+		compat, err := client.CheckCompatibility(context.Background())
+		require.NoError(t, err)
+		assert.True(t, compat.Supported)
+		assert.Equal(t, openAPIVersion, compat.APIVersion)
+		assert.Equal(t, openAPIVersion, compat.GeneratedAPIVersion)
+
+		ids := make([]string, 0)
+		for _, op := range compat.UnroutedOperations {
+			ids = append(ids, op.ID)
+		}
+		assert.Equal(t, []string{"probe_list", "probe_create", "probe_view", "probe_delete"}, ids)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		client, err := NewClient(
+			WithHost(server.URL),
+			WithToken("foo"),
+			WithAPIVersion("9999999999.0.0"),
+		)
+		require.NoError(t, err)
+
+		compat, err := client.CheckCompatibility(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Compatibility{
+			APIVersion:          "9999999999.0.0",
+			GeneratedAPIVersion: openAPIVersion,
+			Message:             "server does not support this API version: 9999999999.0.0",
+		}, compat)
+	})
+
+	t.Run("other bad requests", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"request_id":"1","error_code":"InvalidRequest",` +
+				`"message":"api version of the proxy is unsupported"}`))
+		}))
+		defer server.Close()
+		client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+		require.NoError(t, err)
+
+		_, err = client.CheckCompatibility(context.Background())
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.HTTPResponse.StatusCode)
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+	})
+
+	t.Run("probes bypass the middleware", func(t *testing.T) {
+		var calls atomic.Int32
+		client, err := NewClient(
+			WithHost(server.URL),
+			WithToken("foo"),
+			WithMiddleware(func(next Doer) Doer {
+				return DoerFunc(func(req *http.Request) (*http.Response, error) {
+					calls.Add(1)
+					return next.Do(req)
+				})
+			}),
+		)
+		require.NoError(t, err)
+
+		_, err = client.CheckCompatibility(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, err := NewClient(WithHost(server.URL), WithToken("foo"), WithAPIVersion("latest"))
+		assert.ErrorContains(t, err, `invalid API version "latest": must be a semantic version`)
+	})
+}
+
+func Test_WithAPIVersion(t *testing.T) {
+	var version string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version = r.Header.Get("API-Version")
+		w.Write([]byte(`{"name":"my-project"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithHost(server.URL),
+		WithToken("foo"),
+		WithAPIVersion("2025010100.0.0"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2025010100.0.0", client.APIVersion())
+
+	_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+	require.NoError(t, err)
+	assert.Equal(t, "2025010100.0.0", version)
+}
+
+func Test_WithStrictAPIVersion(t *testing.T) {
+	server, pings := newVersionedServer(t, "2025010100.0.0", "/experimental/v1/probes")
+	ctx := context.Background()
+	params := ProjectViewParams{Project: "p"}
+
+	t.Run("fails fast on a mismatch", func(t *testing.T) {
+		client, err := NewClient(WithHost(server.URL), WithToken("foo"), WithStrictAPIVersion())
+		require.NoError(t, err)
+
+		for range 2 {
+			_, err = client.ProjectView(ctx, params)
+			assert.ErrorContains(
+				t,
+				err,
+				"server does not support API version "+openAPIVersion,
+			)
+		}
+	})
+
+	t.Run("checks the version once", func(t *testing.T) {
+		pings.Store(0)
+		client, err := NewClient(
+			WithHost(server.URL),
+			WithToken("foo"),
+			WithAPIVersion("2025010100.0.0"),
+			WithStrictAPIVersion(),
+		)
+		require.NoError(t, err)
+
+		for range 3 {
+			_, err = client.ProjectView(ctx, params)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), pings.Load())
+	})
+
+	t.Run("waits for the check in flight until its context is done", func(t *testing.T) {
+		pinged := make(chan struct{})
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/ping" {
+				close(pinged)
+				<-release
+			}
+			w.Write([]byte(`{"name":"my-project"}`))
+		}))
+		defer server.Close()
+		client, err := NewClient(WithHost(server.URL), WithToken("foo"), WithStrictAPIVersion())
+		require.NoError(t, err)
+
+		first := make(chan error)
+		go func() {
+			_, err := client.ProjectView(ctx, params)
+			first <- err
+		}()
+		<-pinged
+
+		waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err = client.ProjectView(waitCtx, params)
+		assert.ErrorContains(
+			t,
+			err,
+			"error checking API version: "+context.DeadlineExceeded.Error(),
+		)
+
+		close(release)
+		require.NoError(t, <-first)
+		_, err = client.ProjectView(ctx, params)
+		require.NoError(t, err)
+	})
+}
+
+func Test_operations(t *testing.T) {
+	ids := make(map[string]bool, len(operations))
+	for _, op := range operations {
+		assert.False(t, ids[op.ID], "duplicate operation %q", op.ID)
+		ids[op.ID] = true
+		assert.True(t, strings.HasPrefix(op.PathTemplate, "/"), op.PathTemplate)
+	}
+	assert.True(t, ids["project_view"])
+}
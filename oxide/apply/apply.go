@@ -0,0 +1,198 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/oxidecomputer/oxide.go/internal/stage"
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// defaultConcurrency is the number of steps of a stage that run concurrently.
+const defaultConcurrency = 4
+
+// Options configures an Applier.
+type Options struct {
+	// Concurrency is the number of API calls that run concurrently while applying a plan.
+	// Defaults to 4.
+	Concurrency int
+
+	// Wait configures the polling of instances while they stop before being deleted or resized.
+	Wait []oxide.WaitOption
+}
+
+// Applier plans and applies the changes that make projects match specs.
+type Applier struct {
+	client *oxide.Client
+	opts   Options
+}
+
+// New returns an applier that uses client.
+func New(client *oxide.Client, opts Options) *Applier {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	return &Applier{client: client, opts: opts}
+}
+
+// Plan returns the changes that make the project of spec match it. It fails if the spec changes
+// fields that can't be updated, such as the IPv4 block of a subnet or the size of a disk. The
+// blocks of the default subnet of a VPC that is created are only known once the VPC exists, so
+// Apply checks them instead.
+func (a *Applier) Plan(ctx context.Context, spec *Spec) (*Plan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		client:  a.client,
+		opts:    a.opts,
+		spec:    spec,
+		project: oxide.NameOrId(spec.Project),
+	}
+	current, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.planVpcs(current)
+	p.planDisks(current)
+	p.planFloatingIPs(current)
+	p.planInstances(current)
+	if err := errors.Join(p.errs...); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Project: spec.Project}
+	for i := range numStages {
+		plan.Changes = append(plan.Changes, p.changes[i]...)
+		if len(p.steps[i]) > 0 {
+			plan.stages = append(plan.stages, p.steps[i])
+		}
+	}
+	return plan, nil
+}
+
+// Apply makes the changes of a plan, a stage at a time. Applying stops after the first stage that
+// fails, and the returned error joins the errors of the steps of that stage. Since the resources
+// that were already created or deleted are skipped by a new plan, an interrupted apply can be
+// resumed by planning and applying the spec again.
+func (a *Applier) Apply(ctx context.Context, plan *Plan) error {
+	for _, steps := range plan.stages {
+		if err := stage.Run(ctx, steps, a.opts.Concurrency); err != nil {
+			return fmt.Errorf("error applying the plan of project %s: %w", plan.Project, err)
+		}
+	}
+	return nil
+}
+
+// planner computes a plan.
+type planner struct {
+	client  *oxide.Client
+	opts    Options
+	spec    *Spec
+	project oxide.NameOrId
+
+	changes [numStages][]Change
+	steps   [numStages][]stage.Step
+	// errs holds the changes that can't be made.
+	errs []error
+}
+
+// state is the current state of a project.
+type state struct {
+	vpcs        []*vpcState
+	disks       []oxide.Disk
+	instances   []oxide.Instance
+	floatingIPs []oxide.FloatingIp
+}
+
+// vpcState is the current state of a VPC.
+type vpcState struct {
+	vpc     oxide.Vpc
+	subnets []oxide.VpcSubnet
+	routers []routerState
+	rules   []oxide.VpcFirewallRule
+	// created is set for the VPCs that are created by the plan.
+	created bool
+}
+
+// routerState is the current state of a custom router.
+type routerState struct {
+	router oxide.VpcRouter
+	routes []oxide.RouterRoute
+}
+
+// add adds a change to the stage i, along with the step that makes it, if any.
+func (p *planner) add(i int, c Change, run func(ctx context.Context) error) {
+	p.changes[i] = append(p.changes[i], c)
+	if run != nil {
+		p.steps[i] = append(p.steps[i], stage.Step{Name: c.String(), Run: run})
+	}
+}
+
+// create adds the creation of a resource to a stage.
+func (p *planner) create(stage int, c Change, body any, run func(ctx context.Context) error) {
+	c.Action = ActionCreate
+	fields, err := diff(nil, body)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s: %w", c, err))
+		return
+	}
+	c.Fields = fields
+	p.add(stage, c, run)
+}
+
+// update adds the update of a resource to a stage if desired differs from current. It records an
+// error instead if one of the immutable fields differs.
+func (p *planner) update(
+	stage int,
+	c Change,
+	current, desired any,
+	immutable []string,
+	run func(ctx context.Context) error,
+) {
+	c.Action = ActionUpdate
+	fields, err := diff(current, desired)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s: %w", c, err))
+		return
+	}
+	if len(fields) == 0 {
+		return
+	}
+	for _, f := range fields {
+		if slices.Contains(immutable, f.Name) {
+			p.errs = append(p.errs, fmt.Errorf(
+				"%s: %s can't be changed from %s to %s",
+				c.address(),
+				f.Name,
+				f.Old,
+				f.New,
+			))
+		}
+	}
+	c.Fields = fields
+	p.add(stage, c, run)
+}
+
+// remove adds the deletion of a resource to a stage. A resource that is already gone counts as
+// deleted.
+func (p *planner) remove(stage int, c Change, run func(ctx context.Context) error) {
+	c.Action = ActionDelete
+	if run != nil {
+		del := run
+		run = func(ctx context.Context) error {
+			if err := del(ctx); err != nil && !errors.Is(err, oxide.ErrObjectNotFound) {
+				return err
+			}
+			return nil
+		}
+	}
+	p.add(stage, c, run)
+}
@@ -0,0 +1,768 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI serves the resources of the project "ci". Like the API, it creates a default subnet, a
+// system router and default firewall rules along with every VPC.
+type fakeAPI struct {
+	mu      sync.Mutex
+	project bool
+	// items holds the resources by collection, e.g. "disks", "vpc-subnets/web" for the subnets of
+	// the VPC web, "vpc-router-routes/web/egress" for the routes of one of its routers, or
+	// "instances/web/disks" for the disks of the instance web.
+	items map[string][]map[string]any
+	// calls holds the calls that change resources, e.g. "DELETE disks scratch".
+	calls []string
+	// fail makes the calls to change the named resources fail.
+	fail map[string]bool
+}
+
+func newFakeAPI(t *testing.T, api *fakeAPI) *oxide.Client {
+	t.Helper()
+
+	if api.items == nil {
+		api.items = make(map[string][]map[string]any)
+	}
+	api.fail = make(map[string]bool)
+	for collection, items := range api.items {
+		for _, item := range items {
+			if item["id"] == nil {
+				item["id"] = "id-" + collection + "/" + item["name"].(string)
+			}
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+		collection := parts[0]
+		for _, param := range []string{"vpc", "router", "instance"} {
+			if value := r.URL.Query().Get(param); value != "" {
+				collection += "/" + value
+			}
+		}
+		var body map[string]any
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		if r.Method != http.MethodGet {
+			name, _ := body["name"].(string)
+			if len(parts) > 1 {
+				name = parts[1]
+			}
+			api.calls = append(api.calls, strings.TrimSpace(r.Method+" "+collection+" "+name))
+			if api.fail[name] {
+				writeError(w, http.StatusBadRequest, "InvalidRequest")
+				return
+			}
+		}
+
+		switch {
+		case collection == "projects" && r.Method == http.MethodPost:
+			api.project = true
+			json.NewEncoder(w).Encode(body)
+		case collection == "projects":
+			if !api.project {
+				writeError(w, http.StatusNotFound, "ObjectNotFound")
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"name": parts[1]})
+		case parts[0] == "vpc-firewall-rules" && r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]any{"rules": api.list(collection)})
+		case parts[0] == "vpc-firewall-rules":
+			var rules []map[string]any
+			ruleBodies, _ := body["rules"].([]any)
+			for _, rule := range ruleBodies {
+				rules = append(rules, rule.(map[string]any))
+			}
+			api.items[collection] = rules
+			json.NewEncoder(w).Encode(map[string]any{"rules": rules})
+		case r.Method == http.MethodGet && len(parts) == 1:
+			json.NewEncoder(w).Encode(map[string]any{"items": api.list(collection)})
+		case r.Method == http.MethodGet && len(parts) == 3:
+			json.NewEncoder(w).Encode(map[string]any{"items": api.list(strings.Join(parts, "/"))})
+		case r.Method == http.MethodGet:
+			api.view(w, collection, parts[1])
+		case r.Method == http.MethodPost && len(parts) == 1:
+			api.create(w, collection, body)
+		case r.Method == http.MethodPost && parts[2] == "stop":
+			api.find(collection, parts[1])["run_state"] = "stopped"
+			api.view(w, collection, parts[1])
+		case r.Method == http.MethodPost && parts[2] == "start":
+			api.find(collection, parts[1])["run_state"] = "running"
+			api.view(w, collection, parts[1])
+		case r.Method == http.MethodPut:
+			item := api.find(collection, parts[1])
+			if item == nil {
+				writeError(w, http.StatusNotFound, "ObjectNotFound")
+				return
+			}
+			if collection == "instances" && item["run_state"] != "stopped" &&
+				(!sameJSON(body["ncpus"], item["ncpus"]) ||
+					!sameJSON(body["memory"], item["memory"]) ||
+					!sameJSON(body["boot_disk"], api.diskName(item["boot_disk_id"]))) {
+				writeError(w, http.StatusBadRequest, "InvalidRequest")
+				return
+			}
+			api.update(collection, item, body)
+			json.NewEncoder(w).Encode(item)
+		case r.Method == http.MethodDelete:
+			i := slices.IndexFunc(api.items[collection], func(item map[string]any) bool {
+				return item["name"] == parts[1]
+			})
+			if i < 0 {
+				writeError(w, http.StatusNotFound, "ObjectNotFound")
+				return
+			}
+			if collection == "instances" && api.items[collection][i]["run_state"] != "stopped" {
+				writeError(w, http.StatusBadRequest, "InvalidRequest")
+				return
+			}
+			api.items[collection] = slices.Delete(api.items[collection], i, i+1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := oxide.NewClient(oxide.WithHost(server.URL), oxide.WithToken("foo"))
+	require.NoError(t, err)
+	return client
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"request_id":"1","error_code":%q,"message":"failed"}`, code)
+}
+
+// sameJSON reports whether a and b have the same JSON encoding, e.g. the number 2 of a resource
+// and the number 2 of a request body, which is decoded as a float64.
+func sameJSON(a, b any) bool {
+	aj, _ := json.Marshal(a)
+	bj, _ := json.Marshal(b)
+	return string(aj) == string(bj)
+}
+
+func (api *fakeAPI) list(collection string) []map[string]any {
+	if items := api.items[collection]; items != nil {
+		return items
+	}
+	return []map[string]any{}
+}
+
+func (api *fakeAPI) find(collection, name string) map[string]any {
+	for _, item := range api.items[collection] {
+		if item["name"] == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func (api *fakeAPI) view(w http.ResponseWriter, collection, name string) {
+	item := api.find(collection, name)
+	if item == nil {
+		writeError(w, http.StatusNotFound, "ObjectNotFound")
+		return
+	}
+	json.NewEncoder(w).Encode(item)
+}
+
+func (api *fakeAPI) create(w http.ResponseWriter, collection string, body map[string]any) {
+	name := body["name"].(string)
+	if api.find(collection, name) != nil {
+		writeError(w, http.StatusBadRequest, "ObjectAlreadyExists")
+		return
+	}
+	item := map[string]any{"id": "id-" + collection + "/" + name}
+	api.update(collection, item, body)
+	api.items[collection] = append(api.items[collection], item)
+
+	switch {
+	case collection == "instances":
+		item["run_state"] = "running"
+	case strings.HasPrefix(collection, "vpc-routers/"),
+		strings.HasPrefix(collection, "vpc-router-routes/"):
+		item["kind"] = "custom"
+	case collection == "vpcs":
+		api.items["vpc-subnets/"+name] = []map[string]any{
+			{"id": "id-default", "name": "default", "ipv4_block": "172.30.0.0/22"},
+		}
+		api.items["vpc-routers/"+name] = []map[string]any{
+			{"id": "id-system", "name": "system", "kind": "system"},
+		}
+		api.items["vpc-firewall-rules/"+name] = []map[string]any{
+			{"name": "allow-internal-inbound", "action": "allow", "direction": "inbound"},
+		}
+	}
+	json.NewEncoder(w).Encode(item)
+}
+
+// diskName returns the name of the disk with the given ID, or nil if there's none.
+func (api *fakeAPI) diskName(id any) any {
+	for _, disk := range api.items["disks"] {
+		if disk["id"] == id {
+			return disk["name"]
+		}
+	}
+	return nil
+}
+
+// update sets the fields of an item, and translates the custom router of subnets and the boot disk
+// of instance updates, which are set by name, to the custom_router_id and boot_disk_id fields of
+// their view.
+func (api *fakeAPI) update(collection string, item, body map[string]any) {
+	for key, value := range body {
+		item[key] = value
+	}
+	if disk, ok := body["boot_disk"]; ok && collection == "instances" {
+		if _, ok := disk.(map[string]any); !ok {
+			delete(item, "boot_disk")
+			delete(item, "boot_disk_id")
+			if disk != nil {
+				item["boot_disk_id"] = api.find("disks", disk.(string))["id"]
+			}
+		}
+	}
+	if vpc, ok := strings.CutPrefix(collection, "vpc-subnets/"); ok {
+		delete(item, "custom_router_id")
+		if router, ok := item["custom_router"].(string); ok {
+			item["custom_router_id"] = api.find("vpc-routers/"+vpc, router)["id"]
+		}
+		delete(item, "custom_router")
+	}
+}
+
+// ciProject returns the resources of a project with two VPCs, and stale resources that the spec
+// of Test_Applier removes.
+func ciProject() map[string][]map[string]any {
+	return map[string][]map[string]any{
+		"vpcs": {
+			{"name": "web", "description": "web tier", "dns_name": "web"},
+			{"name": "legacy", "description": "", "dns_name": "legacy"},
+		},
+		"vpc-subnets/web": {
+			{"name": "frontend", "description": "lbs", "ipv4_block": "10.0.1.0/24"},
+			{"name": "old", "description": "", "ipv4_block": "10.0.9.0/24"},
+		},
+		"vpc-subnets/legacy": {
+			{"name": "default", "description": "", "ipv4_block": "172.30.0.0/22"},
+		},
+		"vpc-routers/web": {
+			{"name": "system", "kind": "system"},
+			{"name": "egress", "kind": "custom", "description": ""},
+		},
+		"vpc-routers/legacy": {
+			{"name": "system", "kind": "system"},
+		},
+		"vpc-router-routes/web/egress": {
+			{
+				"name":        "default-route",
+				"kind":        "custom",
+				"destination": map[string]any{"type": "ip_net", "value": "0.0.0.0/0"},
+				"target":      map[string]any{"type": "internet_gateway", "value": "default"},
+			},
+			{
+				"name":        "stale",
+				"kind":        "custom",
+				"destination": map[string]any{"type": "ip_net", "value": "10.9.0.0/16"},
+				"target":      map[string]any{"type": "drop"},
+			},
+		},
+		"vpc-firewall-rules/web": {
+			{
+				"name":      "allow-ssh",
+				"action":    "allow",
+				"direction": "inbound",
+				"priority":  100,
+				"status":    "enabled",
+				"targets":   []any{map[string]any{"type": "vpc", "value": "web"}},
+				"filters": map[string]any{
+					"hosts":     nil,
+					"ports":     []any{"22"},
+					"protocols": []any{map[string]any{"type": "tcp"}},
+				},
+			},
+			{
+				"name":      "allow-icmp",
+				"action":    "allow",
+				"direction": "inbound",
+				"priority":  100,
+				"status":    "enabled",
+				"targets":   []any{map[string]any{"type": "vpc", "value": "web"}},
+				"filters": map[string]any{
+					"protocols": []any{map[string]any{"type": "icmp"}},
+				},
+			},
+		},
+		"disks": {
+			{"name": "data", "description": "data", "size": 1 << 30},
+			{"name": "scratch", "description": "", "size": 1 << 30},
+			{"name": "web-boot", "description": "", "size": 10 << 30},
+		},
+		"instances": {
+			{
+				"name":         "web",
+				"description":  "web server",
+				"hostname":     "web",
+				"ncpus":        2,
+				"memory":       4 << 30,
+				"run_state":    "stopped",
+				"boot_disk_id": "id-disks/web-boot",
+			},
+			{
+				"name":        "batch",
+				"description": "",
+				"hostname":    "batch",
+				"ncpus":       1,
+				"memory":      1 << 30,
+				"run_state":   "running",
+			},
+		},
+		"floating-ips": {
+			{"name": "web-ip", "description": "old"},
+		},
+	}
+}
+
+const ciSpec = `
+project: ci
+vpcs:
+  - name: web
+    description: web tier
+    dns_name: web
+    subnets:
+      - name: frontend
+        description: load balancers
+        ipv4_block: 10.0.1.0/24
+        custom_router: egress
+      - name: backend
+        description: app servers
+        ipv4_block: 10.0.2.0/24
+    routers:
+      - name: egress
+        description: ""
+        routes:
+          - name: default-route
+            description: ""
+            destination: {type: ip_net, value: 0.0.0.0/0}
+            target: {type: internet_gateway, value: default}
+    firewall_rules:
+      - name: allow-ssh
+        description: ""
+        action: allow
+        direction: inbound
+        priority: 100
+        status: enabled
+        targets: [{type: vpc, value: web}]
+        filters:
+          ports: ["22", "2222"]
+          protocols: [{type: tcp}]
+      - name: allow-https
+        description: ""
+        action: allow
+        direction: inbound
+        priority: 100
+        status: enabled
+        targets: [{type: subnet, value: frontend}]
+        filters:
+          ports: ["443"]
+          protocols: [{type: tcp}]
+disks:
+  - name: data
+    description: data
+    size: 1073741824
+    disk_backend:
+      type: distributed
+      disk_source: {type: blank, block_size: 4096}
+instances:
+  - name: web
+    description: web server
+    hostname: web
+    ncpus: 4
+    memory: 4294967296
+    boot_disk:
+      type: create
+      name: web-boot
+      description: ""
+      size: 10737418240
+      disk_backend:
+        type: distributed
+        disk_source: {type: blank, block_size: 4096}
+  - name: worker
+    description: worker
+    hostname: worker
+    ncpus: 2
+    memory: 2147483648
+floating_ips:
+  - name: web-ip
+    description: web frontend
+`
+
+func loadSpec(t *testing.T, doc string) *Spec {
+	t.Helper()
+
+	spec, err := Load(strings.NewReader(doc))
+	require.NoError(t, err)
+	return spec
+}
+
+func Test_Applier(t *testing.T) {
+	ctx := context.Background()
+	opts := Options{
+		Concurrency: 1,
+		Wait:        []oxide.WaitOption{oxide.WithWaitBackoff(time.Millisecond, time.Millisecond)},
+	}
+
+	t.Run("reconciles a project", func(t *testing.T) {
+		api := &fakeAPI{project: true, items: ciProject()}
+		applier := New(newFakeAPI(t, api), opts)
+		spec := loadSpec(t, ciSpec)
+
+		plan, err := applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.Equal(t, `- instance batch
+
+- router route stale (VPC web, router egress)
+
+- disk scratch
+
+- VPC subnet old (VPC web)
+
+- VPC subnet default (VPC legacy)
+
+~ floating IP web-ip
+    description = "old" -> "web frontend"
+
+~ VPC subnet frontend (VPC web)
+    custom_router = null -> "egress"
+    description   = "lbs" -> "load balancers"
+
++ VPC subnet backend (VPC web)
+    description = "app servers"
+    ipv4_block  = "10.0.2.0/24"
+    name        = "backend"
+
+- VPC legacy
+
+~ instance web
+    ncpus = 2 -> 4
+
++ instance worker
+    description = "worker"
+    hostname    = "worker"
+    memory      = 2147483648
+    name        = "worker"
+    ncpus       = 2
+
+~ firewall rule allow-ssh (VPC web)
+    filters = {"ports":["22"],"protocols":[{"type":"tcp"}]} -> {"ports":["22","2222"],"protocols":[{"type":"tcp"}]}
+
++ firewall rule allow-https (VPC web)
+    action    = "allow"
+    direction = "inbound"
+    filters   = {"ports":["443"],"protocols":[{"type":"tcp"}]}
+    name      = "allow-https"
+    priority  = 100
+    status    = "enabled"
+    targets   = [{"type":"subnet","value":"frontend"}]
+
+- firewall rule allow-icmp (VPC web)
+
+Plan: 3 to create, 4 to update, 7 to delete.
+`, plan.String())
+		assert.Empty(t, api.calls)
+
+		require.NoError(t, applier.Apply(ctx, plan))
+		assert.Equal(t, []string{
+			"POST instances batch",
+			"DELETE instances batch",
+			"DELETE vpc-router-routes/web/egress stale",
+			"DELETE disks scratch",
+			"DELETE vpc-subnets/web old",
+			"DELETE vpc-subnets/legacy default",
+			"PUT floating-ips web-ip",
+			"PUT vpc-subnets/web frontend",
+			"POST vpc-subnets/web backend",
+			"DELETE vpcs legacy",
+			"PUT instances web",
+			"POST instances worker",
+			"PUT vpc-firewall-rules/web",
+		}, api.calls)
+
+		plan, err = applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+		assert.Equal(t, "No changes: project ci matches the spec.\n", plan.String())
+	})
+
+	t.Run("creates a project", func(t *testing.T) {
+		api := &fakeAPI{}
+		applier := New(newFakeAPI(t, api), opts)
+		spec := loadSpec(t, `
+project: ci
+vpcs:
+  - name: web
+    description: web tier
+    dns_name: web
+    subnets:
+      - name: default
+        description: default subnet
+        ipv4_block: 172.30.0.0/22
+    routers:
+      - name: egress
+        description: ""
+        routes:
+          - name: blackhole
+            description: ""
+            destination: {type: subnet, value: default}
+            target: {type: drop}
+`)
+
+		plan, err := applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.Equal(t, `+ project ci
+    name = "ci"
+
++ VPC web
+    description = "web tier"
+    dns_name    = "web"
+    name        = "web"
+
++ VPC router egress (VPC web)
+    name = "egress"
+
++ VPC subnet default (VPC web)
+    description = "default subnet"
+    ipv4_block  = "172.30.0.0/22"
+    name        = "default"
+
++ router route blackhole (VPC web, router egress)
+    destination = {"type":"subnet","value":"default"}
+    name        = "blackhole"
+    target      = {"type":"drop"}
+
+Plan: 5 to create, 0 to update, 0 to delete.
+`, plan.String())
+
+		require.NoError(t, applier.Apply(ctx, plan))
+		assert.Equal(t, []string{
+			"POST projects ci",
+			"POST vpcs web",
+			"POST vpc-routers/web egress",
+			// The default subnet is created along with the VPC, and updated instead.
+			"POST vpc-subnets/web default",
+			"PUT vpc-subnets/web default",
+			"POST vpc-router-routes/web/egress blackhole",
+			// The default firewall rules are replaced.
+			"PUT vpc-firewall-rules/web",
+		}, api.calls)
+		assert.Empty(t, api.items["vpc-firewall-rules/web"])
+
+		plan, err = applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+	})
+
+	t.Run("rejects other blocks for the default subnet of a new VPC", func(t *testing.T) {
+		api := &fakeAPI{project: true}
+		applier := New(newFakeAPI(t, api), opts)
+		spec := loadSpec(t, `
+project: ci
+vpcs:
+  - name: web
+    description: web tier
+    dns_name: web
+    subnets:
+      - name: default
+        description: default subnet
+        ipv4_block: 10.0.0.0/24
+`)
+
+		plan, err := applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.EqualError(
+			t,
+			applier.Apply(ctx, plan),
+			"error applying the plan of project ci: create VPC subnet default (VPC web): "+
+				`ipv4_block can't be changed from "172.30.0.0/22" to "10.0.0.0/24"`,
+		)
+		assert.NotContains(t, api.calls, "PUT vpc-subnets/web default")
+	})
+
+	t.Run("leaves the default subnet alone unless it's listed", func(t *testing.T) {
+		api := &fakeAPI{project: true}
+		applier := New(newFakeAPI(t, api), opts)
+		spec := loadSpec(t, `
+project: ci
+vpcs:
+  - name: web
+    description: web tier
+    dns_name: web
+    subnets:
+      - name: frontend
+        description: load balancers
+        ipv4_block: 10.0.1.0/24
+`)
+
+		plan, err := applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		require.NoError(t, applier.Apply(ctx, plan))
+		assert.Equal(t, []string{
+			"POST vpcs web",
+			"POST vpc-subnets/web frontend",
+			"PUT vpc-firewall-rules/web",
+		}, api.calls)
+		assert.NotNil(t, api.find("vpc-subnets/web", "default"))
+
+		// The default subnet created along with the VPC isn't deleted by the next plan.
+		plan, err = applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+	})
+
+	t.Run("restarts running instances to resize them", func(t *testing.T) {
+		items := ciProject()
+		items["instances"][0]["run_state"] = "running"
+		api := &fakeAPI{project: true, items: items}
+		applier := New(newFakeAPI(t, api), opts)
+		spec := loadSpec(t, ciSpec)
+
+		plan, err := applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.Contains(t, plan.String(), `~ instance web
+    ncpus = 2 -> 4
+    (stopped and started again to apply the update)
+`)
+
+		require.NoError(t, applier.Apply(ctx, plan))
+		i := slices.Index(api.calls, "PUT instances web")
+		require.Positive(t, i)
+		// The instance is stopped before the update and started after it.
+		assert.Equal(t, []string{
+			"POST instances web",
+			"PUT instances web",
+			"POST instances web",
+		}, api.calls[i-1:i+2])
+		web := api.find("instances", "web")
+		assert.Equal(t, "running", web["run_state"])
+		assert.EqualValues(t, 4, web["ncpus"])
+
+		plan, err = applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+	})
+
+	t.Run("restarts running instances to change their boot disk", func(t *testing.T) {
+		items := ciProject()
+		items["instances"][0]["run_state"] = "running"
+		api := &fakeAPI{project: true, items: items}
+		applier := New(newFakeAPI(t, api), opts)
+		spec := loadSpec(t, ciSpec)
+		web := &spec.Instances[0]
+		web.Ncpus = 2
+		web.Disks = []oxide.InstanceDiskAttachment{web.BootDisk}
+		web.BootDisk = oxide.InstanceDiskAttachment{
+			Value: &oxide.InstanceDiskAttachmentAttach{Name: "data"},
+		}
+
+		plan, err := applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.Contains(t, plan.String(), `~ instance web
+    boot_disk = "web-boot" -> "data"
+    (stopped and started again to apply the update)
+`)
+
+		require.NoError(t, applier.Apply(ctx, plan))
+		instance := api.find("instances", "web")
+		assert.Equal(t, "running", instance["run_state"])
+		assert.Equal(t, "id-disks/data", instance["boot_disk_id"])
+
+		plan, err = applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+	})
+
+	t.Run("keeps the boot disk when the spec leaves it out", func(t *testing.T) {
+		api := &fakeAPI{project: true, items: ciProject()}
+		applier := New(newFakeAPI(t, api), opts)
+		spec := loadSpec(t, ciSpec)
+		web := &spec.Instances[0]
+		web.Disks = []oxide.InstanceDiskAttachment{web.BootDisk}
+		web.BootDisk = oxide.InstanceDiskAttachment{}
+
+		plan, err := applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.Contains(t, plan.String(), `~ instance web
+    ncpus = 2 -> 4
+`)
+
+		require.NoError(t, applier.Apply(ctx, plan))
+		assert.Equal(t, "id-disks/web-boot", api.find("instances", "web")["boot_disk_id"])
+
+		plan, err = applier.Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty(), plan.String())
+	})
+
+	t.Run("rejects changes to immutable fields", func(t *testing.T) {
+		api := &fakeAPI{project: true, items: ciProject()}
+		applier := New(newFakeAPI(t, api), opts)
+		spec := loadSpec(t, ciSpec)
+		spec.Vpcs[0].Subnets[0].Ipv4Block = "10.0.3.0/24"
+		spec.Disks[0].Size = 2 << 30
+
+		_, err := applier.Plan(ctx, spec)
+		assert.ErrorContains(
+			t,
+			err,
+			`VPC subnet frontend (VPC web): ipv4_block can't be changed from "10.0.1.0/24" to "10.0.3.0/24"`,
+		)
+		assert.ErrorContains(
+			t,
+			err,
+			"disk data: size can't be changed from 1073741824 to 2147483648",
+		)
+	})
+
+	t.Run("stops after a stage fails", func(t *testing.T) {
+		api := &fakeAPI{project: true, items: ciProject()}
+		applier := New(newFakeAPI(t, api), opts)
+		api.fail["scratch"] = true
+
+		plan, err := applier.Plan(ctx, loadSpec(t, ciSpec))
+		require.NoError(t, err)
+		err = applier.Apply(ctx, plan)
+		assert.ErrorContains(t, err, "error applying the plan of project ci: delete disk scratch: ")
+		assert.NotContains(t, api.calls, "DELETE vpc-subnets/web old")
+
+		// Planning again resumes where applying stopped.
+		api.fail = map[string]bool{}
+		plan, err = applier.Plan(ctx, loadSpec(t, ciSpec))
+		require.NoError(t, err)
+		assert.Equal(t, "delete disk scratch", plan.Changes[0].String())
+		require.NoError(t, applier.Apply(ctx, plan))
+	})
+}
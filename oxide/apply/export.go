@@ -0,0 +1,266 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// Export returns the spec of an existing project, e.g. to snapshot a project built by hand, or to
+// copy its resources to another project by changing the Project of the spec before applying it.
+//
+// The spec is portable: it leaves out the IDs, timestamps and states of the resources, and the
+// resources they reference, such as the custom routers of subnets or the disks and subnets of
+// instances, are referenced by name. The addresses that the API assigns when they aren't set are
+// left out too, i.e. the IPv6 prefixes of VPCs and subnets, the addresses of network interfaces,
+// and the addresses and pools of floating and ephemeral IPs. The only IDs left are the images and
+// snapshots that disks are created from, since disk sources can't reference them by name.
+//
+// The disks of the project are exported as standalone disks, which the instances attach.
+func Export(ctx context.Context, client *oxide.Client, project oxide.NameOrId) (*Spec, error) {
+	p, err := client.ProjectView(ctx, oxide.ProjectViewParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error viewing project %s: %w", project, err)
+	}
+	project = oxide.NameOrId(p.Name)
+	current, err := fetchState(ctx, client, project)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{Project: p.Name}
+	for _, v := range current.vpcs {
+		spec.Vpcs = append(spec.Vpcs, exportVpc(v))
+	}
+	for _, disk := range current.disks {
+		spec.Disks = append(spec.Disks, exportDisk(disk))
+	}
+	for _, ip := range current.floatingIPs {
+		spec.FloatingIps = append(spec.FloatingIps, oxide.FloatingIpCreate{
+			Description: ip.Description,
+			Name:        ip.Name,
+		})
+	}
+
+	e := exporter{
+		client:      client,
+		project:     project,
+		vpcNames:    make(map[string]oxide.Name),
+		subnetNames: make(map[string]oxide.Name),
+		diskNames:   make(map[string]oxide.Name),
+	}
+	for _, v := range current.vpcs {
+		e.vpcNames[v.vpc.Id] = v.vpc.Name
+		for _, subnet := range v.subnets {
+			e.subnetNames[subnet.Id] = subnet.Name
+		}
+	}
+	for _, disk := range current.disks {
+		e.diskNames[disk.Id] = disk.Name
+	}
+	for _, instance := range current.instances {
+		body, err := e.exportInstance(ctx, instance)
+		if err != nil {
+			return nil, err
+		}
+		spec.Instances = append(spec.Instances, body)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("error exporting project %s: %w", p.Name, err)
+	}
+	return spec, nil
+}
+
+// exportVpc returns the spec of a VPC.
+func exportVpc(v *vpcState) Vpc {
+	vpc := Vpc{
+		VpcCreate: oxide.VpcCreate{
+			Description: v.vpc.Description,
+			DnsName:     v.vpc.DnsName,
+			Name:        v.vpc.Name,
+		},
+	}
+
+	routerNames := make(map[string]oxide.Name)
+	for _, r := range v.routers {
+		routerNames[r.router.Id] = r.router.Name
+		router := Router{
+			VpcRouterCreate: oxide.VpcRouterCreate{
+				Description: r.router.Description,
+				Name:        r.router.Name,
+			},
+		}
+		for _, route := range r.routes {
+			router.Routes = append(router.Routes, oxide.RouterRouteCreate{
+				Description: route.Description,
+				Destination: route.Destination,
+				Name:        route.Name,
+				Target:      route.Target,
+			})
+		}
+		vpc.Routers = append(vpc.Routers, router)
+	}
+
+	for _, subnet := range v.subnets {
+		vpc.Subnets = append(vpc.Subnets, oxide.VpcSubnetCreate{
+			CustomRouter: oxide.NameOrId(routerNames[subnet.CustomRouterId]),
+			Description:  subnet.Description,
+			Ipv4Block:    subnet.Ipv4Block,
+			Name:         subnet.Name,
+		})
+	}
+	for _, rule := range v.rules {
+		vpc.FirewallRules = append(vpc.FirewallRules, rule.ToUpdate())
+	}
+	return vpc
+}
+
+// exportDisk returns the spec of a disk.
+func exportDisk(disk oxide.Disk) oxide.DiskCreate {
+	body := oxide.DiskCreate{
+		Description: disk.Description,
+		Name:        disk.Name,
+		Size:        disk.Size,
+	}
+	if disk.DiskType == oxide.DiskTypeLocal {
+		body.DiskBackend.Value = &oxide.DiskBackendLocal{}
+		return body
+	}
+
+	var source oxide.DiskSource
+	switch {
+	case disk.ImageId != "":
+		source.Value = &oxide.DiskSourceImage{ImageId: disk.ImageId, ReadOnly: readOnly(disk)}
+	case disk.SnapshotId != "":
+		source.Value = &oxide.DiskSourceSnapshot{
+			ReadOnly:   readOnly(disk),
+			SnapshotId: disk.SnapshotId,
+		}
+	default:
+		source.Value = &oxide.DiskSourceBlank{BlockSize: disk.BlockSize}
+	}
+	body.DiskBackend.Value = &oxide.DiskBackendDistributed{DiskSource: source}
+	return body
+}
+
+// readOnly returns the read-only flag of a disk, or nil if it isn't read-only.
+func readOnly(disk oxide.Disk) *bool {
+	if disk.ReadOnly != nil && *disk.ReadOnly {
+		return disk.ReadOnly
+	}
+	return nil
+}
+
+// exporter maps the IDs of the resources of a project to their names.
+type exporter struct {
+	client  *oxide.Client
+	project oxide.NameOrId
+
+	vpcNames    map[string]oxide.Name
+	subnetNames map[string]oxide.Name
+	diskNames   map[string]oxide.Name
+}
+
+// exportInstance returns the spec of an instance, which attaches its disks by name and creates
+// its network interfaces and external IPs.
+func (e *exporter) exportInstance(
+	ctx context.Context,
+	instance oxide.Instance,
+) (oxide.InstanceCreate, error) {
+	body := oxide.InstanceCreate{
+		AutoRestartPolicy: instance.AutoRestartPolicy,
+		CpuPlatform:       instance.CpuPlatform,
+		Description:       instance.Description,
+		EnableJumboFrames: instance.EnableJumboFrames,
+		Hostname:          oxide.Hostname(instance.Hostname),
+		Memory:            instance.Memory,
+		Name:              instance.Name,
+		Ncpus:             instance.Ncpus,
+	}
+	if instance.RunState == oxide.InstanceStateStopped {
+		body.Start = oxide.NewPointer(false)
+	}
+	if boot, ok := e.diskNames[instance.BootDiskId]; ok {
+		body.BootDisk.Value = &oxide.InstanceDiskAttachmentAttach{Name: boot}
+	}
+	name := oxide.NameOrId(instance.Name)
+
+	disks, err := e.client.InstanceDiskListAllPages(ctx, oxide.InstanceDiskListParams{
+		Project:  e.project,
+		Instance: name,
+	})
+	if err != nil {
+		return body, fmt.Errorf("error listing disks of instance %s: %w", instance.Name, err)
+	}
+	for _, disk := range disks {
+		if disk.Id == instance.BootDiskId {
+			continue
+		}
+		body.Disks = append(body.Disks, oxide.InstanceDiskAttachment{
+			Value: &oxide.InstanceDiskAttachmentAttach{Name: disk.Name},
+		})
+	}
+
+	nics, err := e.client.InstanceNetworkInterfaceListAllPages(
+		ctx,
+		oxide.InstanceNetworkInterfaceListParams{Project: e.project, Instance: name},
+	)
+	if err != nil {
+		return body, fmt.Errorf(
+			"error listing network interfaces of instance %s: %w",
+			instance.Name,
+			err,
+		)
+	}
+	if len(nics) == 0 {
+		body.NetworkInterfaces.Value = &oxide.InstanceNetworkInterfaceAttachmentNone{}
+	} else {
+		// The first network interface of an instance is its primary interface.
+		if i := slices.IndexFunc(nics, func(nic oxide.InstanceNetworkInterface) bool {
+			return nic.Primary != nil && *nic.Primary
+		}); i > 0 {
+			primary := nics[i]
+			nics = slices.Insert(slices.Delete(nics, i, i+1), 0, primary)
+		}
+		attachment := &oxide.InstanceNetworkInterfaceAttachmentCreate{}
+		for _, nic := range nics {
+			attachment.Params = append(attachment.Params, oxide.InstanceNetworkInterfaceCreate{
+				Description: nic.Description,
+				Name:        nic.Name,
+				SubnetName:  e.subnetNames[nic.SubnetId],
+				VpcName:     e.vpcNames[nic.VpcId],
+			})
+		}
+		body.NetworkInterfaces.Value = attachment
+	}
+
+	ips, err := e.client.InstanceExternalIpList(ctx, oxide.InstanceExternalIpListParams{
+		Project:  e.project,
+		Instance: name,
+	})
+	if err != nil {
+		return body, fmt.Errorf("error listing external IPs of instance %s: %w", instance.Name, err)
+	}
+	for _, ip := range ips.Items {
+		// SNAT addresses are allocated along with the instance.
+		if floating, ok := ip.AsFloating(); ok {
+			body.ExternalIps = append(body.ExternalIps, oxide.ExternalIpCreate{
+				Value: &oxide.ExternalIpCreateFloating{
+					FloatingIp: oxide.NameOrId(floating.Name),
+				},
+			})
+		} else if _, ok := ip.AsEphemeral(); ok {
+			body.ExternalIps = append(body.ExternalIps, oxide.ExternalIpCreate{
+				Value: &oxide.ExternalIpCreateEphemeral{},
+			})
+		}
+	}
+	return body, nil
+}
@@ -0,0 +1,248 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apply
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// exportProject returns the resources of a project whose instances have disks, network
+// interfaces and external IPs.
+func exportProject() map[string][]map[string]any {
+	return map[string][]map[string]any{
+		"vpcs": {
+			{
+				"name":         "web",
+				"description":  "web tier",
+				"dns_name":     "web",
+				"ipv6_prefix":  "fd12:3456:789a::/48",
+				"time_created": "2025-01-01T00:00:00Z",
+			},
+		},
+		"vpc-subnets/web": {
+			{
+				"name":             "frontend",
+				"description":      "load balancers",
+				"ipv4_block":       "10.0.1.0/24",
+				"ipv6_block":       "fd12:3456:789a:1::/64",
+				"custom_router_id": "id-vpc-routers/web/egress",
+			},
+		},
+		"vpc-routers/web": {
+			{"name": "system", "kind": "system", "description": "system router"},
+			{"name": "egress", "kind": "custom", "description": "egress"},
+		},
+		"vpc-router-routes/web/egress": {
+			{
+				"name":        "default-route",
+				"kind":        "custom",
+				"description": "",
+				"destination": map[string]any{"type": "ip_net", "value": "0.0.0.0/0"},
+				"target":      map[string]any{"type": "internet_gateway", "value": "default"},
+			},
+		},
+		"vpc-firewall-rules/web": {
+			{
+				"name":          "allow-https",
+				"description":   "",
+				"action":        "allow",
+				"direction":     "inbound",
+				"priority":      100,
+				"status":        "enabled",
+				"time_modified": "2025-01-01T00:00:00Z",
+				"vpc_id":        "id-vpcs/web",
+				"targets":       []any{map[string]any{"type": "subnet", "value": "frontend"}},
+				"filters": map[string]any{
+					"ports":     []any{"443"},
+					"protocols": []any{map[string]any{"type": "tcp"}},
+				},
+			},
+		},
+		"disks": {
+			{
+				"name":        "web-boot",
+				"description": "",
+				"size":        10 << 30,
+				"block_size":  4096,
+				"disk_type":   "distributed",
+				"image_id":    "id-images/debian",
+				"state":       map[string]any{"state": "attached", "instance": "id-instances/web"},
+			},
+			{
+				"name":        "data",
+				"description": "data",
+				"size":        1 << 30,
+				"block_size":  4096,
+				"disk_type":   "distributed",
+			},
+		},
+		"instances": {
+			{
+				"name":         "web",
+				"description":  "web server",
+				"hostname":     "web",
+				"ncpus":        2,
+				"memory":       4 << 30,
+				"run_state":    "running",
+				"boot_disk_id": "id-disks/web-boot",
+			},
+		},
+		"instances/web/disks": {
+			{"name": "data", "id": "id-disks/data"},
+			{"name": "web-boot", "id": "id-disks/web-boot"},
+		},
+		"network-interfaces/web": {
+			{
+				"name":        "net0",
+				"description": "",
+				"primary":     true,
+				"subnet_id":   "id-vpc-subnets/web/frontend",
+				"vpc_id":      "id-vpcs/web",
+				"mac":         "A8:40:25:F0:00:01",
+			},
+		},
+		"instances/web/external-ips": {
+			{"name": "web-ip", "kind": "floating", "ip": "192.0.2.10"},
+			{"name": "ephemeral", "kind": "ephemeral", "ip": "192.0.2.11"},
+			{"name": "snat", "kind": "snat", "ip": "192.0.2.12"},
+		},
+		"floating-ips": {
+			{"name": "web-ip", "description": "web frontend", "ip": "192.0.2.10"},
+		},
+	}
+}
+
+func Test_Export(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("exports a project", func(t *testing.T) {
+		client := newFakeAPI(t, &fakeAPI{project: true, items: exportProject()})
+
+		spec, err := Export(ctx, client, "ci")
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		enc := yaml.NewEncoder(&b)
+		enc.SetIndent(2)
+		require.NoError(t, enc.Encode(spec))
+		assert.Equal(t, `project: ci
+vpcs:
+  - description: web tier
+    dns_name: web
+    name: web
+    subnets:
+      - custom_router: egress
+        description: load balancers
+        ipv4_block: 10.0.1.0/24
+        name: frontend
+    routers:
+      - description: egress
+        name: egress
+        routes:
+          - description: ""
+            destination:
+              type: ip_net
+              value: 0.0.0.0/0
+            name: default-route
+            target:
+              type: internet_gateway
+              value: default
+    firewall_rules:
+      - action: allow
+        description: ""
+        direction: inbound
+        filters:
+          ports:
+            - "443"
+          protocols:
+            - type: tcp
+        name: allow-https
+        priority: 100
+        status: enabled
+        targets:
+          - type: subnet
+            value: frontend
+disks:
+  - description: ""
+    disk_backend:
+      disk_source:
+        image_id: id-images/debian
+        type: image
+      type: distributed
+    name: web-boot
+    size: 10737418240
+  - description: data
+    disk_backend:
+      disk_source:
+        block_size: 4096
+        type: blank
+      type: distributed
+    name: data
+    size: 1073741824
+instances:
+  - boot_disk:
+      name: web-boot
+      type: attach
+    description: web server
+    disks:
+      - name: data
+        type: attach
+    external_ips:
+      - floating_ip: web-ip
+        type: floating
+      - type: ephemeral
+    hostname: web
+    memory: 4294967296
+    name: web
+    ncpus: 2
+    network_interfaces:
+      params:
+        - description: ""
+          name: net0
+          subnet_name: frontend
+          vpc_name: web
+      type: create
+floating_ips:
+  - description: web frontend
+    name: web-ip
+`, b.String())
+
+		loaded, err := Load(&b)
+		require.NoError(t, err)
+		assert.Equal(t, spec.Project, loaded.Project)
+		assert.Len(t, loaded.Instances, 1)
+	})
+
+	t.Run("exports a spec that matches the project", func(t *testing.T) {
+		api := &fakeAPI{project: true, items: ciProject()}
+		client := newFakeAPI(t, api)
+
+		spec, err := Export(ctx, client, "ci")
+		require.NoError(t, err)
+		data, err := yaml.Marshal(spec)
+		require.NoError(t, err)
+		spec, err = Load(bytes.NewReader(data))
+		require.NoError(t, err)
+
+		plan, err := New(client, Options{}).Plan(ctx, spec)
+		require.NoError(t, err)
+		assert.True(t, plan.Empty(), "unexpected plan:\n%s", plan)
+	})
+
+	t.Run("fails for a missing project", func(t *testing.T) {
+		client := newFakeAPI(t, &fakeAPI{})
+
+		_, err := Export(ctx, client, "ci")
+		require.ErrorIs(t, err, oxide.ErrObjectNotFound)
+		assert.Contains(t, err.Error(), "error viewing project ci")
+	})
+}
@@ -0,0 +1,283 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/oxidecomputer/oxide.go/internal/stage"
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// Action is the action of a Change.
+type Action string
+
+const (
+	// ActionCreate creates a resource.
+	ActionCreate Action = "create"
+
+	// ActionUpdate updates a resource.
+	ActionUpdate Action = "update"
+
+	// ActionDelete deletes a resource.
+	ActionDelete Action = "delete"
+)
+
+// Change is a change to a resource of a project.
+type Change struct {
+	// Action is the action of the change.
+	Action Action
+
+	// Kind is the kind of the resource, e.g. "instance" or "VPC subnet".
+	Kind string
+
+	// Name is the name of the resource.
+	Name oxide.Name
+
+	// Vpc is the name of the VPC of the resource, for the resources of a VPC.
+	Vpc oxide.Name
+
+	// Router is the name of the router of the resource, for routes.
+	Router oxide.Name
+
+	// Fields are the fields set by a create or changed by an update, sorted by name.
+	Fields []FieldChange
+
+	// Restart is set for the updates of running instances that change their CPUs, memory, boot
+	// disk or CPU platform, which can only be changed while the instance is stopped. The instance
+	// is stopped, updated and started again.
+	Restart bool
+}
+
+// FieldChange is a change to a field of a resource.
+type FieldChange struct {
+	// Name is the name of the field, e.g. "description".
+	Name string
+
+	// Old is the JSON encoding of the current value, or "null" if the field isn't set. It's empty
+	// for creates.
+	Old string
+
+	// New is the JSON encoding of the value in the spec, or "null" if the field isn't set.
+	New string
+}
+
+// String describes the change, e.g. "create VPC subnet default (VPC default)".
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s", c.Action, c.address())
+}
+
+// address identifies the resource of the change.
+func (c Change) address() string {
+	s := fmt.Sprintf("%s %s", c.Kind, c.Name)
+	switch {
+	case c.Router != "":
+		s += fmt.Sprintf(" (VPC %s, router %s)", c.Vpc, c.Router)
+	case c.Vpc != "":
+		s += fmt.Sprintf(" (VPC %s)", c.Vpc)
+	}
+	return s
+}
+
+// Plan is the set of changes that make a project match a spec.
+type Plan struct {
+	// Project is the project the plan applies to.
+	Project oxide.Name
+
+	// Changes are the changes, in the order they are applied.
+	Changes []Change
+
+	// stages holds the steps that make the changes. The steps of a stage run concurrently, once
+	// all the steps of the previous stages have completed.
+	stages [][]stage.Step
+}
+
+// Empty reports whether the project already matches the spec.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String formats the plan as a diff: every change is prefixed with "+" for creates, "~" for
+// updates and "-" for deletes, followed by the fields it sets or changes, e.g.
+//
+//	~ VPC subnet frontend (VPC web)
+//	    description = "load balancers" -> "load balancers and proxies"
+//
+//	- disk scratch
+//
+//	Plan: 0 to create, 1 to update, 1 to delete.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return fmt.Sprintf("No changes: project %s matches the spec.\n", p.Project)
+	}
+
+	var b strings.Builder
+	counts := make(map[Action]int)
+	for _, c := range p.Changes {
+		counts[c.Action]++
+		fmt.Fprintf(&b, "%s %s\n", actionSymbols[c.Action], c.address())
+
+		width := 0
+		for _, f := range c.Fields {
+			width = max(width, len(f.Name))
+		}
+		for _, f := range c.Fields {
+			if c.Action == ActionUpdate {
+				fmt.Fprintf(&b, "    %-*s = %s -> %s\n", width, f.Name, f.Old, f.New)
+			} else {
+				fmt.Fprintf(&b, "    %-*s = %s\n", width, f.Name, f.New)
+			}
+		}
+		if c.Restart {
+			b.WriteString("    (stopped and started again to apply the update)\n")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(
+		&b,
+		"Plan: %d to create, %d to update, %d to delete.\n",
+		counts[ActionCreate],
+		counts[ActionUpdate],
+		counts[ActionDelete],
+	)
+	return b.String()
+}
+
+var actionSymbols = map[Action]string{
+	ActionCreate: "+",
+	ActionUpdate: "~",
+	ActionDelete: "-",
+}
+
+// The stages of a plan. Deletions come first, so that a resource can be replaced by a resource
+// with different dependencies, and every stage only depends on the previous ones: e.g. subnets
+// are created after the custom routers they use, and the routers that are deleted are only
+// deleted once the subnets don't use them anymore.
+const (
+	stageCreateProject = iota
+	stageStopInstances
+	stageDeleteInstances
+	// stageDeleteDetached deletes the resources that only instances depend on, and routes.
+	stageDeleteDetached
+	stageDeleteSubnets
+	// stageCreateVpcs creates and updates VPCs, disks and floating IPs.
+	stageCreateVpcs
+	stageCreateRouters
+	stageCreateSubnets
+	stageDeleteRouters
+	stageDeleteVpcs
+	stageCreateInstances
+	// stageCreateRules updates the firewall rules, and creates and updates routes, which can
+	// reference subnets and instances.
+	stageCreateRules
+	numStages
+)
+
+// diff compares the JSON encodings of current and desired, and returns the fields that differ,
+// sorted by name. Fields that are null or empty are considered unset. current is nil for
+// creates.
+func diff(current, desired any) ([]FieldChange, error) {
+	old, err := jsonFields(current)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := jsonFields(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := slices.Collect(maps.Keys(fields))
+	for name := range old {
+		if _, ok := fields[name]; !ok {
+			keys = append(keys, name)
+		}
+	}
+	slices.Sort(keys)
+
+	var changes []FieldChange
+	for _, name := range keys {
+		o, n := old[name], fields[name]
+		if o == n {
+			continue
+		}
+		change := FieldChange{Name: name, Old: o, New: n}
+		if current != nil && o == "" {
+			change.Old = "null"
+		}
+		if n == "" {
+			change.New = "null"
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// jsonFields returns the canonical JSON encoding of the fields of v that are set.
+func jsonFields(v any) (map[string]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var object map[string]any
+	if err := dec.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	for name, value := range object {
+		value = prune(value)
+		if value == nil {
+			continue
+		}
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(value); err != nil {
+			return nil, err
+		}
+		fields[name] = strings.TrimSuffix(b.String(), "\n")
+	}
+	return fields, nil
+}
+
+// prune removes the null and empty values of a decoded JSON value, and returns nil if nothing is
+// left.
+func prune(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for name, value := range v {
+			if value = prune(value); value == nil {
+				delete(v, name)
+			} else {
+				v[name] = value
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	case []any:
+		for i := range v {
+			v[i] = prune(v[i])
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	case string:
+		if v == "" {
+			return nil
+		}
+	}
+	return v
+}
@@ -0,0 +1,839 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/oxidecomputer/oxide.go/internal/stage"
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// fetch returns the current state of the project, and plans its creation if it doesn't exist.
+func (p *planner) fetch(ctx context.Context) (*state, error) {
+	_, err := p.client.ProjectView(ctx, oxide.ProjectViewParams{Project: p.project})
+	if errors.Is(err, oxide.ErrObjectNotFound) {
+		body := oxide.ProjectCreate{Name: p.spec.Project}
+		p.create(
+			stageCreateProject,
+			Change{Kind: "project", Name: p.spec.Project},
+			body,
+			func(ctx context.Context) error {
+				_, err := p.client.ProjectCreate(ctx, oxide.ProjectCreateParams{Body: &body})
+				return err
+			},
+		)
+		return &state{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error viewing project %s: %w", p.project, err)
+	}
+
+	return fetchState(ctx, p.client, p.project)
+}
+
+// fetchState returns the current state of an existing project.
+func fetchState(ctx context.Context, client *oxide.Client, project oxide.NameOrId) (*state, error) {
+	var (
+		current state
+		err     error
+	)
+	current.disks, err = client.DiskListAllPages(ctx, oxide.DiskListParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error listing disks: %w", err)
+	}
+	current.instances, err = client.InstanceListAllPages(
+		ctx,
+		oxide.InstanceListParams{Project: project},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing instances: %w", err)
+	}
+	current.floatingIPs, err = client.FloatingIpListAllPages(
+		ctx,
+		oxide.FloatingIpListParams{Project: project},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing floating IPs: %w", err)
+	}
+	vpcs, err := client.VpcListAllPages(ctx, oxide.VpcListParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error listing VPCs: %w", err)
+	}
+	for _, vpc := range vpcs {
+		v, err := fetchVpc(ctx, client, project, vpc)
+		if err != nil {
+			return nil, err
+		}
+		current.vpcs = append(current.vpcs, v)
+	}
+	return &current, nil
+}
+
+// fetchVpc returns the current state of a VPC.
+func fetchVpc(
+	ctx context.Context,
+	client *oxide.Client,
+	project oxide.NameOrId,
+	vpc oxide.Vpc,
+) (*vpcState, error) {
+	v := &vpcState{vpc: vpc}
+	vpcName := oxide.NameOrId(vpc.Name)
+
+	var err error
+	v.subnets, err = client.VpcSubnetListAllPages(ctx, oxide.VpcSubnetListParams{
+		Project: project,
+		Vpc:     vpcName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing subnets of VPC %s: %w", vpc.Name, err)
+	}
+
+	routers, err := client.VpcRouterListAllPages(ctx, oxide.VpcRouterListParams{
+		Project: project,
+		Vpc:     vpcName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing routers of VPC %s: %w", vpc.Name, err)
+	}
+	for _, router := range routers {
+		if router.Kind != oxide.VpcRouterKindCustom {
+			continue
+		}
+		routes, err := client.VpcRouterRouteListAllPages(ctx, oxide.VpcRouterRouteListParams{
+			Project: project,
+			Vpc:     vpcName,
+			Router:  oxide.NameOrId(router.Name),
+		})
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error listing routes of router %s of VPC %s: %w",
+				router.Name,
+				vpc.Name,
+				err,
+			)
+		}
+		v.routers = append(v.routers, routerState{router: router, routes: routes})
+	}
+
+	rules, err := client.VpcFirewallRulesView(ctx, oxide.VpcFirewallRulesViewParams{
+		Project: project,
+		Vpc:     vpcName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error viewing firewall rules of VPC %s: %w", vpc.Name, err)
+	}
+	v.rules = rules.Rules
+	return v, nil
+}
+
+// planVpcs plans the changes to the VPCs and to their resources.
+func (p *planner) planVpcs(current *state) {
+	for _, vpc := range p.spec.Vpcs {
+		i := slices.IndexFunc(current.vpcs, func(v *vpcState) bool {
+			return v.vpc.Name == vpc.Name
+		})
+		var cur *vpcState
+		if i < 0 {
+			cur = &vpcState{created: true}
+			p.createVpc(vpc)
+		} else {
+			cur = current.vpcs[i]
+			p.updateVpc(vpc, cur)
+		}
+		p.planRouters(vpc, cur)
+		p.planSubnets(vpc, cur, false)
+		p.planFirewallRules(vpc, cur)
+	}
+
+	for _, cur := range current.vpcs {
+		if slices.ContainsFunc(p.spec.Vpcs, func(v Vpc) bool { return v.Name == cur.vpc.Name }) {
+			continue
+		}
+		// Planning an empty VPC deletes its subnets and custom routers, which must be deleted
+		// before the VPC.
+		empty := Vpc{VpcCreate: oxide.VpcCreate{Name: cur.vpc.Name}}
+		p.planRouters(empty, cur)
+		p.planSubnets(empty, cur, true)
+		p.remove(
+			stageDeleteVpcs,
+			Change{Kind: "VPC", Name: cur.vpc.Name},
+			func(ctx context.Context) error {
+				return p.client.VpcDelete(ctx, oxide.VpcDeleteParams{
+					Project: p.project,
+					Vpc:     oxide.NameOrId(cur.vpc.Name),
+				})
+			},
+		)
+	}
+}
+
+func (p *planner) createVpc(vpc Vpc) {
+	body := vpc.VpcCreate
+	p.create(
+		stageCreateVpcs,
+		Change{Kind: "VPC", Name: vpc.Name},
+		body,
+		func(ctx context.Context) error {
+			_, err := p.client.VpcCreate(ctx, oxide.VpcCreateParams{
+				Project: p.project,
+				Body:    &body,
+			})
+			return err
+		},
+	)
+}
+
+func (p *planner) updateVpc(vpc Vpc, cur *vpcState) {
+	current := oxide.VpcCreate{
+		Description: cur.vpc.Description,
+		DnsName:     cur.vpc.DnsName,
+		Name:        cur.vpc.Name,
+	}
+	// The IPv6 prefix is assigned by the API when it isn't set.
+	if vpc.Ipv6Prefix != "" {
+		current.Ipv6Prefix = cur.vpc.Ipv6Prefix
+	}
+	p.update(
+		stageCreateVpcs,
+		Change{Kind: "VPC", Name: vpc.Name},
+		current,
+		vpc.VpcCreate,
+		[]string{"ipv6_prefix"},
+		func(ctx context.Context) error {
+			_, err := p.client.VpcUpdate(ctx, oxide.VpcUpdateParams{
+				Project: p.project,
+				Vpc:     oxide.NameOrId(vpc.Name),
+				Body: &oxide.VpcUpdate{
+					Description: vpc.Description,
+					DnsName:     vpc.DnsName,
+				},
+			})
+			return err
+		},
+	)
+}
+
+// planRouters plans the changes to the custom routers of a VPC and to their routes.
+func (p *planner) planRouters(vpc Vpc, cur *vpcState) {
+	vpcName := oxide.NameOrId(vpc.Name)
+	for _, router := range vpc.Routers {
+		c := Change{Kind: "VPC router", Name: router.Name, Vpc: vpc.Name}
+		i := slices.IndexFunc(cur.routers, func(r routerState) bool {
+			return r.router.Name == router.Name
+		})
+		var routes []oxide.RouterRoute
+		if i < 0 {
+			body := router.VpcRouterCreate
+			p.create(stageCreateRouters, c, body, func(ctx context.Context) error {
+				_, err := p.client.VpcRouterCreate(ctx, oxide.VpcRouterCreateParams{
+					Project: p.project,
+					Vpc:     vpcName,
+					Body:    &body,
+				})
+				return err
+			})
+		} else {
+			existing := cur.routers[i].router
+			routes = cur.routers[i].routes
+			current := oxide.VpcRouterCreate{
+				Description: existing.Description,
+				Name:        existing.Name,
+			}
+			p.update(
+				stageCreateRouters,
+				c,
+				current,
+				router.VpcRouterCreate,
+				nil,
+				func(ctx context.Context) error {
+					_, err := p.client.VpcRouterUpdate(ctx, oxide.VpcRouterUpdateParams{
+						Project: p.project,
+						Vpc:     vpcName,
+						Router:  oxide.NameOrId(router.Name),
+						Body:    &oxide.VpcRouterUpdate{Description: router.Description},
+					})
+					return err
+				},
+			)
+		}
+		p.planRoutes(vpc.Name, router, routes)
+	}
+
+	// The routes of the routers that are deleted are deleted along with them.
+	for _, r := range cur.routers {
+		if slices.ContainsFunc(vpc.Routers, func(router Router) bool {
+			return router.Name == r.router.Name
+		}) {
+			continue
+		}
+		p.remove(
+			stageDeleteRouters,
+			Change{Kind: "VPC router", Name: r.router.Name, Vpc: vpc.Name},
+			func(ctx context.Context) error {
+				return p.client.VpcRouterDelete(ctx, oxide.VpcRouterDeleteParams{
+					Project: p.project,
+					Vpc:     vpcName,
+					Router:  oxide.NameOrId(r.router.Name),
+				})
+			},
+		)
+	}
+}
+
+// planRoutes plans the changes to the routes of a custom router.
+func (p *planner) planRoutes(vpc oxide.Name, router Router, current []oxide.RouterRoute) {
+	for _, route := range router.Routes {
+		c := Change{Kind: "router route", Name: route.Name, Vpc: vpc, Router: router.Name}
+		i := slices.IndexFunc(current, func(r oxide.RouterRoute) bool {
+			return r.Name == route.Name
+		})
+		if i < 0 {
+			body := route
+			p.create(stageCreateRules, c, body, func(ctx context.Context) error {
+				_, err := p.client.VpcRouterRouteCreate(ctx, oxide.VpcRouterRouteCreateParams{
+					Project: p.project,
+					Vpc:     oxide.NameOrId(vpc),
+					Router:  oxide.NameOrId(router.Name),
+					Body:    &body,
+				})
+				return err
+			})
+			continue
+		}
+
+		existing := current[i]
+		p.update(
+			stageCreateRules,
+			c,
+			oxide.RouterRouteCreate{
+				Description: existing.Description,
+				Destination: existing.Destination,
+				Name:        existing.Name,
+				Target:      existing.Target,
+			},
+			route,
+			nil,
+			func(ctx context.Context) error {
+				_, err := p.client.VpcRouterRouteUpdate(ctx, oxide.VpcRouterRouteUpdateParams{
+					Project: p.project,
+					Vpc:     oxide.NameOrId(vpc),
+					Router:  oxide.NameOrId(router.Name),
+					Route:   oxide.NameOrId(route.Name),
+					Body: &oxide.RouterRouteUpdate{
+						Description: route.Description,
+						Destination: route.Destination,
+						Target:      route.Target,
+					},
+				})
+				return err
+			},
+		)
+	}
+
+	for _, existing := range current {
+		if slices.ContainsFunc(router.Routes, func(route oxide.RouterRouteCreate) bool {
+			return route.Name == existing.Name
+		}) {
+			continue
+		}
+		p.remove(
+			stageDeleteDetached,
+			Change{Kind: "router route", Name: existing.Name, Vpc: vpc, Router: router.Name},
+			func(ctx context.Context) error {
+				return p.client.VpcRouterRouteDelete(ctx, oxide.VpcRouterRouteDeleteParams{
+					Project: p.project,
+					Vpc:     oxide.NameOrId(vpc),
+					Router:  oxide.NameOrId(router.Name),
+					Route:   oxide.NameOrId(existing.Name),
+				})
+			},
+		)
+	}
+}
+
+// defaultSubnet is the name of the subnet that the API creates along with every VPC.
+const defaultSubnet = "default"
+
+// planSubnets plans the changes to the subnets of a VPC, and the deletion of all its subnets if
+// deleteVpc is set. The API creates a default subnet along with every VPC, so the default subnet
+// is only managed when the spec lists it.
+func (p *planner) planSubnets(vpc Vpc, cur *vpcState, deleteVpc bool) {
+	vpcName := oxide.NameOrId(vpc.Name)
+	routerNames := make(map[string]oxide.Name)
+	for _, r := range cur.routers {
+		routerNames[r.router.Id] = r.router.Name
+	}
+
+	for _, subnet := range vpc.Subnets {
+		c := Change{Kind: "VPC subnet", Name: subnet.Name, Vpc: vpc.Name}
+		update := func(ctx context.Context) error {
+			_, err := p.client.VpcSubnetUpdate(ctx, oxide.VpcSubnetUpdateParams{
+				Project: p.project,
+				Vpc:     vpcName,
+				Subnet:  oxide.NameOrId(subnet.Name),
+				Body: &oxide.VpcSubnetUpdate{
+					CustomRouter: subnet.CustomRouter,
+					Description:  subnet.Description,
+				},
+			})
+			return err
+		}
+
+		i := slices.IndexFunc(cur.subnets, func(s oxide.VpcSubnet) bool {
+			return s.Name == subnet.Name
+		})
+		if i < 0 {
+			body := subnet
+			created := cur.created
+			p.create(stageCreateSubnets, c, body, func(ctx context.Context) error {
+				_, err := p.client.VpcSubnetCreate(ctx, oxide.VpcSubnetCreateParams{
+					Project: p.project,
+					Vpc:     vpcName,
+					Body:    &body,
+				})
+				// A VPC is created along with its default subnet, which is updated instead.
+				if created && errors.Is(err, oxide.ErrObjectAlreadyExists) {
+					return p.updateDefaultSubnet(ctx, vpcName, body, update)
+				}
+				return err
+			})
+			continue
+		}
+
+		existing := cur.subnets[i]
+		current := oxide.VpcSubnetCreate{
+			CustomRouter: oxide.NameOrId(routerNames[existing.CustomRouterId]),
+			Description:  existing.Description,
+			Ipv4Block:    existing.Ipv4Block,
+			Name:         existing.Name,
+		}
+		// The IPv6 block is assigned by the API when it isn't set.
+		if subnet.Ipv6Block != "" {
+			current.Ipv6Block = existing.Ipv6Block
+		}
+		p.update(
+			stageCreateSubnets,
+			c,
+			current,
+			subnet,
+			[]string{"ipv4_block", "ipv6_block"},
+			update,
+		)
+	}
+
+	for _, existing := range cur.subnets {
+		if slices.ContainsFunc(vpc.Subnets, func(s oxide.VpcSubnetCreate) bool {
+			return s.Name == existing.Name
+		}) || (existing.Name == defaultSubnet && !deleteVpc) {
+			continue
+		}
+		p.remove(
+			stageDeleteSubnets,
+			Change{Kind: "VPC subnet", Name: existing.Name, Vpc: vpc.Name},
+			func(ctx context.Context) error {
+				return p.client.VpcSubnetDelete(ctx, oxide.VpcSubnetDeleteParams{
+					Project: p.project,
+					Vpc:     vpcName,
+					Subnet:  oxide.NameOrId(existing.Name),
+				})
+			},
+		)
+	}
+}
+
+// updateDefaultSubnet updates the default subnet created along with a VPC to match subnet. The
+// blocks of a subnet can't be updated, so it fails if they differ from the blocks of subnet.
+func (p *planner) updateDefaultSubnet(
+	ctx context.Context,
+	vpc oxide.NameOrId,
+	subnet oxide.VpcSubnetCreate,
+	update func(ctx context.Context) error,
+) error {
+	existing, err := p.client.VpcSubnetView(ctx, oxide.VpcSubnetViewParams{
+		Project: p.project,
+		Vpc:     vpc,
+		Subnet:  oxide.NameOrId(subnet.Name),
+	})
+	if err != nil {
+		return err
+	}
+	if existing.Ipv4Block != subnet.Ipv4Block {
+		return fmt.Errorf(
+			"ipv4_block can't be changed from %q to %q",
+			existing.Ipv4Block,
+			subnet.Ipv4Block,
+		)
+	}
+	if subnet.Ipv6Block != "" && existing.Ipv6Block != subnet.Ipv6Block {
+		return fmt.Errorf(
+			"ipv6_block can't be changed from %q to %q",
+			existing.Ipv6Block,
+			subnet.Ipv6Block,
+		)
+	}
+	return update(ctx)
+}
+
+// planFirewallRules plans the changes to the firewall rules of a VPC. The rules of a VPC are
+// replaced all at once, so the changes share a single step. The rules of a VPC that is created
+// are always replaced, since the API creates default rules along with the VPC.
+func (p *planner) planFirewallRules(vpc Vpc, cur *vpcState) {
+	n := len(p.changes[stageCreateRules])
+	for _, rule := range vpc.FirewallRules {
+		c := Change{Kind: "firewall rule", Name: rule.Name, Vpc: vpc.Name}
+		i := slices.IndexFunc(cur.rules, func(r oxide.VpcFirewallRule) bool {
+			return r.Name == rule.Name
+		})
+		if i < 0 {
+			p.create(stageCreateRules, c, rule, nil)
+		} else {
+			p.update(stageCreateRules, c, cur.rules[i].ToUpdate(), rule, nil, nil)
+		}
+	}
+	for _, existing := range cur.rules {
+		if slices.ContainsFunc(vpc.FirewallRules, func(r oxide.VpcFirewallRuleUpdate) bool {
+			return r.Name == existing.Name
+		}) {
+			continue
+		}
+		p.remove(
+			stageCreateRules,
+			Change{Kind: "firewall rule", Name: existing.Name, Vpc: vpc.Name},
+			nil,
+		)
+	}
+
+	if !cur.created && len(p.changes[stageCreateRules]) == n {
+		return
+	}
+	rules := vpc.FirewallRules
+	p.steps[stageCreateRules] = append(p.steps[stageCreateRules], stage.Step{
+		Name: fmt.Sprintf("update firewall rules (VPC %s)", vpc.Name),
+		Run: func(ctx context.Context) error {
+			_, err := p.client.VpcFirewallRulesUpdate(ctx, oxide.VpcFirewallRulesUpdateParams{
+				Project: p.project,
+				Vpc:     oxide.NameOrId(vpc.Name),
+				Body:    &oxide.VpcFirewallRuleUpdateParams{Rules: rules},
+			})
+			return err
+		},
+	})
+}
+
+// diskFields are the fields of a disk that are compared with the spec. Disks can't be updated.
+type diskFields struct {
+	Description string          `json:"description"`
+	Size        oxide.ByteCount `json:"size"`
+}
+
+// planDisks plans the changes to the disks. The disks created along with the instances of the
+// spec are left alone.
+func (p *planner) planDisks(current *state) {
+	for _, disk := range p.spec.Disks {
+		c := Change{Kind: "disk", Name: disk.Name}
+		i := slices.IndexFunc(current.disks, func(d oxide.Disk) bool {
+			return d.Name == disk.Name
+		})
+		if i < 0 {
+			body := disk
+			p.create(stageCreateVpcs, c, body, func(ctx context.Context) error {
+				_, err := p.client.DiskCreate(ctx, oxide.DiskCreateParams{
+					Project: p.project,
+					Body:    &body,
+				})
+				return err
+			})
+			continue
+		}
+
+		existing := current.disks[i]
+		p.update(
+			stageCreateVpcs,
+			c,
+			diskFields{Description: existing.Description, Size: existing.Size},
+			diskFields{Description: disk.Description, Size: disk.Size},
+			[]string{"description", "size"},
+			nil,
+		)
+	}
+
+	wanted := make(names)
+	for _, disk := range p.spec.Disks {
+		wanted[disk.Name] = true
+	}
+	for _, instance := range p.spec.Instances {
+		for _, disk := range append([]oxide.InstanceDiskAttachment{instance.BootDisk}, instance.Disks...) {
+			if create, ok := diskAttachmentCreate(disk); ok {
+				wanted[create.Name] = true
+			}
+		}
+	}
+	for _, existing := range current.disks {
+		if wanted[existing.Name] {
+			continue
+		}
+		p.remove(
+			stageDeleteDetached,
+			Change{Kind: "disk", Name: existing.Name},
+			func(ctx context.Context) error {
+				return p.client.DiskDelete(ctx, oxide.DiskDeleteParams{
+					Project: p.project,
+					Disk:    oxide.NameOrId(existing.Name),
+				})
+			},
+		)
+	}
+}
+
+// planFloatingIPs plans the changes to the floating IPs.
+func (p *planner) planFloatingIPs(current *state) {
+	for _, ip := range p.spec.FloatingIps {
+		c := Change{Kind: "floating IP", Name: ip.Name}
+		i := slices.IndexFunc(current.floatingIPs, func(f oxide.FloatingIp) bool {
+			return f.Name == ip.Name
+		})
+		if i < 0 {
+			body := ip
+			p.create(stageCreateVpcs, c, body, func(ctx context.Context) error {
+				_, err := p.client.FloatingIpCreate(ctx, oxide.FloatingIpCreateParams{
+					Project: p.project,
+					Body:    &body,
+				})
+				return err
+			})
+			continue
+		}
+
+		body := oxide.FloatingIpUpdate{Description: ip.Description}
+		p.update(
+			stageCreateVpcs,
+			c,
+			oxide.FloatingIpUpdate{Description: current.floatingIPs[i].Description},
+			body,
+			nil,
+			func(ctx context.Context) error {
+				_, err := p.client.FloatingIpUpdate(ctx, oxide.FloatingIpUpdateParams{
+					Project:    p.project,
+					FloatingIp: oxide.NameOrId(ip.Name),
+					Body:       &body,
+				})
+				return err
+			},
+		)
+	}
+
+	for _, existing := range current.floatingIPs {
+		if slices.ContainsFunc(p.spec.FloatingIps, func(ip oxide.FloatingIpCreate) bool {
+			return ip.Name == existing.Name
+		}) {
+			continue
+		}
+		p.remove(
+			stageDeleteDetached,
+			Change{Kind: "floating IP", Name: existing.Name},
+			func(ctx context.Context) error {
+				return p.client.FloatingIpDelete(ctx, oxide.FloatingIpDeleteParams{
+					Project:    p.project,
+					FloatingIp: oxide.NameOrId(existing.Name),
+				})
+			},
+		)
+	}
+}
+
+// instanceFields are the fields of an instance that are compared with the spec.
+type instanceFields struct {
+	Description string `json:"description"`
+	Hostname    string `json:"hostname"`
+	oxide.InstanceUpdate
+}
+
+// planInstances plans the changes to the instances. Instances are stopped before they are
+// deleted. Their CPUs, memory, boot disk and CPU platform can only be updated while they are
+// stopped, so the running instances whose CPUs, memory, boot disk or CPU platform change are
+// stopped, updated and started again.
+func (p *planner) planInstances(current *state) {
+	diskNames := make(map[string]oxide.Name)
+	for _, disk := range current.disks {
+		diskNames[disk.Id] = disk.Name
+	}
+
+	for _, instance := range p.spec.Instances {
+		c := Change{Kind: "instance", Name: instance.Name}
+		i := slices.IndexFunc(current.instances, func(in oxide.Instance) bool {
+			return in.Name == instance.Name
+		})
+		if i < 0 {
+			body := instance
+			p.create(stageCreateInstances, c, body, func(ctx context.Context) error {
+				_, err := p.client.InstanceCreate(ctx, oxide.InstanceCreateParams{
+					Project: p.project,
+					Body:    &body,
+				})
+				return err
+			})
+			continue
+		}
+
+		existing := current.instances[i]
+		cur := instanceFields{
+			Description: existing.Description,
+			Hostname:    existing.Hostname,
+			InstanceUpdate: oxide.InstanceUpdate{
+				AutoRestartPolicy: optional(existing.AutoRestartPolicy),
+				BootDisk:          optional(oxide.NameOrId(diskNames[existing.BootDiskId])),
+				CpuPlatform:       optional(existing.CpuPlatform),
+				Memory:            existing.Memory,
+				Ncpus:             existing.Ncpus,
+			},
+		}
+		body := oxide.InstanceUpdate{
+			AutoRestartPolicy: optional(instance.AutoRestartPolicy),
+			BootDisk:          optional(oxide.NameOrId(diskAttachmentName(instance.BootDisk))),
+			CpuPlatform:       optional(instance.CpuPlatform),
+			EnableJumboFrames: instance.EnableJumboFrames,
+			Memory:            instance.Memory,
+			Ncpus:             instance.Ncpus,
+		}
+		desired := instanceFields{
+			Description:    instance.Description,
+			Hostname:       string(instance.Hostname),
+			InstanceUpdate: body,
+		}
+		// Jumbo frames are only managed when they're set in the spec.
+		if body.EnableJumboFrames == nil {
+			body.EnableJumboFrames = existing.EnableJumboFrames
+		} else {
+			cur.EnableJumboFrames = existing.EnableJumboFrames
+		}
+		// The boot disk is only managed when it's set in the spec, since updating an instance
+		// without a boot disk clears it.
+		if body.BootDisk == nil {
+			body.BootDisk = cur.BootDisk
+			cur.BootDisk = nil
+		}
+		switch existing.RunState {
+		case oxide.InstanceStateStopped, oxide.InstanceStateFailed, oxide.InstanceStateDestroyed:
+		default:
+			c.Restart = cur.Ncpus != desired.Ncpus || cur.Memory != desired.Memory ||
+				!equal(cur.BootDisk, desired.BootDisk) ||
+				!equal(cur.CpuPlatform, desired.CpuPlatform)
+		}
+		name := oxide.NameOrId(instance.Name)
+		restart := c.Restart
+		p.update(
+			stageCreateInstances,
+			c,
+			cur,
+			desired,
+			[]string{"description", "hostname"},
+			func(ctx context.Context) error {
+				if restart {
+					if err := p.client.StopInstanceAndWait(
+						ctx,
+						oxide.InstanceStopParams{Project: p.project, Instance: name},
+						p.opts.Wait...,
+					); err != nil {
+						return err
+					}
+				}
+				_, err := p.client.InstanceUpdate(ctx, oxide.InstanceUpdateParams{
+					Project:  p.project,
+					Instance: name,
+					Body:     &body,
+				})
+				if restart {
+					// The instance is started again even if the update failed.
+					_, startErr := p.client.InstanceStart(ctx, oxide.InstanceStartParams{
+						Project:  p.project,
+						Instance: name,
+					})
+					err = errors.Join(err, startErr)
+				}
+				return err
+			},
+		)
+	}
+
+	for _, existing := range current.instances {
+		if slices.ContainsFunc(p.spec.Instances, func(in oxide.InstanceCreate) bool {
+			return in.Name == existing.Name
+		}) {
+			continue
+		}
+		instance := oxide.NameOrId(existing.Name)
+		switch existing.RunState {
+		case oxide.InstanceStateStopped, oxide.InstanceStateFailed, oxide.InstanceStateDestroyed:
+		default:
+			p.steps[stageStopInstances] = append(p.steps[stageStopInstances], stage.Step{
+				Name: fmt.Sprintf("stop instance %s", existing.Name),
+				Run: func(ctx context.Context) error {
+					return p.client.StopInstanceAndWait(
+						ctx,
+						oxide.InstanceStopParams{Project: p.project, Instance: instance},
+						p.opts.Wait...,
+					)
+				},
+			})
+		}
+		p.remove(
+			stageDeleteInstances,
+			Change{Kind: "instance", Name: existing.Name},
+			func(ctx context.Context) error {
+				return p.client.InstanceDelete(ctx, oxide.InstanceDeleteParams{
+					Project:  p.project,
+					Instance: instance,
+				})
+			},
+		)
+	}
+}
+
+// diskAttachmentCreate returns the disk created by a disk attachment, if it creates one.
+func diskAttachmentCreate(
+	a oxide.InstanceDiskAttachment,
+) (*oxide.InstanceDiskAttachmentCreate, bool) {
+	switch v := a.Value.(type) {
+	case *oxide.InstanceDiskAttachmentCreate:
+		return v, true
+	case oxide.InstanceDiskAttachmentCreate:
+		return &v, true
+	}
+	return nil, false
+}
+
+// diskAttachmentName returns the name of the disk of a disk attachment, or an empty name if the
+// attachment isn't set.
+func diskAttachmentName(a oxide.InstanceDiskAttachment) oxide.Name {
+	switch v := a.Value.(type) {
+	case *oxide.InstanceDiskAttachmentCreate:
+		return v.Name
+	case oxide.InstanceDiskAttachmentCreate:
+		return v.Name
+	case *oxide.InstanceDiskAttachmentAttach:
+		return v.Name
+	case oxide.InstanceDiskAttachmentAttach:
+		return v.Name
+	}
+	return ""
+}
+
+// equal reports whether a and b are both nil, or point to equal values.
+func equal[T comparable](a, b *T) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
+
+// optional returns a pointer to v, or nil if v is the zero value.
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,278 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package apply reconciles the resources of a project with a spec: a YAML or JSON document
+// describing its VPCs with their subnets, routers and firewall rules, its disks, its instances and
+// its floating IPs.
+//
+// Applying a spec is done in two steps. Plan compares the spec with the resources returned by the
+// list and view endpoints, and returns the changes that make the project match the spec. The plan
+// prints as a diff, and Apply then makes the changes in dependency order, e.g. the disks and
+// subnets used by an instance are created before the instance, and an instance is stopped and
+// deleted before its disks.
+//
+//	spec, err := apply.LoadFile("project.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	applier := apply.New(client, apply.Options{})
+//	plan, err := applier.Plan(ctx, spec)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(plan)
+//	if err := applier.Apply(ctx, plan); err != nil {
+//		return err
+//	}
+//
+// Resources are identified by name, so renaming a resource in the spec deletes it and creates a
+// new one. Resources of the project that aren't in the spec are deleted, except for the system
+// routers of the VPCs and their routes.
+//
+// Export goes the other way, and returns the spec of an existing project, which can be written
+// with yaml.Marshal and applied to another project.
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the desired state of a project. Its resources use the create-time parameters of the
+// API, with the fields of the `yaml` struct tags of the generated types, e.g.
+//
+//	project: web
+//	vpcs:
+//	  - name: web
+//	    description: web tier
+//	    dns_name: web
+//	    subnets:
+//	      - name: frontend
+//	        description: load balancers
+//	        ipv4_block: 10.0.1.0/24
+//	    firewall_rules:
+//	      - name: allow-https
+//	        description: inbound HTTPS
+//	        action: allow
+//	        direction: inbound
+//	        priority: 100
+//	        status: enabled
+//	        targets:
+//	          - type: subnet
+//	            value: frontend
+//	        filters:
+//	          protocols:
+//	            - type: tcp
+//	          ports: ["443"]
+type Spec struct {
+	// Project is the name of the project. It's created if it doesn't exist.
+	Project oxide.Name `json:"project" yaml:"project"`
+
+	// Vpcs are the VPCs of the project.
+	Vpcs []Vpc `json:"vpcs,omitempty" yaml:"vpcs,omitempty"`
+
+	// Disks are the disks of the project, other than the disks created along with instances.
+	Disks []oxide.DiskCreate `json:"disks,omitempty" yaml:"disks,omitempty"`
+
+	// Instances are the instances of the project. Their disks, network interfaces and external
+	// IPs are only used to create them. The boot disk and jumbo frames of an existing instance
+	// are left alone unless they're set.
+	Instances []oxide.InstanceCreate `json:"instances,omitempty" yaml:"instances,omitempty"`
+
+	// FloatingIps are the floating IPs of the project.
+	FloatingIps []oxide.FloatingIpCreate `json:"floating_ips,omitempty" yaml:"floating_ips,omitempty"`
+}
+
+// Vpc is the desired state of a VPC and of its resources.
+type Vpc struct {
+	oxide.VpcCreate `yaml:",inline"`
+
+	// Subnets are the subnets of the VPC. The default subnet, which is created along with the
+	// VPC, is left alone unless it's listed.
+	Subnets []oxide.VpcSubnetCreate `json:"subnets,omitempty" yaml:"subnets,omitempty"`
+
+	// Routers are the custom routers of the VPC. The system router isn't managed.
+	Routers []Router `json:"routers,omitempty" yaml:"routers,omitempty"`
+
+	// FirewallRules are the firewall rules of the VPC.
+	FirewallRules []oxide.VpcFirewallRuleUpdate `json:"firewall_rules,omitempty" yaml:"firewall_rules,omitempty"`
+}
+
+// Router is the desired state of a custom router and of its routes.
+type Router struct {
+	oxide.VpcRouterCreate `yaml:",inline"`
+
+	// Routes are the routes of the router.
+	Routes []oxide.RouterRouteCreate `json:"routes,omitempty" yaml:"routes,omitempty"`
+}
+
+// LoadFile reads a spec from a YAML or JSON file.
+func LoadFile(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Load reads a spec from a YAML or JSON document. Unknown fields are rejected.
+func Load(r io.Reader) (*Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// The generated types can't be decoded by yaml.v3 directly: their union types, such as
+	// RouteTarget, only implement json.Unmarshaler, and yaml.v3 doesn't support the omitzero
+	// option of their tags. Since their yaml and json tags use the same keys, the document is
+	// converted to JSON instead.
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing spec: %w", err)
+	}
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing spec: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("error parsing spec: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// MarshalYAML implements yaml.Marshaler, so that yaml.Marshal writes specs in the format read by
+// Load. Like Load, it goes through JSON, and leaves out the fields that are null.
+func (s Spec) MarshalYAML() (any, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	// Decoding into a node keeps the fields in the order of the JSON encoding.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	node := doc.Content[0]
+	blockStyle(node)
+	return node, nil
+}
+
+// blockStyle removes the null fields of a node and formats it in the block style, instead of the
+// flow style and the quoted strings of JSON.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	if n.Kind == yaml.MappingNode {
+		var content []*yaml.Node
+		for i := 0; i < len(n.Content); i += 2 {
+			if n.Content[i+1].Tag != "!!null" {
+				content = append(content, n.Content[i], n.Content[i+1])
+			}
+		}
+		n.Content = content
+	}
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// Validate checks that the project is set, that the names of the resources are unique, and that
+// the custom routers of the subnets are in the spec.
+func (s *Spec) Validate() error {
+	var errs []error
+	if s.Project == "" {
+		errs = append(errs, errors.New("project must be set"))
+	}
+
+	vpcs := make(names)
+	for _, vpc := range s.Vpcs {
+		errs = append(errs, vpcs.add(Change{Kind: "VPC", Name: vpc.Name}))
+
+		routers := make(names)
+		for _, router := range vpc.Routers {
+			errs = append(errs, routers.add(Change{
+				Kind: "VPC router",
+				Name: router.Name,
+				Vpc:  vpc.Name,
+			}))
+
+			routes := make(names)
+			for _, route := range router.Routes {
+				errs = append(errs, routes.add(Change{
+					Kind:   "router route",
+					Name:   route.Name,
+					Vpc:    vpc.Name,
+					Router: router.Name,
+				}))
+			}
+		}
+
+		subnets := make(names)
+		for _, subnet := range vpc.Subnets {
+			c := Change{Kind: "VPC subnet", Name: subnet.Name, Vpc: vpc.Name}
+			errs = append(errs, subnets.add(c))
+			if subnet.CustomRouter != "" && !routers[oxide.Name(subnet.CustomRouter)] {
+				errs = append(errs, fmt.Errorf(
+					"%s uses custom router %s, which isn't in the spec",
+					c.address(),
+					subnet.CustomRouter,
+				))
+			}
+		}
+
+		rules := make(names)
+		for _, rule := range vpc.FirewallRules {
+			errs = append(errs, rules.add(Change{
+				Kind: "firewall rule",
+				Name: rule.Name,
+				Vpc:  vpc.Name,
+			}))
+		}
+	}
+
+	disks := make(names)
+	for _, disk := range s.Disks {
+		errs = append(errs, disks.add(Change{Kind: "disk", Name: disk.Name}))
+	}
+	instances := make(names)
+	for _, instance := range s.Instances {
+		errs = append(errs, instances.add(Change{Kind: "instance", Name: instance.Name}))
+	}
+	floatingIPs := make(names)
+	for _, ip := range s.FloatingIps {
+		errs = append(errs, floatingIPs.add(Change{Kind: "floating IP", Name: ip.Name}))
+	}
+	return errors.Join(errs...)
+}
+
+// names is a set of resource names.
+type names map[oxide.Name]bool
+
+// add adds the name of a resource to the set, and returns an error if it's empty or already in
+// it.
+func (n names) add(c Change) error {
+	switch {
+	case c.Name == "":
+		c.Name = "with no name"
+		return errors.New(c.address())
+	case n[c.Name]:
+		return fmt.Errorf("duplicate %s", c.address())
+	}
+	n[c.Name] = true
+	return nil
+}
@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apply
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Load(t *testing.T) {
+	t.Run("reads YAML with unions", func(t *testing.T) {
+		spec := loadSpec(t, `
+project: ci
+instances:
+  - name: web-1
+    description: web server
+    hostname: web-1
+    memory: 4294967296
+    ncpus: 2
+    boot_disk:
+      type: create
+      name: web-1-boot
+      description: boot disk
+      size: 10737418240
+      disk_backend:
+        type: distributed
+        disk_source:
+          type: blank
+          block_size: 4096
+`)
+
+		require.Len(t, spec.Instances, 1)
+		attachment, ok := spec.Instances[0].BootDisk.Value.(*oxide.InstanceDiskAttachmentCreate)
+		require.True(t, ok, "unexpected boot disk %T", spec.Instances[0].BootDisk.Value)
+		assert.Equal(t, oxide.Name("web-1-boot"), attachment.Name)
+		assert.Equal(t, oxide.ByteCount(10737418240), attachment.Size)
+	})
+
+	t.Run("reads JSON", func(t *testing.T) {
+		spec := loadSpec(
+			t,
+			`{"project": "ci", "vpcs": [{"name": "web", "description": "web tier", "dns_name": "web"}]}`,
+		)
+
+		require.Len(t, spec.Vpcs, 1)
+		assert.Equal(t, oxide.Name("web"), spec.Vpcs[0].Name)
+		assert.Equal(t, oxide.Name("web"), spec.Vpcs[0].DnsName)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := Load(strings.NewReader("project: ci\nvpc:\n  - name: web\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown field "vpc"`)
+	})
+
+	t.Run("validates the spec", func(t *testing.T) {
+		_, err := Load(strings.NewReader(`
+vpcs:
+  - name: web
+    subnets:
+      - name: frontend
+        custom_router: egress
+      - name: frontend
+      - description: unnamed
+disks:
+  - name: data
+  - name: data
+`))
+		require.Error(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"project must be set",
+			"VPC subnet frontend (VPC web) uses custom router egress, which isn't in the spec",
+			"duplicate VPC subnet frontend (VPC web)",
+			"VPC subnet with no name (VPC web)",
+			"duplicate disk data",
+		}, "\n"), err.Error())
+	})
+}
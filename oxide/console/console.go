@@ -0,0 +1,347 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package console scripts interactions with a serial console, expect-style: wait for output that
+// matches a pattern, such as a login prompt, send lines, and capture the output of commands. It's
+// meant for automation that can't rely on the network of an instance, such as first-boot
+// debugging and image validation.
+//
+//	session, err := console.Connect(ctx, client, oxide.InstanceSerialConsoleStreamParams{
+//		Instance: "my-instance",
+//		Project:  "my-project",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer session.Close()
+//
+//	if _, err := session.ExpectString(ctx, "login: "); err != nil {
+//		return err
+//	}
+//	if err := session.SendLine("root"); err != nil {
+//		return err
+//	}
+//	if _, err := session.Expect(ctx, regexp.MustCompile(`# $`)); err != nil {
+//		return err
+//	}
+package console
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+const (
+	// defaultTimeout is the timeout of a step when no timeout is set.
+	defaultTimeout = 30 * time.Second
+
+	// defaultLineEnding is what a terminal sends when Enter is pressed.
+	defaultLineEnding = "\r"
+
+	// maxBufferSize is the amount of unmatched output kept. Older output is discarded.
+	maxBufferSize = 1 << 20
+
+	// timeoutOutputSize is the amount of recent output included in a TimeoutError.
+	timeoutOutputSize = 256
+)
+
+// TimeoutError is returned when a step times out before the output matches its pattern.
+type TimeoutError struct {
+	// Pattern is the pattern the step waited for.
+	Pattern string
+
+	// Timeout is the timeout of the step.
+	Timeout time.Duration
+
+	// Output is the end of the output that didn't match the pattern.
+	Output string
+}
+
+// Error implements the error interface.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf(
+		"timed out after %s waiting for %q, recent output: %q",
+		e.Timeout,
+		e.Pattern,
+		e.Output,
+	)
+}
+
+// Unwrap returns context.DeadlineExceeded, so that timeouts can be tested with errors.Is.
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Match is the output matched by a step.
+type Match struct {
+	// Before is the output that preceded the match.
+	Before string
+
+	// Text is the text that matched the pattern.
+	Text string
+
+	// Groups holds the text of the capturing groups of the pattern, if any. Groups that didn't
+	// participate in the match are empty.
+	Groups []string
+}
+
+// Option configures a Session.
+type Option func(*Session)
+
+// WithTimeout sets the default timeout of the steps of a session. Defaults to 30s.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Session) {
+		s.timeout = timeout
+	}
+}
+
+// WithLineEnding sets what SendLine appends to lines. Defaults to "\r", which is what a terminal
+// sends when Enter is pressed.
+func WithLineEnding(ending string) Option {
+	return func(s *Session) {
+		s.lineEnding = ending
+	}
+}
+
+// WithTranscript copies all the output of the console to w, e.g. to keep a log of the session.
+func WithTranscript(w io.Writer) Option {
+	return func(s *Session) {
+		s.transcript = w
+	}
+}
+
+// StepOption configures a single step of a session.
+type StepOption func(*stepConfig)
+
+type stepConfig struct {
+	timeout time.Duration
+}
+
+// StepTimeout sets the timeout of a step, instead of the default timeout of the session.
+func StepTimeout(timeout time.Duration) StepOption {
+	return func(cfg *stepConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// Session scripts the interactions with a console. The output of the console is buffered from
+// the moment the session is created, and every step consumes the output it matched, so a step
+// can match output that was printed before it started.
+//
+// The methods of a Session must not be called concurrently.
+type Session struct {
+	rw         io.ReadWriter
+	timeout    time.Duration
+	lineEnding string
+	transcript io.Writer
+
+	// mu guards the output that wasn't matched yet, and the error that ended the output, if
+	// any. updated is closed and replaced every time either changes.
+	mu      sync.Mutex
+	buf     []byte
+	err     error
+	updated chan struct{}
+}
+
+// New returns a session that reads the output of a console from rw and writes its input to rw.
+// If rw is an io.Closer, Close closes it.
+func New(rw io.ReadWriter, opts ...Option) *Session {
+	s := &Session{
+		rw:         rw,
+		timeout:    defaultTimeout,
+		lineEnding: defaultLineEnding,
+		updated:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	go s.read()
+	return s
+}
+
+// Connect connects to the serial console of an instance and returns a session for it. Canceling
+// ctx ends the session.
+func Connect(
+	ctx context.Context,
+	client *oxide.Client,
+	params oxide.InstanceSerialConsoleStreamParams,
+	opts ...Option,
+) (*Session, error) {
+	conn, err := client.InstanceSerialConsoleConnect(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return New(conn, opts...), nil
+}
+
+// read buffers the output of the console until it ends.
+func (s *Session) read() {
+	chunk := make([]byte, 4096)
+	for {
+		n, err := s.rw.Read(chunk)
+		if n > 0 && s.transcript != nil {
+			s.transcript.Write(chunk[:n])
+		}
+
+		s.mu.Lock()
+		s.buf = append(s.buf, chunk[:n]...)
+		if len(s.buf) > maxBufferSize {
+			s.buf = s.buf[len(s.buf)-maxBufferSize:]
+		}
+		if err != nil {
+			s.err = err
+		}
+		close(s.updated)
+		s.updated = make(chan struct{})
+		s.mu.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Expect waits for the output of the console to match pattern, and consumes the output up to the
+// end of the match. It returns a *TimeoutError if the step times out, and io.EOF if the console
+// closes first.
+func (s *Session) Expect(
+	ctx context.Context,
+	pattern *regexp.Regexp,
+	opts ...StepOption,
+) (*Match, error) {
+	ctx, cancel, timeout := s.step(ctx, opts)
+	defer cancel()
+	return s.expect(ctx, pattern, timeout)
+}
+
+// ExpectString waits for the output of the console to contain text. See Expect.
+func (s *Session) ExpectString(
+	ctx context.Context,
+	text string,
+	opts ...StepOption,
+) (*Match, error) {
+	return s.Expect(ctx, regexp.MustCompile(regexp.QuoteMeta(text)), opts...)
+}
+
+// Send writes text to the console as is.
+func (s *Session) Send(text string) error {
+	_, err := io.WriteString(s.rw, text)
+	return err
+}
+
+// SendLine writes line to the console, followed by the line ending of the session.
+func (s *Session) SendLine(line string) error {
+	return s.Send(line + s.lineEnding)
+}
+
+// Capture waits for the output of the console to match start, then for it to match end, and
+// returns the output between the two matches. The timeout of the step covers both. It's typically
+// used to capture the output of a command that is surrounded by markers:
+//
+//	session.SendLine("echo BEGIN; uname -a; echo END")
+//	begin, end := regexp.MustCompile(`BEGIN\r?\n`), regexp.MustCompile(`END`)
+//	output, err := session.Capture(ctx, begin, end)
+//
+// Note that the console echoes the command, so the markers must not match the command itself.
+func (s *Session) Capture(
+	ctx context.Context,
+	start, end *regexp.Regexp,
+	opts ...StepOption,
+) (string, error) {
+	ctx, cancel, timeout := s.step(ctx, opts)
+	defer cancel()
+
+	if _, err := s.expect(ctx, start, timeout); err != nil {
+		return "", err
+	}
+	match, err := s.expect(ctx, end, timeout)
+	if err != nil {
+		return "", err
+	}
+	return match.Before, nil
+}
+
+// Close closes the console, if it's an io.Closer.
+func (s *Session) Close() error {
+	if closer, ok := s.rw.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// step returns the context of a step, bounded by its timeout.
+func (s *Session) step(
+	ctx context.Context,
+	opts []StepOption,
+) (context.Context, context.CancelFunc, time.Duration) {
+	cfg := &stepConfig{timeout: s.timeout}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.timeout <= 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, 0
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, cfg.timeout, errStepTimeout)
+	return ctx, cancel, cfg.timeout
+}
+
+// errStepTimeout is the cause of the cancellation of a step that timed out.
+var errStepTimeout = errors.New("step timed out")
+
+// expect waits for the output to match pattern until ctx is done.
+func (s *Session) expect(
+	ctx context.Context,
+	pattern *regexp.Regexp,
+	timeout time.Duration,
+) (*Match, error) {
+	for {
+		s.mu.Lock()
+		if loc := pattern.FindSubmatchIndex(s.buf); loc != nil {
+			match := &Match{
+				Before: string(s.buf[:loc[0]]),
+				Text:   string(s.buf[loc[0]:loc[1]]),
+			}
+			for i := 2; i < len(loc); i += 2 {
+				group := ""
+				if loc[i] >= 0 {
+					group = string(s.buf[loc[i]:loc[i+1]])
+				}
+				match.Groups = append(match.Groups, group)
+			}
+			s.buf = s.buf[loc[1]:]
+			s.mu.Unlock()
+			return match, nil
+		}
+		err, updated := s.err, s.updated
+		s.mu.Unlock()
+
+		if err != nil {
+			return nil, fmt.Errorf("console closed while waiting for %q: %w", pattern, err)
+		}
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			if context.Cause(ctx) != errStepTimeout {
+				return nil, ctx.Err()
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return nil, &TimeoutError{
+				Pattern: pattern.String(),
+				Timeout: timeout,
+				Output:  string(s.buf[max(0, len(s.buf)-timeoutOutputSize):]),
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package console
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeShell emulates a shell on a serial console: it prints a login prompt, then a prompt after
+// every line it reads, and runs "echo" commands.
+func fakeShell(t *testing.T, conn net.Conn) {
+	t.Helper()
+	defer conn.Close()
+
+	io.WriteString(conn, "\r\nhelios console login: ")
+	lines := bufio.NewScanner(conn)
+	lines.Split(scanCRLines)
+	if !lines.Scan() {
+		return
+	}
+	io.WriteString(conn, lines.Text()+"\r\n# ")
+
+	for lines.Scan() {
+		line := lines.Text()
+		// The console echoes the input.
+		output := line + "\r\n"
+		for cmd := range strings.SplitSeq(line, ";") {
+			if arg, ok := strings.CutPrefix(strings.TrimSpace(cmd), "echo "); ok {
+				output += arg + "\r\n"
+			}
+		}
+		if _, err := io.WriteString(conn, output+"# "); err != nil {
+			return
+		}
+	}
+}
+
+// scanCRLines splits lines that end with a carriage return.
+func scanCRLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := strings.IndexByte(string(data), '\r'); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func newTestSession(t *testing.T, opts ...Option) *Session {
+	t.Helper()
+
+	client, server := net.Pipe()
+	go fakeShell(t, server)
+	session := New(client, opts...)
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func Test_Session(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("logs in and runs commands", func(t *testing.T) {
+		var transcript strings.Builder
+		session := newTestSession(t, WithTranscript(&transcript))
+
+		match, err := session.Expect(ctx, regexp.MustCompile(`(\S+) console login: $`))
+		require.NoError(t, err)
+		assert.Equal(t, "\r\n", match.Before)
+		assert.Equal(t, []string{"helios"}, match.Groups)
+
+		require.NoError(t, session.SendLine("root"))
+		_, err = session.ExpectString(ctx, "# ")
+		require.NoError(t, err)
+
+		require.NoError(t, session.SendLine("echo BEGIN; echo hello; echo world; echo END"))
+		output, err := session.Capture(
+			ctx,
+			regexp.MustCompile(`BEGIN\r\n`),
+			regexp.MustCompile(`END\r\n`),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "hello\r\nworld\r\n", output)
+
+		_, err = session.ExpectString(ctx, "# ")
+		require.NoError(t, err)
+		assert.Contains(t, transcript.String(), "helios console login: root\r\n# ")
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		session := newTestSession(t, WithTimeout(time.Hour))
+
+		_, err := session.ExpectString(ctx, "password:", StepTimeout(20*time.Millisecond))
+		var timeoutErr *TimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, &TimeoutError{
+			Pattern: "password:",
+			Timeout: 20 * time.Millisecond,
+			Output:  "\r\nhelios console login: ",
+		}, timeoutErr)
+
+		// The output that didn't match is still available to the next step.
+		_, err = session.ExpectString(ctx, "login: ")
+		require.NoError(t, err)
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		session := newTestSession(t)
+
+		ctx, cancel := context.WithCancel(ctx)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		_, err := session.ExpectString(ctx, "password:")
+		assert.ErrorIs(t, err, context.Canceled)
+		var timeoutErr *TimeoutError
+		assert.False(t, errors.As(err, &timeoutErr))
+	})
+
+	t.Run("fails when the console closes", func(t *testing.T) {
+		session := newTestSession(t)
+		_, err := session.ExpectString(ctx, "login: ")
+		require.NoError(t, err)
+		require.NoError(t, session.Close())
+
+		_, err = session.ExpectString(ctx, "# ")
+		assert.ErrorIs(t, err, io.ErrClosedPipe)
+		assert.ErrorContains(t, err, `console closed while waiting for "# "`)
+	})
+}
@@ -0,0 +1,314 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// deviceCodeGrantType is the OAuth 2.0 grant type used to poll for a device access token.
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultDevicePollInterval is the delay between token polls when none is configured.
+	defaultDevicePollInterval = 5 * time.Second
+
+	// deviceSlowDownIncrement is added to the poll interval each time the server asks the client
+	// to slow down, as required by RFC 8628.
+	deviceSlowDownIncrement = 5 * time.Second
+)
+
+// DeviceAuthorization holds the codes returned when starting a device login. The user must visit
+// VerificationURI and enter UserCode to grant access.
+type DeviceAuthorization struct {
+	// UserCode is the code the user enters at VerificationURI.
+	UserCode string
+
+	// VerificationURI is the URL of the web console page where the user confirms the login.
+	VerificationURI string
+
+	// ExpiresIn is how long the user has to confirm the login.
+	ExpiresIn time.Duration
+}
+
+// DeviceAccessTokenGrant is the access token granted once the user confirms a device login.
+type DeviceAccessTokenGrant struct {
+	// AccessToken is the bearer token used to authenticate API requests.
+	AccessToken string `json:"access_token"`
+
+	// TokenType is the type of the token, always "Bearer".
+	TokenType string `json:"token_type"`
+
+	// TokenId is the ID of the token, which can be used to revoke it.
+	TokenId string `json:"token_id,omitempty"`
+
+	// TimeExpires is when the token expires, if it expires at all.
+	TimeExpires *time.Time `json:"time_expires,omitempty"`
+}
+
+// DeviceAuthError is returned when the server rejects a device login, e.g. because the user
+// denied access or the device code expired.
+type DeviceAuthError struct {
+	// Code is the OAuth 2.0 error code (e.g., "access_denied" or "expired_token").
+	Code string
+
+	// Description is an optional human-readable explanation of the error.
+	Description string
+}
+
+// Error implements the error interface.
+func (e *DeviceAuthError) Error() string {
+	if e.Description == "" {
+		return fmt.Sprintf("device authorization failed: %s", e.Code)
+	}
+	return fmt.Sprintf("device authorization failed: %s: %s", e.Code, e.Description)
+}
+
+// DeviceLoginOptions configures [DeviceLogin].
+type DeviceLoginOptions struct {
+	// Prompt is called once the device code has been issued. It must show the user code and
+	// verification URL to the user, e.g. by printing them or opening a browser. Required.
+	Prompt func(ctx context.Context, auth DeviceAuthorization) error
+
+	// ClientID identifies this client to the server. A random UUID is used when empty.
+	ClientID string
+
+	// TTL is the requested lifetime of the token. The silo's maximum lifetime is used when zero.
+	TTL time.Duration
+
+	// PollInterval is the delay between attempts to fetch the token. Defaults to 5s.
+	PollInterval time.Duration
+
+	// ClientOptions configure the requests sent to log in and the returned [Client], e.g.
+	// [WithTimeout], [WithInsecureSkipVerify], [WithMiddleware] or [WithLogger]. They must not
+	// set a token, token source or profile, which is checked before the login starts.
+	ClientOptions []ClientOption
+}
+
+// DeviceLogin logs in to the Oxide API at host using the OAuth 2.0 device authorization flow and
+// returns a [Client] authenticated with the granted token. It requests a device code, surfaces the
+// user code and verification URL through opts.Prompt, and polls until the user confirms the login
+// in the web console, the code expires, or ctx is done.
+func DeviceLogin(ctx context.Context, host string, opts DeviceLoginOptions) (*Client, error) {
+	grant, err := DeviceLoginToken(ctx, host, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOpts := append(
+		append([]ClientOption{}, opts.ClientOptions...),
+		WithHost(host),
+		WithToken(grant.AccessToken),
+	)
+	return NewClient(clientOpts...)
+}
+
+// DeviceLoginToken performs the same flow as [DeviceLogin] but returns the granted token instead of
+// a client, so that callers can persist it.
+func DeviceLoginToken(
+	ctx context.Context,
+	host string,
+	opts DeviceLoginOptions,
+) (*DeviceAccessTokenGrant, error) {
+	if opts.Prompt == nil {
+		return nil, errors.New("device login prompt is required")
+	}
+	f, err := newDeviceFlow(host, opts)
+	if err != nil {
+		return nil, err
+	}
+	return f.run(ctx, opts.Prompt)
+}
+
+// deviceFlow is a device login in progress.
+type deviceFlow struct {
+	// client sends the requests through the middleware, retry policy and logger configured by the
+	// client options. It has no token, since the device authorization endpoints don't require
+	// one.
+	client   *Client
+	clientID string
+	ttl      time.Duration
+	interval time.Duration
+	// slowDown is added to interval each time the server asks the client to slow down.
+	slowDown time.Duration
+}
+
+func newDeviceFlow(host string, opts DeviceLoginOptions) (*deviceFlow, error) {
+	cfg := &clientConfig{
+		userAgent:  defaultUserAgent(),
+		httpClient: defaultHTTPClient(),
+	}
+	for _, opt := range opts.ClientOptions {
+		if err := opt.apply(cfg); err != nil {
+			return nil, fmt.Errorf("failed to apply options: %w", err)
+		}
+	}
+	// The client returned by DeviceLogin is authenticated with the granted token, so a conflicting
+	// option must be rejected before the user goes through the login.
+	if cfg.tokenSetFromOption || cfg.tokenSource != nil || cfg.profileSetFromOption ||
+		cfg.defaultProfileSetFromOption {
+		return nil, errors.New("device login client options cannot set a token or profile")
+	}
+
+	baseURL, err := parseBaseURL(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing host address: %w", err)
+	}
+
+	f := &deviceFlow{
+		client:   cfg.newClient(baseURL),
+		clientID: opts.ClientID,
+		ttl:      opts.TTL,
+		interval: opts.PollInterval,
+		slowDown: deviceSlowDownIncrement,
+	}
+	if f.clientID == "" {
+		f.clientID = newUUID()
+	}
+	if f.interval <= 0 {
+		f.interval = defaultDevicePollInterval
+	}
+	return f, nil
+}
+
+func (f *deviceFlow) run(
+	ctx context.Context,
+	prompt func(ctx context.Context, auth DeviceAuthorization) error,
+) (*DeviceAccessTokenGrant, error) {
+	form := url.Values{"client_id": {f.clientID}}
+	if f.ttl > 0 {
+		form.Set("ttl_seconds", strconv.Itoa(int(f.ttl.Seconds())))
+	}
+	var authResp struct {
+		DeviceCode      string `json:"device_code"`
+		UserCode        string `json:"user_code"`
+		VerificationURI string `json:"verification_uri"`
+		ExpiresIn       int    `json:"expires_in"`
+	}
+	if err := f.post(ctx, "device_auth_request", "/device/auth", form, &authResp); err != nil {
+		return nil, fmt.Errorf("error requesting device code: %w", err)
+	}
+
+	auth := DeviceAuthorization{
+		UserCode:        authResp.UserCode,
+		VerificationURI: authResp.VerificationURI,
+		ExpiresIn:       time.Duration(authResp.ExpiresIn) * time.Second,
+	}
+	if err := prompt(ctx, auth); err != nil {
+		return nil, fmt.Errorf("device login prompt failed: %w", err)
+	}
+
+	if auth.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(
+			ctx,
+			auth.ExpiresIn,
+			&DeviceAuthError{Code: "expired_token"},
+		)
+		defer cancel()
+	}
+
+	interval := f.interval
+	tokenForm := url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {authResp.DeviceCode},
+		"client_id":   {f.clientID},
+	}
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, context.Cause(ctx)
+		case <-timer.C:
+		}
+
+		var grant DeviceAccessTokenGrant
+		err := f.post(ctx, "device_access_token", "/device/token", tokenForm, &grant)
+		var authErr *DeviceAuthError
+		switch {
+		case err == nil:
+			return &grant, nil
+		case errors.As(err, &authErr) && authErr.Code == "authorization_pending":
+		case errors.As(err, &authErr) && authErr.Code == "slow_down":
+			interval += f.slowDown
+		case ctx.Err() != nil:
+			return nil, context.Cause(ctx)
+		default:
+			return nil, fmt.Errorf("error polling for device access token: %w", err)
+		}
+	}
+}
+
+// post sends a form-encoded POST request to one of the unauthenticated device authorization
+// endpoints and decodes the JSON response into v. OAuth errors are returned as a
+// *DeviceAuthError.
+func (f *deviceFlow) post(ctx context.Context, opID, path string, form url.Values, v any) error {
+	c := f.client
+	ctx = context.WithValue(ctx, operationKey{}, Operation{
+		ID:           opID,
+		Method:       http.MethodPost,
+		PathTemplate: path,
+	})
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		resolveRelative(c.host, path),
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("API-Version", c.APIVersion())
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+			return &DeviceAuthError{Code: oauthErr.Error, Description: oauthErr.ErrorDescription}
+		}
+		// Fall back to the regular API error, restoring the body we consumed.
+		resp.Body = io.NopCloser(strings.NewReader(string(body)))
+		return NewHTTPError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error decoding response body: %v", err)
+	}
+	return nil
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
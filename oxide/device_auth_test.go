@@ -0,0 +1,203 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deviceAuthServer returns a server implementing the device authorization endpoints. The token
+// endpoint replies with each of the given responses in turn, repeating the last one.
+func deviceAuthServer(t *testing.T, tokenResponses ...string) *httptest.Server {
+	t.Helper()
+
+	var polls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /device/auth", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "3fa85f64-5717-4562-b3fc-2c963f66afa6", r.PostForm.Get("client_id"))
+		assert.Equal(t, "3600", r.PostForm.Get("ttl_seconds"))
+		w.Write([]byte(`{"device_code":"dev-code","user_code":"ABCD-EFGH",` +
+			`"verification_uri":"https://oxide.example.com/device/verify","expires_in":300}`))
+	})
+	mux.HandleFunc("POST /device/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, deviceCodeGrantType, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "dev-code", r.PostForm.Get("device_code"))
+
+		resp := tokenResponses[min(polls, len(tokenResponses)-1)]
+		polls++
+		if resp[2:7] == "error" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(resp))
+	})
+	mux.HandleFunc("GET /v1/me", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer oxide-token-abc", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"id":"u1","display_name":"me"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_DeviceLogin(t *testing.T) {
+	server := deviceAuthServer(
+		t,
+		`{"error":"authorization_pending"}`,
+		`{"access_token":"oxide-token-abc","token_type":"Bearer","token_id":"t1"}`,
+	)
+
+	var (
+		prompted   DeviceAuthorization
+		operations []string
+	)
+	client, err := DeviceLogin(context.Background(), server.URL, DeviceLoginOptions{
+		ClientID:     "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+		TTL:          time.Hour,
+		PollInterval: time.Millisecond,
+		Prompt: func(ctx context.Context, auth DeviceAuthorization) error {
+			prompted = auth
+			return nil
+		},
+		ClientOptions: []ClientOption{
+			WithMiddleware(func(next Doer) Doer {
+				return DoerFunc(func(req *http.Request) (*http.Response, error) {
+					op, _ := OperationFromContext(req.Context())
+					operations = append(operations, op.ID)
+					return next.Do(req)
+				})
+			}),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]string{"device_auth_request", "device_access_token", "device_access_token"},
+		operations,
+	)
+
+	assert.Equal(t, DeviceAuthorization{
+		UserCode:        "ABCD-EFGH",
+		VerificationURI: "https://oxide.example.com/device/verify",
+		ExpiresIn:       5 * time.Minute,
+	}, prompted)
+
+	user, err := client.CurrentUserView(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "me", user.DisplayName)
+}
+
+func Test_DeviceLogin_slowDown(t *testing.T) {
+	server := deviceAuthServer(
+		t,
+		`{"error":"slow_down"}`,
+		`{"access_token":"oxide-token-abc","token_type":"Bearer","token_id":"t1"}`,
+	)
+	f, err := newDeviceFlow(server.URL, DeviceLoginOptions{
+		ClientID:     "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+		TTL:          time.Hour,
+		PollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, deviceSlowDownIncrement, f.slowDown)
+	f.slowDown = 10 * time.Millisecond
+
+	start := time.Now()
+	grant, err := f.run(
+		context.Background(),
+		func(ctx context.Context, auth DeviceAuthorization) error { return nil },
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "oxide-token-abc", grant.AccessToken)
+	assert.GreaterOrEqual(t, time.Since(start), 12*time.Millisecond)
+}
+
+func Test_DeviceLogin_errors(t *testing.T) {
+	prompt := func(ctx context.Context, auth DeviceAuthorization) error { return nil }
+
+	t.Run("fails when access is denied", func(t *testing.T) {
+		server := deviceAuthServer(t, `{"error":"access_denied"}`)
+
+		_, err := DeviceLogin(context.Background(), server.URL, DeviceLoginOptions{
+			ClientID:     "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+			TTL:          time.Hour,
+			PollInterval: time.Millisecond,
+			Prompt:       prompt,
+		})
+		var authErr *DeviceAuthError
+		require.ErrorAs(t, err, &authErr)
+		assert.Equal(t, "access_denied", authErr.Code)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		server := deviceAuthServer(t, `{"error":"authorization_pending"}`)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := DeviceLogin(ctx, server.URL, DeviceLoginOptions{
+			ClientID:     "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+			TTL:          time.Hour,
+			PollInterval: time.Millisecond,
+			Prompt:       prompt,
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("surfaces prompt errors", func(t *testing.T) {
+		server := deviceAuthServer(t, `{"error":"authorization_pending"}`)
+		errPrompt := errors.New("no terminal")
+
+		_, err := DeviceLogin(context.Background(), server.URL, DeviceLoginOptions{
+			ClientID: "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+			TTL:      time.Hour,
+			Prompt: func(ctx context.Context, auth DeviceAuthorization) error {
+				return errPrompt
+			},
+		})
+		assert.ErrorIs(t, err, errPrompt)
+	})
+
+	t.Run("requires a prompt", func(t *testing.T) {
+		_, err := DeviceLogin(context.Background(), "http://localhost", DeviceLoginOptions{})
+		assert.EqualError(t, err, "device login prompt is required")
+	})
+
+	t.Run("rejects a token or profile before logging in", func(t *testing.T) {
+		for _, opt := range []ClientOption{
+			WithToken("oxide-token-old"),
+			WithProfile("default"),
+			WithDefaultProfile(),
+		} {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) { requests++ },
+			))
+			t.Cleanup(server.Close)
+
+			_, err := DeviceLogin(context.Background(), server.URL, DeviceLoginOptions{
+				Prompt:        prompt,
+				ClientOptions: []ClientOption{opt},
+			})
+			assert.EqualError(t, err, "device login client options cannot set a token or profile")
+			assert.Zero(t, requests)
+		}
+	})
+}
+
+func Test_newUUID(t *testing.T) {
+	id := newUUID()
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+	assert.NotEqual(t, id, newUUID())
+}
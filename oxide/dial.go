@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"time"
+)
+
+// DialContextFunc opens a network connection to addr, as [net.Dialer.DialContext] does.
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// WithDialContext sets the function used to open connections to the Oxide API, e.g. to tunnel
+// through a bastion host. It's combined with [WithResolve] if both are set. This option is
+// overridden if [WithHTTPClient] is set after this option.
+func WithDialContext(dial DialContextFunc) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		if dial == nil {
+			return errors.New("dial function must not be nil")
+		}
+		cfg.dialContext = dial
+		cfg.transport().DialContext = cfg.dialer()
+		return nil
+	})
+}
+
+// WithResolve connects to fixed addresses instead of resolving host names through DNS, like curl's
+// --resolve flag. This is useful for racks whose DNS records don't exist yet.
+//
+// Keys are host names, optionally with a port (e.g., "oxide.sys.example.com" or
+// "oxide.sys.example.com:443"), and values are IP addresses, optionally with a port. Entries with a
+// port take precedence. The host name is still used for TLS server name verification and the Host
+// header. Calling this option multiple times merges the entries. This option is overridden if
+// [WithHTTPClient] is set after this option.
+func WithResolve(hosts map[string]string) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		var errs []error
+		for host, addr := range hosts {
+			if host == "" {
+				errs = append(errs, errors.New("resolve host must not be empty"))
+				continue
+			}
+			ip := addr
+			if h, _, err := net.SplitHostPort(addr); err == nil {
+				ip = h
+			}
+			if net.ParseIP(ip) == nil {
+				errs = append(errs, fmt.Errorf("invalid IP address %q for host %q", addr, host))
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+
+		if cfg.resolve == nil {
+			cfg.resolve = make(map[string]string, len(hosts))
+		}
+		maps.Copy(cfg.resolve, hosts)
+		cfg.transport().DialContext = cfg.dialer()
+		return nil
+	})
+}
+
+// dialer returns the dial function for the configured dialer and static host entries.
+func (cfg *clientConfig) dialer() DialContextFunc {
+	dial := cfg.dialContext
+	if dial == nil {
+		// Match the dialer used by http.DefaultTransport.
+		dial = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	if len(cfg.resolve) == 0 {
+		return dial
+	}
+
+	resolve := maps.Clone(cfg.resolve)
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dial(ctx, network, resolveAddr(resolve, addr))
+	}
+}
+
+// resolveAddr returns the address to connect to for addr, a "host:port" pair, using the static
+// host entries in resolve.
+func resolveAddr(resolve map[string]string, addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	target, ok := resolve[addr]
+	if !ok {
+		if target, ok = resolve[host]; !ok {
+			return addr
+		}
+	}
+	if _, _, err := net.SplitHostPort(target); err == nil {
+		return target
+	}
+	return net.JoinHostPort(target, port)
+}
@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithResolve(t *testing.T) {
+	var host, serverName string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		serverName = r.TLS.ServerName
+		w.Write([]byte(`{"name":"my-project"}`))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	ip, port, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	// The test certificate is valid for example.com, which doesn't resolve to the test server.
+	client, err := NewClient(
+		WithHost("https://example.com:"+port),
+		WithToken("foo"),
+		WithRootCAs(pool),
+		WithResolve(map[string]string{"example.com": ip}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+	require.NoError(t, err)
+	assert.Equal(t, "example.com:"+port, host)
+	assert.Equal(t, "example.com", serverName)
+}
+
+func Test_WithDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"my-project"}`))
+	}))
+	defer server.Close()
+
+	serverAddr := server.Listener.Addr().String()
+	var dialed []string
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		return (&net.Dialer{}).DialContext(ctx, network, serverAddr)
+	}
+
+	t.Run("custom dialer", func(t *testing.T) {
+		dialed = nil
+		client, err := NewClient(
+			WithHost("http://oxide.invalid"),
+			WithToken("foo"),
+			WithDialContext(dial),
+		)
+		require.NoError(t, err)
+
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"oxide.invalid:80"}, dialed)
+	})
+
+	t.Run("custom dialer with static hosts", func(t *testing.T) {
+		dialed = nil
+		client, err := NewClient(
+			WithHost("http://oxide.invalid"),
+			WithToken("foo"),
+			WithResolve(map[string]string{"oxide.invalid": "192.0.2.1"}),
+			WithDialContext(dial),
+		)
+		require.NoError(t, err)
+
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"192.0.2.1:80"}, dialed)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := NewClient(
+			WithHost("http://oxide.invalid"),
+			WithToken("foo"),
+			WithDialContext(nil),
+			WithResolve(map[string]string{"oxide.invalid": "not-an-ip"}),
+		)
+		assert.ErrorContains(t, err, "dial function must not be nil")
+		assert.ErrorContains(t, err, `invalid IP address "not-an-ip" for host "oxide.invalid"`)
+	})
+}
+
+func Test_resolveAddr(t *testing.T) {
+	resolve := map[string]string{
+		"oxide.sys.example.com":      "192.0.2.1",
+		"oxide.sys.example.com:8443": "192.0.2.2:443",
+		"v6.example.com":             "2001:db8::1",
+	}
+
+	tests := []struct {
+		addr     string
+		expected string
+	}{
+		{addr: "oxide.sys.example.com:443", expected: "192.0.2.1:443"},
+		{addr: "oxide.sys.example.com:8443", expected: "192.0.2.2:443"},
+		{addr: "v6.example.com:443", expected: "[2001:db8::1]:443"},
+		{addr: "other.example.com:443", expected: "other.example.com:443"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.addr, func(t *testing.T) {
+			assert.Equal(t, tc.expected, resolveAddr(resolve, tc.addr))
+		})
+	}
+}
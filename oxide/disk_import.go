@@ -0,0 +1,559 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oxidecomputer/oxide.go/oxide/diskimage"
+)
+
+const (
+	// defaultImportBlockSize is the block size of imported disks when ImportDiskParams.BlockSize
+	// is unset.
+	defaultImportBlockSize BlockSize = 512
+
+	// maxImportChunkSize is the largest amount of data Nexus accepts in a single bulk write.
+	maxImportChunkSize = 512 * 1024
+
+	// defaultImportConcurrency is the number of parallel bulk writes when
+	// ImportDiskParams.Concurrency is unset.
+	defaultImportConcurrency = 8
+
+	// diskSizeAlignment is the granularity of disk sizes.
+	diskSizeAlignment = 1 << 30
+
+	// importCleanupTimeout bounds the requests made to clean up after a failed import.
+	importCleanupTimeout = time.Minute
+)
+
+// ImportDiskParams is the request parameters for ImportDiskFromReader and ImportDiskFromFile.
+//
+// Required fields:
+// - Project
+// - Name
+// - Size, for ImportDiskFromReader
+type ImportDiskParams struct {
+	// Project is the name or ID of the project the disk is created in.
+	Project NameOrId
+
+	// Name is the name of the disk.
+	Name Name
+
+	// Description is the description of the disk.
+	Description string
+
+	// Size is the size of the disk, which must be a multiple of 1 GiB and at least as large as the
+	// imported data. ImportDiskFromFile defaults it to the size of the file rounded up to 1 GiB.
+	Size ByteCount
+
+	// BlockSize is the block size of the disk: 512, 2048 or 4096. Defaults to 512.
+	BlockSize BlockSize
+
+	// ChunkSize is the amount of data sent in each bulk write. It must be a multiple of the block
+	// size and at most 512 KiB, which is also the default.
+	ChunkSize int
+
+	// Concurrency is the maximum number of bulk writes in flight. Defaults to 8.
+	Concurrency int
+
+	// SnapshotName, if set, is the name of a snapshot of the disk taken when the import is
+	// finalized.
+	SnapshotName Name
+
+	// Image, if set, creates an image from the snapshot of the disk. It requires SnapshotName.
+	Image *ImportDiskImage
+
+	// Progress, if set, is called every time a chunk of the disk is written or skipped. Calls are
+	// never concurrent.
+	Progress func(ImportProgress)
+}
+
+// ImportDiskImage describes the image created from an imported disk.
+//
+// Required fields:
+// - Name
+// - OS
+// - Version
+type ImportDiskImage struct {
+	// Name is the name of the image.
+	Name Name
+
+	// Description is the description of the image.
+	Description string
+
+	// OS is the family of the operating system, e.g. Debian.
+	OS string
+
+	// Version is the version of the operating system, e.g. 12.
+	Version string
+}
+
+// ImportProgress reports the progress of a disk import.
+type ImportProgress struct {
+	// TotalBytes is the size of the disk.
+	TotalBytes uint64
+
+	// WrittenBytes is the number of bytes uploaded so far.
+	WrittenBytes uint64
+
+	// SkippedBytes is the number of bytes that weren't uploaded because they're zero.
+	SkippedBytes uint64
+}
+
+// ImportDiskResult holds the resources created by a disk import. Snapshot and Image are only set
+// when they were requested.
+type ImportDiskResult struct {
+	Disk     *Disk
+	Snapshot *Snapshot
+	Image    *Image
+}
+
+// Validate verifies all required fields for ImportDiskParams are set and consistent.
+func (p *ImportDiskParams) Validate() error {
+	v := new(Validator)
+	v.HasRequiredStr(string(p.Project), "Project")
+	v.HasRequiredStr(string(p.Name), "Name")
+	if p.Size == 0 {
+		v.err = errors.Join(v.err, errors.New("required value for Size is zero"))
+	}
+	switch p.BlockSize {
+	case 0, 512, 2048, 4096:
+	default:
+		v.err = errors.Join(v.err, fmt.Errorf("invalid block size %d", p.BlockSize))
+	}
+	if p.ChunkSize < 0 || p.ChunkSize > maxImportChunkSize ||
+		p.ChunkSize%int(p.blockSize()) != 0 {
+		v.err = errors.Join(
+			v.err,
+			fmt.Errorf(
+				"invalid chunk size %d: must be a multiple of the block size and at most %d",
+				p.ChunkSize,
+				maxImportChunkSize,
+			),
+		)
+	}
+	if p.Concurrency < 0 {
+		v.err = errors.Join(v.err, errors.New("concurrency must not be negative"))
+	}
+	if p.Image != nil {
+		if p.SnapshotName == "" {
+			v.err = errors.Join(v.err, errors.New("an image requires a SnapshotName"))
+		}
+		v.HasRequiredStr(string(p.Image.Name), "Image.Name")
+		v.HasRequiredStr(p.Image.OS, "Image.OS")
+		v.HasRequiredStr(p.Image.Version, "Image.Version")
+	}
+	if !v.IsValid() {
+		return fmt.Errorf("validation error:\n%v", v.Error())
+	}
+	return nil
+}
+
+func (p *ImportDiskParams) blockSize() BlockSize {
+	if p.BlockSize == 0 {
+		return defaultImportBlockSize
+	}
+	return p.BlockSize
+}
+
+func (p *ImportDiskParams) chunkSize() int {
+	if p.ChunkSize == 0 {
+		return maxImportChunkSize
+	}
+	return p.ChunkSize
+}
+
+func (p *ImportDiskParams) concurrency() int {
+	if p.Concurrency == 0 {
+		return defaultImportConcurrency
+	}
+	return p.Concurrency
+}
+
+// ImportDiskFromFile creates a disk from a disk image file. qcow2 and VMDK images are read with
+// the diskimage package, as in ImportDiskFromImage, and other files are read as raw images, as in
+// ImportDiskFromReader. The disk size defaults to the size of the virtual disk rounded up to 1 GiB.
+func (c *Client) ImportDiskFromFile(
+	ctx context.Context,
+	path string,
+	params ImportDiskParams,
+) (*ImportDiskResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	format, err := diskimage.DetectFormat(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect disk image format: %w", err)
+	}
+	if format != diskimage.FormatRaw {
+		image, err := diskimage.NewReader(f, info.Size())
+		if err != nil {
+			return nil, err
+		}
+		return c.ImportDiskFromImage(ctx, image, params)
+	}
+
+	if params.Size == 0 {
+		params.Size = ByteCount(alignUp(uint64(info.Size()), diskSizeAlignment))
+	}
+	return c.ImportDiskFromReader(ctx, f, params)
+}
+
+// ImportDiskFromImage creates a disk from a virtual disk image, such as a qcow2 or VMDK image read
+// with the diskimage package. Only the allocated extents of the image are read and uploaded. The
+// disk size defaults to the size of the virtual disk rounded up to 1 GiB. See
+// ImportDiskFromReader for the rest of the workflow.
+func (c *Client) ImportDiskFromImage(
+	ctx context.Context,
+	image diskimage.SparseReader,
+	params ImportDiskParams,
+) (*ImportDiskResult, error) {
+	if params.Size == 0 {
+		params.Size = ByteCount(alignUp(image.Size(), diskSizeAlignment))
+	}
+
+	chunkSize := uint64(params.chunkSize())
+	end := alignUp(image.Size(), uint64(params.blockSize()))
+	var (
+		extent diskimage.Extent
+		chunk  *importChunk
+	)
+	// Extents are copied into chunk-aligned windows, so that bulk writes are block-aligned
+	// whatever the granularity of the image, and small extents are coalesced.
+	next := func() (importChunk, error) {
+		for {
+			if len(extent.Data) == 0 {
+				var err error
+				if extent, err = image.Next(); err == io.EOF {
+					if chunk == nil {
+						return importChunk{}, io.EOF
+					}
+					ready := *chunk
+					chunk = nil
+					return ready, nil
+				} else if err != nil {
+					return importChunk{}, err
+				}
+				continue
+			}
+			if extent.Offset >= end {
+				extent.Data = nil
+				continue
+			}
+
+			offset := extent.Offset / chunkSize * chunkSize
+			if chunk != nil && chunk.offset != offset {
+				ready := *chunk
+				chunk = nil
+				return ready, nil
+			}
+			if chunk == nil {
+				chunk = &importChunk{
+					offset: offset,
+					data:   make([]byte, min(chunkSize, end-offset)),
+				}
+			}
+			n := copy(chunk.data[extent.Offset-offset:], extent.Data)
+			extent.Offset += uint64(n)
+			extent.Data = extent.Data[n:]
+		}
+	}
+	return c.importDisk(ctx, params, next)
+}
+
+// ImportDiskFromReader creates a disk from the raw disk image read from r.
+//
+// The disk is created in the import_ready state, and the image is uploaded with parallel bulk
+// writes. Blocks that only hold zeros are skipped, since disks start zeroed. The import is then
+// finalized, optionally into a snapshot and an image.
+//
+// If the upload fails or ctx is done, the bulk writes are stopped and the disk is deleted before
+// returning.
+func (c *Client) ImportDiskFromReader(
+	ctx context.Context,
+	r io.Reader,
+	params ImportDiskParams,
+) (*ImportDiskResult, error) {
+	blockSize := int(params.blockSize())
+	chunkSize := params.chunkSize()
+	var offset uint64
+	next := func() (importChunk, error) {
+		buf := make([]byte, chunkSize)
+		n, err := io.ReadFull(r, buf)
+		switch {
+		case err == io.EOF:
+			return importChunk{}, io.EOF
+		case err != nil && err != io.ErrUnexpectedEOF:
+			return importChunk{}, err
+		}
+
+		// Bulk writes must cover whole blocks, and disks start zeroed.
+		chunk := importChunk{offset: offset, data: buf[:alignUp(uint64(n), uint64(blockSize))]}
+		offset += uint64(n)
+		return chunk, nil
+	}
+	return c.importDisk(ctx, params, next)
+}
+
+// importChunk is a chunk of a disk image at offset.
+type importChunk struct {
+	offset uint64
+	data   []byte
+}
+
+// importDisk creates a disk and writes the chunks returned by next to it until next returns
+// io.EOF, then finalizes the import.
+func (c *Client) importDisk(
+	ctx context.Context,
+	params ImportDiskParams,
+	next func() (importChunk, error),
+) (*ImportDiskResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	disk, err := c.DiskCreate(ctx, DiskCreateParams{
+		Project: params.Project,
+		Body: &DiskCreate{
+			Name:        params.Name,
+			Description: params.Description,
+			Size:        params.Size,
+			DiskBackend: DiskBackend{Value: &DiskBackendDistributed{
+				DiskSource: DiskSource{Value: &DiskSourceImportingBlocks{
+					BlockSize: params.blockSize(),
+				}},
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create disk: %w", err)
+	}
+	diskRef := NameOrId(disk.Id)
+
+	if err := c.DiskBulkWriteImportStart(ctx, DiskBulkWriteImportStartParams{
+		Disk: diskRef,
+	}); err != nil {
+		return nil, c.abortDiskImport(ctx, diskRef, false, fmt.Errorf(
+			"failed to start bulk writes: %w",
+			err,
+		))
+	}
+
+	if err := c.uploadChunks(ctx, params, diskRef, next); err != nil {
+		return nil, c.abortDiskImport(ctx, diskRef, true, err)
+	}
+
+	if err := c.DiskBulkWriteImportStop(ctx, DiskBulkWriteImportStopParams{
+		Disk: diskRef,
+	}); err != nil {
+		return nil, c.abortDiskImport(ctx, diskRef, false, fmt.Errorf(
+			"failed to stop bulk writes: %w",
+			err,
+		))
+	}
+
+	if err := c.DiskFinalizeImport(ctx, DiskFinalizeImportParams{
+		Disk: diskRef,
+		Body: &FinalizeDisk{SnapshotName: params.SnapshotName},
+	}); err != nil {
+		return nil, c.abortDiskImport(ctx, diskRef, false, fmt.Errorf(
+			"failed to finalize import: %w",
+			err,
+		))
+	}
+
+	// The disk is complete from here on, so it's kept even if the image can't be created.
+	result := &ImportDiskResult{}
+	if result.Disk, err = c.DiskView(ctx, DiskViewParams{Disk: diskRef}); err != nil {
+		return nil, err
+	}
+	if params.SnapshotName == "" {
+		return result, nil
+	}
+
+	if result.Snapshot, err = c.SnapshotView(ctx, SnapshotViewParams{
+		Project:  params.Project,
+		Snapshot: NameOrId(params.SnapshotName),
+	}); err != nil {
+		return result, fmt.Errorf("failed to fetch snapshot: %w", err)
+	}
+	if params.Image == nil {
+		return result, nil
+	}
+
+	if result.Image, err = c.ImageCreate(ctx, ImageCreateParams{
+		Project: params.Project,
+		Body: &ImageCreate{
+			Name:        params.Image.Name,
+			Description: params.Image.Description,
+			Os:          params.Image.OS,
+			Version:     params.Image.Version,
+			Source:      ImageSource{Value: &ImageSourceSnapshot{Id: result.Snapshot.Id}},
+		},
+	}); err != nil {
+		return result, fmt.Errorf("failed to create image: %w", err)
+	}
+	return result, nil
+}
+
+// uploadChunks writes the chunks returned by next to a disk with up to params.Concurrency bulk
+// writes in flight. It returns the first error, after all bulk writes in flight are done.
+func (c *Client) uploadChunks(
+	ctx context.Context,
+	params ImportDiskParams,
+	disk NameOrId,
+	next func() (importChunk, error),
+) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		mu       sync.Mutex
+		progress = ImportProgress{TotalBytes: uint64(params.Size)}
+	)
+	report := func(written, skipped int) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress.WrittenBytes += uint64(written)
+		progress.SkippedBytes += uint64(skipped)
+		if params.Progress != nil {
+			params.Progress(progress)
+		}
+	}
+
+	blockSize := int(params.blockSize())
+	chunks := make(chan importChunk)
+	var wg sync.WaitGroup
+	for range params.concurrency() {
+		wg.Go(func() {
+			for chunk := range chunks {
+				var written int
+				for _, run := range nonZeroRuns(chunk.data, blockSize) {
+					if err := c.DiskBulkWriteImport(ctx, DiskBulkWriteImportParams{
+						Disk: disk,
+						Body: &ImportBlocksBulkWrite{
+							Base64EncodedData: base64.StdEncoding.EncodeToString(
+								chunk.data[run[0]:run[1]],
+							),
+							Offset: NewPointer(chunk.offset + uint64(run[0])),
+						},
+					}); err != nil {
+						cancel(fmt.Errorf(
+							"failed to write %d bytes at offset %d: %w",
+							run[1]-run[0],
+							chunk.offset+uint64(run[0]),
+							err,
+						))
+						return
+					}
+					written += run[1] - run[0]
+				}
+				report(written, len(chunk.data)-written)
+			}
+		})
+	}
+
+	var readErr error
+read:
+	for {
+		chunk, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			readErr = fmt.Errorf("failed to read disk image: %w", err)
+			break
+		}
+		if end := chunk.offset + uint64(len(chunk.data)); end > uint64(params.Size) {
+			readErr = fmt.Errorf("disk image is larger than the disk size of %d bytes", params.Size)
+			break
+		}
+
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+			break read
+		}
+	}
+	close(chunks)
+	wg.Wait()
+
+	if readErr != nil {
+		return readErr
+	}
+	return context.Cause(ctx)
+}
+
+// abortDiskImport stops the bulk writes to a disk, if they were started, and deletes it. It
+// returns err along with any cleanup error. The cleanup requests outlive the cancellation of ctx.
+func (c *Client) abortDiskImport(
+	ctx context.Context,
+	disk NameOrId,
+	stopBulkWrites bool,
+	err error,
+) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), importCleanupTimeout)
+	defer cancel()
+
+	if stopBulkWrites {
+		if stopErr := c.DiskBulkWriteImportStop(ctx, DiskBulkWriteImportStopParams{
+			Disk: disk,
+		}); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop bulk writes: %w", stopErr))
+		}
+	}
+	if deleteErr := c.DiskDelete(ctx, DiskDeleteParams{Disk: disk}); deleteErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to delete disk: %w", deleteErr))
+	}
+	return err
+}
+
+// nonZeroRuns returns the [start, end) ranges of data covered by consecutive blocks that aren't
+// all zeros.
+func nonZeroRuns(data []byte, blockSize int) [][2]int {
+	var runs [][2]int
+	for start := 0; start < len(data); start += blockSize {
+		end := min(start+blockSize, len(data))
+		if isZero(data[start:end]) {
+			continue
+		}
+		if n := len(runs); n > 0 && runs[n-1][1] == start {
+			runs[n-1][1] = end
+			continue
+		}
+		runs = append(runs, [2]int{start, end})
+	}
+	return runs
+}
+
+// isZero reports whether b only holds zeros.
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// alignUp rounds n up to a multiple of alignment.
+func alignUp(n, alignment uint64) uint64 {
+	return (n + alignment - 1) / alignment * alignment
+}
@@ -0,0 +1,387 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/oxidecomputer/oxide.go/oxide/diskimage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDiskImportServer emulates the Nexus endpoints used to import a disk with bulk writes. It
+// records the data written to the disk and the calls made.
+type fakeDiskImportServer struct {
+	mu     sync.Mutex
+	size   ByteCount
+	blocks map[uint64][]byte
+	calls  []string
+
+	// failWriteAt, if set, fails bulk writes at that offset.
+	failWriteAt *uint64
+
+	// onWrite, if set, is called on every bulk write.
+	onWrite func()
+}
+
+func (s *fakeDiskImportServer) record(call string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, call)
+}
+
+// contents returns the first n bytes of the disk.
+func (s *fakeDiskImportServer) contents(n int) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := make([]byte, n)
+	for offset, block := range s.blocks {
+		if offset < uint64(n) {
+			copy(data[offset:], block)
+		}
+	}
+	return data
+}
+
+func newFakeDiskImportServer(t *testing.T, s *fakeDiskImportServer) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/disks", func(w http.ResponseWriter, r *http.Request) {
+		var body DiskCreate
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		s.record("create")
+		s.mu.Lock()
+		s.size = body.Size
+		s.blocks = make(map[uint64][]byte)
+		s.mu.Unlock()
+		json.NewEncoder(w).Encode(Disk{Id: "disk-id", Name: body.Name, Size: body.Size})
+	})
+	mux.HandleFunc(
+		"POST /v1/disks/{disk}/bulk-write-start",
+		func(w http.ResponseWriter, r *http.Request) {
+			s.record("start")
+			w.WriteHeader(http.StatusNoContent)
+		},
+	)
+	mux.HandleFunc(
+		"POST /v1/disks/{disk}/bulk-write",
+		func(w http.ResponseWriter, r *http.Request) {
+			var body ImportBlocksBulkWrite
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if s.onWrite != nil {
+				s.onWrite()
+			}
+			if s.failWriteAt != nil && *s.failWriteAt == *body.Offset {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"request_id":"1","error_code":"Internal","message":"boom"}`))
+				return
+			}
+			data, err := base64.StdEncoding.DecodeString(body.Base64EncodedData)
+			require.NoError(t, err)
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.blocks[*body.Offset] = data
+			w.WriteHeader(http.StatusNoContent)
+		},
+	)
+	mux.HandleFunc(
+		"POST /v1/disks/{disk}/bulk-write-stop",
+		func(w http.ResponseWriter, r *http.Request) {
+			s.record("stop")
+			w.WriteHeader(http.StatusNoContent)
+		},
+	)
+	mux.HandleFunc("POST /v1/disks/{disk}/finalize", func(w http.ResponseWriter, r *http.Request) {
+		var body FinalizeDisk
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		s.record("finalize " + string(body.SnapshotName))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /v1/disks/{disk}", func(w http.ResponseWriter, r *http.Request) {
+		s.record("view")
+		w.Write([]byte(`{"id":"disk-id","name":"my-disk","state":{"state":"detached"}}`))
+	})
+	mux.HandleFunc("DELETE /v1/disks/{disk}", func(w http.ResponseWriter, r *http.Request) {
+		s.record("delete")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /v1/snapshots/{snapshot}", func(w http.ResponseWriter, r *http.Request) {
+		s.record("snapshot " + r.PathValue("snapshot"))
+		w.Write([]byte(`{"id":"snapshot-id","name":"my-snapshot"}`))
+	})
+	mux.HandleFunc("POST /v1/images", func(w http.ResponseWriter, r *http.Request) {
+		var body ImageCreate
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		source, ok := body.Source.Value.(*ImageSourceSnapshot)
+		require.True(t, ok)
+		s.record("image " + source.Id)
+		json.NewEncoder(w).Encode(Image{Id: "image-id", Name: body.Name})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+	require.NoError(t, err)
+	return client
+}
+
+// testDiskImage returns a disk image of size bytes where only a few ranges hold data.
+func testDiskImage(size int) []byte {
+	image := make([]byte, size)
+	copy(image[0:], bytes.Repeat([]byte{1}, 1000))
+	copy(image[600*1024:], bytes.Repeat([]byte{2}, 512))
+	copy(image[size-100:], bytes.Repeat([]byte{3}, 100))
+	return image
+}
+
+func Test_ImportDiskFromReader(t *testing.T) {
+	ctx := context.Background()
+	params := ImportDiskParams{Project: "my-project", Name: "my-disk", Size: 4 * 1024 * 1024}
+
+	t.Run("writes non-zero blocks", func(t *testing.T) {
+		server := &fakeDiskImportServer{}
+		client := newFakeDiskImportServer(t, server)
+		image := testDiskImage(2*1024*1024 + 100)
+
+		var progress []ImportProgress
+		params := params
+		params.Concurrency = 3
+		params.Progress = func(p ImportProgress) { progress = append(progress, p) }
+
+		result, err := client.ImportDiskFromReader(ctx, bytes.NewReader(image), params)
+		require.NoError(t, err)
+		assert.Equal(t, "disk-id", result.Disk.Id)
+		assert.Nil(t, result.Snapshot)
+		assert.Nil(t, result.Image)
+
+		assert.Equal(t, []string{"create", "start", "stop", "finalize ", "view"}, server.calls)
+		assert.Equal(t, image, server.contents(len(image)))
+		assert.True(t, isZero(server.contents(int(params.Size))[len(image):]))
+		assert.Len(t, server.blocks, 3)
+
+		require.Len(t, progress, 5)
+		assert.Equal(t, ImportProgress{
+			TotalBytes:   4 * 1024 * 1024,
+			WrittenBytes: 1024 + 512 + 512,
+			SkippedBytes: 2*1024*1024 + 512 - 2048,
+		}, progress[4])
+	})
+
+	t.Run("creates a snapshot and an image", func(t *testing.T) {
+		server := &fakeDiskImportServer{}
+		client := newFakeDiskImportServer(t, server)
+
+		params := params
+		params.SnapshotName = "my-snapshot"
+		params.Image = &ImportDiskImage{Name: "my-image", OS: "debian", Version: "12"}
+
+		result, err := client.ImportDiskFromReader(
+			ctx,
+			bytes.NewReader(testDiskImage(1024*1024)),
+			params,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "snapshot-id", result.Snapshot.Id)
+		assert.Equal(t, "image-id", result.Image.Id)
+		assert.Equal(t, []string{
+			"create",
+			"start",
+			"stop",
+			"finalize my-snapshot",
+			"view",
+			"snapshot my-snapshot",
+			"image snapshot-id",
+		}, server.calls)
+	})
+
+	t.Run("aborts on a failed write", func(t *testing.T) {
+		server := &fakeDiskImportServer{failWriteAt: NewPointer(uint64(600 * 1024))}
+		client := newFakeDiskImportServer(t, server)
+
+		_, err := client.ImportDiskFromReader(
+			ctx,
+			bytes.NewReader(testDiskImage(1024*1024)),
+			params,
+		)
+		assert.ErrorContains(t, err, "failed to write 512 bytes at offset 614400")
+		assert.Equal(t, []string{"create", "start", "stop", "delete"}, server.calls)
+	})
+
+	t.Run("aborts when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		server := &fakeDiskImportServer{onWrite: cancel}
+		client := newFakeDiskImportServer(t, server)
+
+		_, err := client.ImportDiskFromReader(
+			ctx,
+			bytes.NewReader(testDiskImage(1024*1024)),
+			params,
+		)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []string{"create", "start", "stop", "delete"}, server.calls)
+	})
+
+	t.Run("rejects images larger than the disk", func(t *testing.T) {
+		server := &fakeDiskImportServer{}
+		client := newFakeDiskImportServer(t, server)
+
+		params := params
+		params.Size = 1024 * 1024
+		_, err := client.ImportDiskFromReader(
+			ctx,
+			bytes.NewReader(testDiskImage(1024*1024+1)),
+			params,
+		)
+		assert.ErrorContains(t, err, "disk image is larger than the disk size of 1048576 bytes")
+		assert.Equal(t, []string{"create", "start", "stop", "delete"}, server.calls)
+	})
+
+	t.Run("validates the parameters", func(t *testing.T) {
+		client, err := NewClient(WithHost("http://127.0.0.1:0"), WithToken("foo"))
+		require.NoError(t, err)
+
+		_, err = client.ImportDiskFromReader(ctx, bytes.NewReader(nil), ImportDiskParams{
+			BlockSize: 1024,
+			ChunkSize: 1000,
+			Image:     &ImportDiskImage{},
+		})
+		assert.EqualError(t, err, `validation error:
+required value for Project is an empty string
+required value for Name is an empty string
+required value for Size is zero
+invalid block size 1024
+invalid chunk size 1000: must be a multiple of the block size and at most 524288
+an image requires a SnapshotName
+required value for Image.Name is an empty string
+required value for Image.OS is an empty string
+required value for Image.Version is an empty string`)
+	})
+}
+
+func Test_ImportDiskFromFile(t *testing.T) {
+	server := &fakeDiskImportServer{}
+	client := newFakeDiskImportServer(t, server)
+
+	image := testDiskImage(1024 * 1024)
+	path := filepath.Join(t.TempDir(), "disk.raw")
+	require.NoError(t, os.WriteFile(path, image, 0o600))
+
+	_, err := client.ImportDiskFromFile(context.Background(), path, ImportDiskParams{
+		Project: "my-project",
+		Name:    "my-disk",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ByteCount(1<<30), server.size)
+	assert.Equal(t, image, server.contents(len(image)))
+}
+
+func Test_nonZeroRuns(t *testing.T) {
+	data := make([]byte, 10*512)
+	data[0] = 1
+	data[512+3] = 1
+	data[4*512] = 1
+	data[9*512+511] = 1
+	assert.Equal(t, [][2]int{{0, 1024}, {2048, 2560}, {4608, 5120}}, nonZeroRuns(data, 512))
+	assert.Nil(t, nonZeroRuns(make([]byte, 1024), 512))
+}
+
+// sliceImage is a diskimage.SparseReader over a list of extents.
+type sliceImage struct {
+	size    uint64
+	extents []diskimage.Extent
+}
+
+func (s *sliceImage) Size() uint64 {
+	return s.size
+}
+
+func (s *sliceImage) Next() (diskimage.Extent, error) {
+	if len(s.extents) == 0 {
+		return diskimage.Extent{}, io.EOF
+	}
+	extent := s.extents[0]
+	s.extents = s.extents[1:]
+	return extent, nil
+}
+
+func Test_ImportDiskFromImage(t *testing.T) {
+	server := &fakeDiskImportServer{}
+	client := newFakeDiskImportServer(t, server)
+
+	size := 3*1024*1024 + 10
+	disk := make([]byte, size)
+	image := &sliceImage{size: uint64(size)}
+	for _, extent := range []diskimage.Extent{
+		{Offset: 0, Data: bytes.Repeat([]byte{1}, 1000)},
+		{Offset: 512*1024 - 100, Data: bytes.Repeat([]byte{2}, 200)},
+		{Offset: 3 * 1024 * 1024, Data: bytes.Repeat([]byte{3}, 10)},
+	} {
+		copy(disk[extent.Offset:], extent.Data)
+		image.extents = append(image.extents, extent)
+	}
+
+	var progress ImportProgress
+	_, err := client.ImportDiskFromImage(context.Background(), image, ImportDiskParams{
+		Project:  "my-project",
+		Name:     "my-disk",
+		Progress: func(p ImportProgress) { progress = p },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ByteCount(1<<30), server.size)
+	assert.Equal(t, disk, server.contents(size))
+	assert.Len(t, server.blocks, 4)
+	assert.Equal(t, uint64(5*512), progress.WrittenBytes)
+}
+
+// buildQcow2 returns a qcow2 image of a virtual disk of size bytes whose first 64 KiB cluster
+// holds data.
+func buildQcow2(size uint64, data []byte) []byte {
+	const cluster = 64 * 1024
+	image := make([]byte, 4*cluster)
+	copy(image, []byte{'Q', 'F', 'I', 0xfb})
+	binary.BigEndian.PutUint32(image[4:], 2)
+	binary.BigEndian.PutUint32(image[20:], 16)
+	binary.BigEndian.PutUint64(image[24:], size)
+	binary.BigEndian.PutUint32(image[36:], 1)
+	binary.BigEndian.PutUint64(image[40:], cluster)
+	binary.BigEndian.PutUint64(image[cluster:], 2*cluster)
+	binary.BigEndian.PutUint64(image[2*cluster:], 3*cluster)
+	copy(image[3*cluster:], data)
+	return image
+}
+
+func Test_ImportDiskFromFile_Qcow2(t *testing.T) {
+	server := &fakeDiskImportServer{}
+	client := newFakeDiskImportServer(t, server)
+
+	data := bytes.Repeat([]byte{7}, 4096)
+	path := filepath.Join(t.TempDir(), "disk.qcow2")
+	require.NoError(t, os.WriteFile(path, buildQcow2(512<<20, data), 0o600))
+
+	_, err := client.ImportDiskFromFile(context.Background(), path, ImportDiskParams{
+		Project: "my-project",
+		Name:    "my-disk",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ByteCount(1<<30), server.size)
+	assert.Equal(t, data, server.contents(len(data)))
+	assert.Len(t, server.blocks, 1)
+}
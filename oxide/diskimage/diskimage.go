@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package diskimage reads virtual disk image formats, such as qcow2 and VMDK, as a stream of the
+// extents of the virtual disk that hold data. It lets disk images be imported without converting
+// them to raw images first, and without reading the unallocated parts of the virtual disk.
+package diskimage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format is a disk image format.
+type Format string
+
+const (
+	// FormatRaw is a raw disk image, which holds the bytes of the virtual disk as they are.
+	FormatRaw Format = "raw"
+
+	// FormatQcow2 is a QEMU copy-on-write version 2 or 3 image.
+	FormatQcow2 Format = "qcow2"
+
+	// FormatVMDK is a VMware sparse extent, either monolithicSparse or streamOptimized.
+	FormatVMDK Format = "vmdk"
+)
+
+// ErrUnsupported is returned when an image uses a feature of its format that isn't supported,
+// such as a backing file or encryption.
+var ErrUnsupported = errors.New("unsupported disk image")
+
+// Extent is a range of the virtual disk that holds data.
+type Extent struct {
+	// Offset is the offset of the extent in the virtual disk, in bytes.
+	Offset uint64
+
+	// Data is the content of the extent.
+	Data []byte
+}
+
+// SparseReader reads the allocated extents of a virtual disk. The parts of the virtual disk that
+// aren't covered by an extent read as zeros.
+type SparseReader interface {
+	// Size returns the size of the virtual disk, in bytes.
+	Size() uint64
+
+	// Next returns the next extent of the virtual disk. Extents are returned in increasing
+	// offset order and don't overlap. Next returns io.EOF after the last extent.
+	Next() (Extent, error)
+}
+
+var (
+	qcow2Magic = []byte{'Q', 'F', 'I', 0xfb}
+	vmdkMagic  = []byte{'K', 'D', 'M', 'V'}
+)
+
+// DetectFormat returns the format of the disk image read from r. Images that aren't in a known
+// format are raw images.
+func DetectFormat(r io.ReaderAt) (Format, error) {
+	magic := make([]byte, 4)
+	if _, err := r.ReadAt(magic, 0); err != nil {
+		if errors.Is(err, io.EOF) {
+			return FormatRaw, nil
+		}
+		return "", err
+	}
+
+	switch {
+	case bytes.Equal(magic, qcow2Magic):
+		return FormatQcow2, nil
+	case bytes.Equal(magic, vmdkMagic):
+		return FormatVMDK, nil
+	default:
+		return FormatRaw, nil
+	}
+}
+
+// NewReader returns a SparseReader for the qcow2 or VMDK image of size bytes read from r.
+func NewReader(r io.ReaderAt, size int64) (SparseReader, error) {
+	format, err := DetectFormat(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatQcow2:
+		return NewQcow2Reader(r)
+	case FormatVMDK:
+		return NewVMDKReader(r, size)
+	default:
+		return nil, fmt.Errorf("%w: not a qcow2 or VMDK image", ErrUnsupported)
+	}
+}
+
+// readFull reads exactly len(buf) bytes from r at off.
+func readFull(r io.ReaderAt, buf []byte, off int64) error {
+	n, err := r.ReadAt(buf, off)
+	if n == len(buf) {
+		return nil
+	}
+	if err == nil || errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("failed to read %d bytes at offset %d: %w", len(buf), off, err)
+}
@@ -0,0 +1,304 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package diskimage
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDisk returns the content of a virtual disk of size bytes where the units of unitSize bytes
+// listed in units hold data.
+func testDisk(size, unitSize int, units ...int) []byte {
+	disk := make([]byte, size)
+	for _, unit := range units {
+		start := unit * unitSize
+		for i := start; i < min(start+unitSize, size); i++ {
+			disk[i] = byte(unit + 1)
+		}
+	}
+	return disk
+}
+
+// readAll reads all the extents of r into a virtual disk, and returns it along with the number of
+// extents.
+func readAll(t *testing.T, r SparseReader) ([]byte, int) {
+	t.Helper()
+
+	disk := make([]byte, r.Size())
+	var (
+		extents int
+		last    uint64
+	)
+	for {
+		extent, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return disk, extents
+		}
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, extent.Offset, last, "extents are out of order")
+		last = extent.Offset + uint64(len(extent.Data))
+		copy(disk[extent.Offset:], extent.Data)
+		extents++
+	}
+}
+
+// qcow2Cluster describes how a cluster of a test qcow2 image is stored.
+type qcow2Cluster int
+
+const (
+	qcow2Plain qcow2Cluster = iota
+	qcow2Deflated
+	qcow2Zeroed
+)
+
+// buildQcow2 returns a version 3 qcow2 image of disk with clusters of 1<<clusterBits bytes. Only
+// the clusters listed in clusters are allocated.
+func buildQcow2(disk []byte, clusterBits uint32, clusters map[int]qcow2Cluster) []byte {
+	clusterSize := 1 << clusterBits
+	l2Entries := clusterSize / 8
+	l1Size := (len(disk) + clusterSize*l2Entries - 1) / (clusterSize * l2Entries)
+
+	// The header and the L1 table take the first two clusters.
+	image := make([]byte, 2*clusterSize)
+	copy(image, qcow2Magic)
+	binary.BigEndian.PutUint32(image[4:], 3)
+	binary.BigEndian.PutUint32(image[20:], clusterBits)
+	binary.BigEndian.PutUint64(image[24:], uint64(len(disk)))
+	binary.BigEndian.PutUint32(image[36:], uint32(l1Size))
+	binary.BigEndian.PutUint64(image[40:], uint64(clusterSize))
+	binary.BigEndian.PutUint32(image[100:], 104)
+	alloc := func(data []byte) uint64 {
+		image = append(image, make([]byte, (clusterSize-len(image)%clusterSize)%clusterSize)...)
+		offset := len(image)
+		image = append(image, data...)
+		return uint64(offset)
+	}
+
+	l2Tables := make(map[int]uint64)
+	for cluster := 0; cluster*clusterSize < len(disk); cluster++ {
+		kind, ok := clusters[cluster]
+		if !ok {
+			continue
+		}
+		l1Index, l2Index := cluster/l2Entries, cluster%l2Entries
+		if _, ok := l2Tables[l1Index]; !ok {
+			l2Tables[l1Index] = alloc(make([]byte, clusterSize))
+			binary.BigEndian.PutUint64(image[clusterSize+l1Index*8:], l2Tables[l1Index]|1<<63)
+		}
+
+		data := make([]byte, clusterSize)
+		copy(data, disk[cluster*clusterSize:])
+		var entry uint64
+		switch kind {
+		case qcow2Plain:
+			entry = alloc(data) | 1<<63
+		case qcow2Zeroed:
+			entry = alloc(data) | qcow2Zero
+		case qcow2Deflated:
+			var buf bytes.Buffer
+			w, _ := flate.NewWriter(&buf, flate.BestCompression)
+			w.Write(data)
+			w.Close()
+			// Compressed clusters aren't aligned.
+			offset := uint64(len(image) + 3)
+			image = append(image, 0, 0, 0)
+			image = append(image, buf.Bytes()...)
+			sectors := (offset%512 + uint64(buf.Len()) - 1) / 512
+			entry = qcow2Compressed | sectors<<(62-(clusterBits-8)) | offset
+		}
+		binary.BigEndian.PutUint64(image[l2Tables[l1Index]+uint64(l2Index)*8:], entry)
+	}
+	return image
+}
+
+func Test_Qcow2Reader(t *testing.T) {
+	// 512-byte clusters make 64 clusters per L2 table, so the disk needs 4 L2 tables.
+	disk := testDisk(200*512+100, 512, 0, 1, 2, 63, 64, 150, 200)
+	image := buildQcow2(disk, 9, map[int]qcow2Cluster{
+		0:   qcow2Plain,
+		1:   qcow2Deflated,
+		2:   qcow2Plain,
+		63:  qcow2Plain,
+		64:  qcow2Deflated,
+		100: qcow2Zeroed,
+		150: qcow2Plain,
+		200: qcow2Plain,
+	})
+
+	format, err := DetectFormat(bytes.NewReader(image))
+	require.NoError(t, err)
+	assert.Equal(t, FormatQcow2, format)
+
+	r, err := NewReader(bytes.NewReader(image), int64(len(image)))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(disk)), r.Size())
+
+	got, extents := readAll(t, r)
+	assert.Equal(t, disk, got)
+	assert.Equal(t, 7, extents)
+
+	t.Run("rejects backing files", func(t *testing.T) {
+		image := bytes.Clone(image)
+		binary.BigEndian.PutUint64(image[8:], 512)
+		_, err := NewQcow2Reader(bytes.NewReader(image))
+		assert.ErrorIs(t, err, ErrUnsupported)
+		assert.ErrorContains(t, err, "backing file")
+	})
+
+	t.Run("rejects incompatible features", func(t *testing.T) {
+		image := bytes.Clone(image)
+		binary.BigEndian.PutUint64(image[72:], 1<<2)
+		_, err := NewQcow2Reader(bytes.NewReader(image))
+		assert.ErrorIs(t, err, ErrUnsupported)
+		assert.ErrorContains(t, err, "qcow2 incompatible features 0x4")
+	})
+
+	t.Run("fails on truncated images", func(t *testing.T) {
+		r, err := NewQcow2Reader(bytes.NewReader(image[:len(image)-512]))
+		require.NoError(t, err)
+		for err == nil {
+			_, err = r.Next()
+		}
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+// buildVMDK returns a VMDK sparse extent of disk with grains of grainSectors sectors and grain
+// tables of gtEntries entries. Only the grains listed in grains are allocated. Stream-optimized
+// extents have compressed grains, and their grain directory is described by a footer.
+func buildVMDK(disk []byte, grainSectors, gtEntries int, grains []int, stream bool) []byte {
+	grainSize := grainSectors * vmdkSectorSize
+	gdEntries := (len(disk) + grainSize*gtEntries - 1) / (grainSize * gtEntries)
+
+	header := make([]byte, vmdkSectorSize)
+	copy(header, vmdkMagic)
+	binary.LittleEndian.PutUint32(header[4:], 1)
+	binary.LittleEndian.PutUint64(header[12:], uint64(len(disk)/vmdkSectorSize))
+	binary.LittleEndian.PutUint64(header[20:], uint64(grainSectors))
+	binary.LittleEndian.PutUint32(header[44:], uint32(gtEntries))
+	if stream {
+		binary.LittleEndian.PutUint32(header[4:], 3)
+		binary.LittleEndian.PutUint32(header[8:], vmdkCompressed|1<<17|1)
+		binary.LittleEndian.PutUint16(header[77:], vmdkCompressionDeflate)
+	}
+
+	// The header is followed by a sector for the descriptor.
+	image := append(bytes.Clone(header), make([]byte, vmdkSectorSize)...)
+	sector := func() uint32 { return uint32(len(image) / vmdkSectorSize) }
+	pad := func() {
+		image = append(
+			image,
+			make([]byte, (vmdkSectorSize-len(image)%vmdkSectorSize)%vmdkSectorSize)...)
+	}
+
+	gts := make([][]uint32, gdEntries)
+	for _, grain := range grains {
+		if gts[grain/gtEntries] == nil {
+			gts[grain/gtEntries] = make([]uint32, gtEntries)
+		}
+		gts[grain/gtEntries][grain%gtEntries] = sector()
+
+		data := disk[grain*grainSize : min((grain+1)*grainSize, len(disk))]
+		if !stream {
+			image = append(image, data...)
+			pad()
+			continue
+		}
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		w.Write(data)
+		w.Close()
+		image = binary.LittleEndian.AppendUint64(image, uint64(grain*grainSectors))
+		image = binary.LittleEndian.AppendUint32(image, uint32(buf.Len()))
+		image = append(image, buf.Bytes()...)
+		pad()
+	}
+
+	gd := make([]uint32, gdEntries)
+	for i, gt := range gts {
+		if gt == nil {
+			continue
+		}
+		gd[i] = sector()
+		for _, entry := range gt {
+			image = binary.LittleEndian.AppendUint32(image, entry)
+		}
+		pad()
+	}
+	gdOffset := uint64(sector())
+	for _, entry := range gd {
+		image = binary.LittleEndian.AppendUint32(image, entry)
+	}
+	pad()
+
+	if !stream {
+		binary.LittleEndian.PutUint64(image[56:], gdOffset)
+		return image
+	}
+	binary.LittleEndian.PutUint64(image[56:], vmdkGDAtEnd)
+	footer := bytes.Clone(header)
+	binary.LittleEndian.PutUint64(footer[56:], gdOffset)
+	image = append(image, make([]byte, vmdkSectorSize)...)
+	image = append(image, footer...)
+	return append(image, make([]byte, vmdkSectorSize)...)
+}
+
+func Test_VMDKReader(t *testing.T) {
+	// 4 KiB grains and 4 grains per table make 16 KiB per grain table.
+	disk := testDisk(100*1024+512, 4096, 0, 1, 3, 4, 12, 25)
+	grains := []int{0, 1, 3, 4, 12, 25}
+
+	for _, stream := range []bool{true, false} {
+		image := buildVMDK(disk, 8, 4, grains, stream)
+
+		format, err := DetectFormat(bytes.NewReader(image))
+		require.NoError(t, err)
+		assert.Equal(t, FormatVMDK, format)
+
+		r, err := NewReader(bytes.NewReader(image), int64(len(image)))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(disk)), r.Size())
+
+		got, extents := readAll(t, r)
+		assert.Equal(t, disk, got, "stream: %t", stream)
+		assert.Equal(t, len(grains), extents)
+	}
+
+	t.Run("rejects unknown compression", func(t *testing.T) {
+		image := buildVMDK(disk, 8, 4, grains, true)
+		binary.LittleEndian.PutUint16(image[len(image)-1024+77:], 2)
+		_, err := NewVMDKReader(bytes.NewReader(image), int64(len(image)))
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("checks grain markers", func(t *testing.T) {
+		image := buildVMDK(disk, 8, 4, grains, true)
+		binary.LittleEndian.PutUint64(image[2*vmdkSectorSize:], 1)
+		r, err := NewVMDKReader(bytes.NewReader(image), int64(len(image)))
+		require.NoError(t, err)
+		_, err = r.Next()
+		assert.ErrorContains(t, err, "grain marker has LBA 1, want 0")
+	})
+}
+
+func Test_DetectFormat(t *testing.T) {
+	for _, data := range [][]byte{nil, {'Q'}, make([]byte, 1024)} {
+		format, err := DetectFormat(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, FormatRaw, format)
+	}
+
+	_, err := NewReader(bytes.NewReader(make([]byte, 1024)), 1024)
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
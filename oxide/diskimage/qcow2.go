@@ -0,0 +1,204 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package diskimage
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// qcow2HeaderSize is the size of the fields of the qcow2 header that are read.
+	qcow2HeaderSize = 104
+
+	// qcow2OffsetMask selects the host offset of L1 and L2 entries.
+	qcow2OffsetMask = 0x00fffffffffffe00
+
+	// qcow2Compressed flags L2 entries of compressed clusters.
+	qcow2Compressed = 1 << 62
+
+	// qcow2Zero flags L2 entries of clusters that read as zeros.
+	qcow2Zero = 1
+
+	// qcow2IncompatibleDirty is the only incompatible feature that doesn't change how clusters
+	// are read.
+	qcow2IncompatibleDirty = 1
+)
+
+// Qcow2Reader reads the allocated clusters of a qcow2 image. Images with a backing file, an
+// external data file, encryption, extended L2 entries, or a compression type other than deflate
+// aren't supported.
+type Qcow2Reader struct {
+	r           io.ReaderAt
+	size        uint64
+	clusterBits uint32
+	l1          []uint64
+
+	// l1Index and l2Index are the position of the next L2 entry to read, and l2 holds the
+	// entries of the L2 table at l1Index.
+	l1Index int
+	l2Index int
+	l2      []uint64
+}
+
+// NewQcow2Reader returns a reader for the qcow2 image read from r.
+func NewQcow2Reader(r io.ReaderAt) (*Qcow2Reader, error) {
+	header := make([]byte, qcow2HeaderSize)
+	if err := readFull(r, header[:72], 0); err != nil {
+		return nil, fmt.Errorf("failed to read qcow2 header: %w", err)
+	}
+	if !bytes.Equal(header[:4], qcow2Magic) {
+		return nil, errors.New("not a qcow2 image")
+	}
+
+	version := binary.BigEndian.Uint32(header[4:])
+	switch version {
+	case 2:
+	case 3:
+		if err := readFull(r, header[72:], 72); err != nil {
+			return nil, fmt.Errorf("failed to read qcow2 header: %w", err)
+		}
+		if features := binary.BigEndian.Uint64(header[72:]); features&^qcow2IncompatibleDirty != 0 {
+			return nil, fmt.Errorf(
+				"%w: qcow2 incompatible features %#x",
+				ErrUnsupported,
+				features,
+			)
+		}
+	default:
+		return nil, fmt.Errorf("%w: qcow2 version %d", ErrUnsupported, version)
+	}
+
+	if binary.BigEndian.Uint64(header[8:]) != 0 {
+		return nil, fmt.Errorf("%w: qcow2 image has a backing file", ErrUnsupported)
+	}
+	if binary.BigEndian.Uint32(header[32:]) != 0 {
+		return nil, fmt.Errorf("%w: qcow2 image is encrypted", ErrUnsupported)
+	}
+
+	q := &Qcow2Reader{
+		r:           r,
+		size:        binary.BigEndian.Uint64(header[24:]),
+		clusterBits: binary.BigEndian.Uint32(header[20:]),
+	}
+	if q.clusterBits < 9 || q.clusterBits > 21 {
+		return nil, fmt.Errorf("invalid qcow2 cluster bits %d", q.clusterBits)
+	}
+
+	l1Size := binary.BigEndian.Uint32(header[36:])
+	if need := (q.size + q.l2Coverage() - 1) / q.l2Coverage(); uint64(l1Size) < need {
+		return nil, fmt.Errorf("invalid qcow2 L1 table size %d, need %d", l1Size, need)
+	}
+	l1, err := readBETable(r, binary.BigEndian.Uint64(header[40:]), int(l1Size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read qcow2 L1 table: %w", err)
+	}
+	q.l1 = l1
+
+	return q, nil
+}
+
+// Size returns the size of the virtual disk, in bytes.
+func (q *Qcow2Reader) Size() uint64 {
+	return q.size
+}
+
+// Next returns the next allocated cluster. Clusters flagged as zero are skipped.
+func (q *Qcow2Reader) Next() (Extent, error) {
+	clusterSize := uint64(1) << q.clusterBits
+	entries := int(clusterSize / 8)
+
+	for q.l1Index < len(q.l1) {
+		l2Offset := q.l1[q.l1Index] & qcow2OffsetMask
+		if l2Offset == 0 || q.l2Index >= entries {
+			q.l1Index, q.l2Index, q.l2 = q.l1Index+1, 0, nil
+			continue
+		}
+		if q.l2 == nil {
+			l2, err := readBETable(q.r, l2Offset, entries)
+			if err != nil {
+				return Extent{}, fmt.Errorf("failed to read qcow2 L2 table: %w", err)
+			}
+			q.l2 = l2
+		}
+
+		entry := q.l2[q.l2Index]
+		offset := uint64(q.l1Index)*q.l2Coverage() + uint64(q.l2Index)*clusterSize
+		q.l2Index++
+		if offset >= q.size {
+			q.l1Index = len(q.l1)
+			break
+		}
+
+		length := min(clusterSize, q.size-offset)
+		var (
+			data []byte
+			err  error
+		)
+		switch {
+		case entry&qcow2Compressed != 0:
+			data, err = q.readCompressed(entry)
+		case entry&qcow2Zero != 0 || entry&qcow2OffsetMask == 0:
+			continue
+		default:
+			data = make([]byte, clusterSize)
+			err = readFull(q.r, data, int64(entry&qcow2OffsetMask))
+		}
+		if err != nil {
+			return Extent{}, fmt.Errorf(
+				"failed to read qcow2 cluster at offset %d: %w",
+				offset,
+				err,
+			)
+		}
+		return Extent{Offset: offset, Data: data[:length]}, nil
+	}
+
+	return Extent{}, io.EOF
+}
+
+// l2Coverage returns the number of bytes of the virtual disk covered by an L2 table.
+func (q *Qcow2Reader) l2Coverage() uint64 {
+	return uint64(1) << (2*q.clusterBits - 3)
+}
+
+// readCompressed reads and inflates the compressed cluster of an L2 entry.
+func (q *Qcow2Reader) readCompressed(entry uint64) ([]byte, error) {
+	clusterSize := 1 << q.clusterBits
+	offsetBits := 62 - (q.clusterBits - 8)
+	offset := entry & (1<<offsetBits - 1)
+	sectors := (entry & (1<<62 - 1)) >> offsetBits
+
+	// The compressed data ends at the end of the sectors that follow the one holding the offset.
+	compressed := make([]byte, (sectors+1)*512-offset%512)
+	n, err := q.r.ReadAt(compressed, int64(offset))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	data := make([]byte, clusterSize)
+	if _, err := io.ReadFull(flate.NewReader(bytes.NewReader(compressed[:n])), data); err != nil {
+		return nil, fmt.Errorf("failed to inflate compressed cluster: %w", err)
+	}
+	return data, nil
+}
+
+// readBETable reads a table of n big-endian 64-bit entries at offset.
+func readBETable(r io.ReaderAt, offset uint64, n int) ([]uint64, error) {
+	buf := make([]byte, n*8)
+	if err := readFull(r, buf, int64(offset)); err != nil {
+		return nil, err
+	}
+
+	table := make([]uint64, n)
+	for i := range table {
+		table[i] = binary.BigEndian.Uint64(buf[i*8:])
+	}
+	return table, nil
+}
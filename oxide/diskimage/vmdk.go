@@ -0,0 +1,214 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package diskimage
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// vmdkSectorSize is the unit of the sizes and offsets of VMDK images.
+	vmdkSectorSize = 512
+
+	// vmdkGDAtEnd is the grain directory offset of streamOptimized images, whose grain
+	// directory is described by a footer at the end of the image.
+	vmdkGDAtEnd = 0xffffffffffffffff
+
+	// vmdkCompressed flags images whose grains are compressed.
+	vmdkCompressed = 1 << 16
+
+	// vmdkCompressionDeflate is the only compression algorithm of VMDK images.
+	vmdkCompressionDeflate = 1
+
+	// vmdkMarkerSize is the size of the marker that precedes compressed grains: the LBA of the
+	// grain and the size of its compressed data.
+	vmdkMarkerSize = 12
+)
+
+// VMDKReader reads the allocated grains of a VMDK sparse extent, as found in monolithicSparse
+// and streamOptimized images. Images split across several extent files aren't supported.
+type VMDKReader struct {
+	r          io.ReaderAt
+	capacity   uint64
+	grainSize  uint64
+	compressed bool
+	gtEntries  int
+	gd         []uint32
+
+	// gdIndex and gtIndex are the position of the next grain table entry to read, and gt holds
+	// the entries of the grain table at gdIndex.
+	gdIndex int
+	gtIndex int
+	gt      []uint32
+}
+
+// vmdkHeader holds the fields of a sparse extent header that are read.
+type vmdkHeader struct {
+	version           uint32
+	flags             uint32
+	capacity          uint64
+	grainSize         uint64
+	gtEntries         uint32
+	gdOffset          uint64
+	compressAlgorithm uint16
+}
+
+// NewVMDKReader returns a reader for the VMDK sparse extent of size bytes read from r.
+func NewVMDKReader(r io.ReaderAt, size int64) (*VMDKReader, error) {
+	header, err := readVMDKHeader(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if header.gdOffset == vmdkGDAtEnd {
+		// The footer is followed by the end-of-stream marker.
+		if header, err = readVMDKHeader(r, size-2*vmdkSectorSize); err != nil {
+			return nil, fmt.Errorf("failed to read VMDK footer: %w", err)
+		}
+	}
+
+	switch {
+	case header.version < 1 || header.version > 3:
+		return nil, fmt.Errorf("%w: VMDK version %d", ErrUnsupported, header.version)
+	case header.grainSize == 0 || header.grainSize&(header.grainSize-1) != 0:
+		return nil, fmt.Errorf("invalid VMDK grain size %d", header.grainSize)
+	case header.gtEntries == 0:
+		return nil, errors.New("invalid VMDK grain table size 0")
+	case header.flags&vmdkCompressed != 0 &&
+		header.compressAlgorithm != vmdkCompressionDeflate:
+		return nil, fmt.Errorf(
+			"%w: VMDK compression algorithm %d",
+			ErrUnsupported,
+			header.compressAlgorithm,
+		)
+	}
+
+	v := &VMDKReader{
+		r:          r,
+		capacity:   header.capacity * vmdkSectorSize,
+		grainSize:  header.grainSize * vmdkSectorSize,
+		compressed: header.flags&vmdkCompressed != 0,
+		gtEntries:  int(header.gtEntries),
+	}
+	gtCoverage := v.grainSize * uint64(v.gtEntries)
+	gdEntries := int((v.capacity + gtCoverage - 1) / gtCoverage)
+	if v.gd, err = readLETable(r, header.gdOffset*vmdkSectorSize, gdEntries); err != nil {
+		return nil, fmt.Errorf("failed to read VMDK grain directory: %w", err)
+	}
+
+	return v, nil
+}
+
+// readVMDKHeader reads the sparse extent header at offset.
+func readVMDKHeader(r io.ReaderAt, offset int64) (vmdkHeader, error) {
+	buf := make([]byte, 79)
+	if err := readFull(r, buf, offset); err != nil {
+		return vmdkHeader{}, fmt.Errorf("failed to read VMDK header: %w", err)
+	}
+	if !bytes.Equal(buf[:4], vmdkMagic) {
+		return vmdkHeader{}, errors.New("not a VMDK sparse extent")
+	}
+
+	return vmdkHeader{
+		version:           binary.LittleEndian.Uint32(buf[4:]),
+		flags:             binary.LittleEndian.Uint32(buf[8:]),
+		capacity:          binary.LittleEndian.Uint64(buf[12:]),
+		grainSize:         binary.LittleEndian.Uint64(buf[20:]),
+		gtEntries:         binary.LittleEndian.Uint32(buf[44:]),
+		gdOffset:          binary.LittleEndian.Uint64(buf[56:]),
+		compressAlgorithm: binary.LittleEndian.Uint16(buf[77:]),
+	}, nil
+}
+
+// Size returns the size of the virtual disk, in bytes.
+func (v *VMDKReader) Size() uint64 {
+	return v.capacity
+}
+
+// Next returns the next allocated grain. Grains that are flagged as zero are skipped.
+func (v *VMDKReader) Next() (Extent, error) {
+	for v.gdIndex < len(v.gd) {
+		if v.gd[v.gdIndex] == 0 || v.gtIndex >= v.gtEntries {
+			v.gdIndex, v.gtIndex, v.gt = v.gdIndex+1, 0, nil
+			continue
+		}
+		if v.gt == nil {
+			gt, err := readLETable(v.r, uint64(v.gd[v.gdIndex])*vmdkSectorSize, v.gtEntries)
+			if err != nil {
+				return Extent{}, fmt.Errorf("failed to read VMDK grain table: %w", err)
+			}
+			v.gt = gt
+		}
+
+		entry := v.gt[v.gtIndex]
+		offset := (uint64(v.gdIndex)*uint64(v.gtEntries) + uint64(v.gtIndex)) * v.grainSize
+		v.gtIndex++
+		if offset >= v.capacity {
+			v.gdIndex = len(v.gd)
+			break
+		}
+		// Entry 1 marks a grain that reads as zeros.
+		if entry <= 1 {
+			continue
+		}
+
+		data, err := v.readGrain(uint64(entry)*vmdkSectorSize, offset)
+		if err != nil {
+			return Extent{}, fmt.Errorf("failed to read VMDK grain at offset %d: %w", offset, err)
+		}
+		return Extent{Offset: offset, Data: data}, nil
+	}
+
+	return Extent{}, io.EOF
+}
+
+// readGrain reads the grain at the given host offset, which holds the virtual disk at offset.
+func (v *VMDKReader) readGrain(host, offset uint64) ([]byte, error) {
+	length := min(v.grainSize, v.capacity-offset)
+	if !v.compressed {
+		data := make([]byte, length)
+		return data, readFull(v.r, data, int64(host))
+	}
+
+	marker := make([]byte, vmdkMarkerSize)
+	if err := readFull(v.r, marker, int64(host)); err != nil {
+		return nil, err
+	}
+	if lba := binary.LittleEndian.Uint64(marker); lba*vmdkSectorSize != offset {
+		return nil, fmt.Errorf("grain marker has LBA %d, want %d", lba, offset/vmdkSectorSize)
+	}
+	compressed := make([]byte, binary.LittleEndian.Uint32(marker[8:]))
+	if err := readFull(v.r, compressed, int64(host+vmdkMarkerSize)); err != nil {
+		return nil, err
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inflate grain: %w", err)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(zr, data); err != nil {
+		return nil, fmt.Errorf("failed to inflate grain: %w", err)
+	}
+	return data, nil
+}
+
+// readLETable reads a table of n little-endian 32-bit entries at offset.
+func readLETable(r io.ReaderAt, offset uint64, n int) ([]uint32, error) {
+	buf := make([]byte, n*4)
+	if err := readFull(r, buf, int64(offset)); err != nil {
+		return nil, err
+	}
+
+	table := make([]uint32, n)
+	for i := range table {
+		table[i] = binary.LittleEndian.Uint32(buf[i*4:])
+	}
+	return table, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 // ErrorCode represents an Oxide API error matched by error_code. Use `errors.Is` to test SDK
@@ -159,3 +160,14 @@ func NewHTTPError(res *http.Response) error {
 
 	return &e
 }
+
+// peekHTTPError returns the error described by resp, like [NewHTTPError], but leaves an in-memory
+// copy of the body in place so that the response can still be returned to the caller.
+func peekHTTPError(resp *http.Response) error {
+	httpErr := NewHTTPError(resp)
+	if herr, ok := httpErr.(*HTTPError); ok {
+		resp.Body.Close()
+		resp.Body = io.NopCloser(strings.NewReader(herr.RawBody))
+	}
+	return httpErr
+}
@@ -0,0 +1,296 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ErrFirewallRulesModified is returned by PatchFirewallRules when the firewall rules of the VPC
+// were modified while they were being patched.
+var ErrFirewallRulesModified = errors.New("firewall rules were modified concurrently")
+
+// ToUpdate returns the parameters that update the rule to its current state, i.e. the rule
+// without its ID, VPC and timestamps. The rule is copied deeply, including the variants of its
+// targets and filters, so that the returned parameters can be modified without modifying the
+// rule.
+func (r VpcFirewallRule) ToUpdate() VpcFirewallRuleUpdate {
+	update := VpcFirewallRuleUpdate{
+		Action:      r.Action,
+		Description: r.Description,
+		Direction:   r.Direction,
+		Filters: VpcFirewallRuleFilter{
+			Hosts:     slices.Clone(r.Filters.Hosts),
+			Ports:     slices.Clone(r.Filters.Ports),
+			Protocols: slices.Clone(r.Filters.Protocols),
+		},
+		Name:     r.Name,
+		Priority: clonePointer(r.Priority),
+		Status:   r.Status,
+		Targets:  slices.Clone(r.Targets),
+	}
+	for i, t := range update.Targets {
+		update.Targets[i] = t.clone()
+	}
+	for i, h := range update.Filters.Hosts {
+		update.Filters.Hosts[i] = h.clone()
+	}
+	for i, p := range update.Filters.Protocols {
+		update.Filters.Protocols[i] = p.clone()
+	}
+	return update
+}
+
+// clone returns a copy of the target that doesn't share its variant or its network.
+func (t VpcFirewallRuleTarget) clone() VpcFirewallRuleTarget {
+	switch v := t.Value.(type) {
+	case *VpcFirewallRuleTargetVpc:
+		t.Value = clonePointer(v)
+	case *VpcFirewallRuleTargetSubnet:
+		t.Value = clonePointer(v)
+	case *VpcFirewallRuleTargetInstance:
+		t.Value = clonePointer(v)
+	case *VpcFirewallRuleTargetIp:
+		t.Value = clonePointer(v)
+	case *VpcFirewallRuleTargetIpNet:
+		t.Value = &VpcFirewallRuleTargetIpNet{Value: v.Value.clone()}
+	case VpcFirewallRuleTargetIpNet:
+		t.Value = VpcFirewallRuleTargetIpNet{Value: v.Value.clone()}
+	}
+	return t
+}
+
+// clone returns a copy of the host filter that doesn't share its variant or its network.
+func (h VpcFirewallRuleHostFilter) clone() VpcFirewallRuleHostFilter {
+	switch v := h.Value.(type) {
+	case *VpcFirewallRuleHostFilterVpc:
+		h.Value = clonePointer(v)
+	case *VpcFirewallRuleHostFilterSubnet:
+		h.Value = clonePointer(v)
+	case *VpcFirewallRuleHostFilterInstance:
+		h.Value = clonePointer(v)
+	case *VpcFirewallRuleHostFilterIp:
+		h.Value = clonePointer(v)
+	case *VpcFirewallRuleHostFilterIpNet:
+		h.Value = &VpcFirewallRuleHostFilterIpNet{Value: v.Value.clone()}
+	case VpcFirewallRuleHostFilterIpNet:
+		h.Value = VpcFirewallRuleHostFilterIpNet{Value: v.Value.clone()}
+	}
+	return h
+}
+
+// clone returns a copy of the protocol filter that doesn't share its variant or its ICMP filter.
+func (p VpcFirewallRuleProtocol) clone() VpcFirewallRuleProtocol {
+	switch v := p.Value.(type) {
+	case *VpcFirewallRuleProtocolTcp:
+		p.Value = clonePointer(v)
+	case *VpcFirewallRuleProtocolUdp:
+		p.Value = clonePointer(v)
+	case *VpcFirewallRuleProtocolIcmp:
+		p.Value = &VpcFirewallRuleProtocolIcmp{Value: cloneIcmpFilter(v.Value)}
+	case VpcFirewallRuleProtocolIcmp:
+		p.Value = VpcFirewallRuleProtocolIcmp{Value: cloneIcmpFilter(v.Value)}
+	case *VpcFirewallRuleProtocolIcmp6:
+		p.Value = &VpcFirewallRuleProtocolIcmp6{Value: cloneIcmpFilter(v.Value)}
+	case VpcFirewallRuleProtocolIcmp6:
+		p.Value = VpcFirewallRuleProtocolIcmp6{Value: cloneIcmpFilter(v.Value)}
+	}
+	return p
+}
+
+// clone returns a copy of the network that doesn't share its variant.
+func (n IpNet) clone() IpNet {
+	switch v := n.Value.(type) {
+	case *Ipv4Net:
+		n.Value = clonePointer(v)
+	case *Ipv6Net:
+		n.Value = clonePointer(v)
+	}
+	return n
+}
+
+func cloneIcmpFilter(f *VpcFirewallIcmpFilter) *VpcFirewallIcmpFilter {
+	if f == nil {
+		return nil
+	}
+	return &VpcFirewallIcmpFilter{Code: f.Code, IcmpType: clonePointer(f.IcmpType)}
+}
+
+// clonePointer returns a pointer to a copy of the value p points to, or nil if p is nil.
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// PatchFirewallRules updates some of the firewall rules of a VPC. VpcFirewallRulesUpdate replaces
+// all the rules of a VPC at once, so PatchFirewallRules reads the current rules, passes them to
+// patch, and writes the rules it returns. Nothing is written if patch returns an error, or if it
+// returns the rules unchanged.
+//
+// Before writing, the rules are read again and compared with the rules passed to patch, by ID and
+// modification time. If another client modified them in the meantime, the returned error wraps
+// ErrFirewallRulesModified, and patching can be retried with the new rules. The API has no
+// conditional updates, so this narrows the window for lost updates to the time between the
+// second read and the write, but doesn't close it.
+func (c *Client) PatchFirewallRules(
+	ctx context.Context,
+	params VpcFirewallRulesViewParams,
+	patch func(rules []VpcFirewallRuleUpdate) ([]VpcFirewallRuleUpdate, error),
+) (*VpcFirewallRules, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	current, err := c.VpcFirewallRulesView(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error viewing firewall rules of VPC %s: %w", params.Vpc, err)
+	}
+	// The rules passed to patch may be modified in place, so they are compared with a copy.
+	unchanged := firewallRuleUpdates(current.Rules)
+	rules, err := patch(firewallRuleUpdates(current.Rules))
+	if err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(rules, unchanged) {
+		return current, nil
+	}
+
+	latest, err := c.VpcFirewallRulesView(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error viewing firewall rules of VPC %s: %w", params.Vpc, err)
+	}
+	if !sameFirewallRules(current.Rules, latest.Rules) {
+		return nil, fmt.Errorf(
+			"error updating firewall rules of VPC %s: %w",
+			params.Vpc,
+			ErrFirewallRulesModified,
+		)
+	}
+
+	if rules == nil {
+		// A nil slice would leave out the rules of the request body.
+		rules = []VpcFirewallRuleUpdate{}
+	}
+	updated, err := c.VpcFirewallRulesUpdate(ctx, VpcFirewallRulesUpdateParams{
+		Project: params.Project,
+		Vpc:     params.Vpc,
+		Body:    &VpcFirewallRuleUpdateParams{Rules: rules},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating firewall rules of VPC %s: %w", params.Vpc, err)
+	}
+	return updated, nil
+}
+
+// AddFirewallRule adds a firewall rule to a VPC, keeping its other rules. The returned error
+// wraps ErrObjectAlreadyExists if the VPC already has a rule with the same name.
+func (c *Client) AddFirewallRule(
+	ctx context.Context,
+	params VpcFirewallRulesViewParams,
+	rule VpcFirewallRuleUpdate,
+) (*VpcFirewallRules, error) {
+	return c.PatchFirewallRules(
+		ctx,
+		params,
+		func(rules []VpcFirewallRuleUpdate) ([]VpcFirewallRuleUpdate, error) {
+			if firewallRuleIndex(rules, rule.Name) >= 0 {
+				return nil, fmt.Errorf("firewall rule %s: %w", rule.Name, ErrObjectAlreadyExists)
+			}
+			return append(rules, rule), nil
+		},
+	)
+}
+
+// RemoveFirewallRule removes a firewall rule from a VPC, keeping its other rules. The returned
+// error wraps ErrObjectNotFound if the VPC has no rule with that name.
+func (c *Client) RemoveFirewallRule(
+	ctx context.Context,
+	params VpcFirewallRulesViewParams,
+	name Name,
+) (*VpcFirewallRules, error) {
+	return c.PatchFirewallRules(
+		ctx,
+		params,
+		func(rules []VpcFirewallRuleUpdate) ([]VpcFirewallRuleUpdate, error) {
+			i := firewallRuleIndex(rules, name)
+			if i < 0 {
+				return nil, fmt.Errorf("firewall rule %s: %w", name, ErrObjectNotFound)
+			}
+			return slices.Delete(rules, i, i+1), nil
+		},
+	)
+}
+
+// ReplaceFirewallRule replaces the firewall rule of a VPC named name with rule, which can have
+// another name, keeping the other rules. The returned error wraps ErrObjectNotFound if the VPC
+// has no rule named name, and ErrObjectAlreadyExists if rule is renamed to the name of another
+// rule.
+func (c *Client) ReplaceFirewallRule(
+	ctx context.Context,
+	params VpcFirewallRulesViewParams,
+	name Name,
+	rule VpcFirewallRuleUpdate,
+) (*VpcFirewallRules, error) {
+	return c.PatchFirewallRules(
+		ctx,
+		params,
+		func(rules []VpcFirewallRuleUpdate) ([]VpcFirewallRuleUpdate, error) {
+			i := firewallRuleIndex(rules, name)
+			if i < 0 {
+				return nil, fmt.Errorf("firewall rule %s: %w", name, ErrObjectNotFound)
+			}
+			if rule.Name != name && firewallRuleIndex(rules, rule.Name) >= 0 {
+				return nil, fmt.Errorf("firewall rule %s: %w", rule.Name, ErrObjectAlreadyExists)
+			}
+			rules[i] = rule
+			return rules, nil
+		},
+	)
+}
+
+// firewallRuleUpdates returns the parameters that update rules to their current state.
+func firewallRuleUpdates(rules []VpcFirewallRule) []VpcFirewallRuleUpdate {
+	updates := make([]VpcFirewallRuleUpdate, len(rules))
+	for i, rule := range rules {
+		updates[i] = rule.ToUpdate()
+	}
+	return updates
+}
+
+// firewallRuleIndex returns the index of the rule with the given name, or -1.
+func firewallRuleIndex(rules []VpcFirewallRuleUpdate, name Name) int {
+	return slices.IndexFunc(rules, func(rule VpcFirewallRuleUpdate) bool {
+		return rule.Name == name
+	})
+}
+
+// sameFirewallRules reports whether two reads of the rules of a VPC returned the same rules, with
+// the same modification times.
+func sameFirewallRules(a, b []VpcFirewallRule) bool {
+	byID := make(map[string]*VpcFirewallRule, len(a))
+	for i := range a {
+		byID[a[i].Id] = &a[i]
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for _, rule := range b {
+		prev, ok := byID[rule.Id]
+		if !ok || (prev.TimeModified == nil) != (rule.TimeModified == nil) {
+			return false
+		}
+		if rule.TimeModified != nil && !rule.TimeModified.Equal(*prev.TimeModified) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,281 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package firewall
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// Packet is the first packet of a connection between a network interface of an instance of the
+// VPC and a remote address.
+type Packet struct {
+	// Direction is the direction of the packet from the point of view of the instance: inbound
+	// packets are sent to the instance, and outbound packets are sent by the instance.
+	Direction oxide.VpcFirewallRuleDirection
+
+	// Protocol is the protocol of the packet.
+	Protocol oxide.VpcFirewallRuleProtocolType
+
+	// Port is the destination port of TCP and UDP packets.
+	Port int
+
+	// IcmpType and IcmpCode are the type and code of ICMP and ICMPv6 packets.
+	IcmpType int
+	IcmpCode int
+
+	// Instance is the name of the instance.
+	Instance oxide.Name
+
+	// Interface is the name of the network interface of the instance. Defaults to its primary
+	// interface in the VPC.
+	Interface oxide.Name
+
+	// Remote is the address at the other end of the connection: the source of inbound packets,
+	// and the destination of outbound packets.
+	Remote netip.Addr
+}
+
+// String describes the packet, e.g. "TCP 443 from 10.1.2.3 to instance web-1".
+func (p Packet) String() string {
+	var what string
+	switch p.Protocol {
+	case oxide.VpcFirewallRuleProtocolTypeTcp, oxide.VpcFirewallRuleProtocolTypeUdp:
+		what = fmt.Sprintf("%s %d", strings.ToUpper(string(p.Protocol)), p.Port)
+	case oxide.VpcFirewallRuleProtocolTypeIcmp6:
+		what = fmt.Sprintf("ICMPv6 type %d code %d", p.IcmpType, p.IcmpCode)
+	default:
+		what = fmt.Sprintf(
+			"%s type %d code %d",
+			strings.ToUpper(string(p.Protocol)),
+			p.IcmpType,
+			p.IcmpCode,
+		)
+	}
+
+	instance := fmt.Sprintf("instance %s", p.Instance)
+	if p.Interface != "" {
+		instance += fmt.Sprintf(" (%s)", p.Interface)
+	}
+	if p.Direction == oxide.VpcFirewallRuleDirectionOutbound {
+		return fmt.Sprintf("%s from %s to %s", what, instance, p.Remote)
+	}
+	return fmt.Sprintf("%s from %s to %s", what, p.Remote, instance)
+}
+
+// validate checks that the direction and the protocol of the packet are known, that its port or
+// ICMP type and code are in range, and that its remote address is set. A packet that no rule can
+// match would otherwise be reported as decided by default.
+func (p Packet) validate() error {
+	if !slices.Contains(oxide.VpcFirewallRuleDirectionCollection, p.Direction) {
+		return fmt.Errorf("invalid direction %q", p.Direction)
+	}
+	switch p.Protocol {
+	case oxide.VpcFirewallRuleProtocolTypeTcp, oxide.VpcFirewallRuleProtocolTypeUdp:
+		if p.Port < 0 || p.Port > 65535 {
+			return fmt.Errorf("invalid port %d: must be between 0 and 65535", p.Port)
+		}
+	case oxide.VpcFirewallRuleProtocolTypeIcmp, oxide.VpcFirewallRuleProtocolTypeIcmp6:
+		if p.IcmpType < 0 || p.IcmpType > 255 {
+			return fmt.Errorf("invalid ICMP type %d: must be between 0 and 255", p.IcmpType)
+		}
+		if p.IcmpCode < 0 || p.IcmpCode > 255 {
+			return fmt.Errorf("invalid ICMP code %d: must be between 0 and 255", p.IcmpCode)
+		}
+	default:
+		return fmt.Errorf("invalid protocol %q", p.Protocol)
+	}
+	if !p.Remote.IsValid() {
+		return errors.New("remote address must be set")
+	}
+	return nil
+}
+
+// Decision is the result of the evaluation of a packet.
+type Decision struct {
+	// Packet is the packet that was evaluated.
+	Packet Packet
+
+	// Allowed reports whether the packet is allowed.
+	Allowed bool
+
+	// Rule is the rule that decided, or nil if no rule matches the packet and the default
+	// applies.
+	Rule *oxide.VpcFirewallRule
+
+	// Matches are the enabled rules that match the packet, in the order they apply. The first
+	// one is Rule.
+	Matches []oxide.VpcFirewallRule
+}
+
+// String describes the decision, e.g. "TCP 443 from 10.1.2.3 to instance web-1: allowed by rule
+// allow-https (priority 100)".
+func (d Decision) String() string {
+	verdict := "denied"
+	if d.Allowed {
+		verdict = "allowed"
+	}
+	if d.Rule == nil {
+		return fmt.Sprintf("%s: %s by default, no rule matches", d.Packet, verdict)
+	}
+	return fmt.Sprintf(
+		"%s: %s by rule %s (priority %s)",
+		d.Packet,
+		verdict,
+		d.Rule.Name,
+		oxide.PointerIntToStr(d.Rule.Priority),
+	)
+}
+
+// Evaluator evaluates packets against the firewall rules of a VPC.
+type Evaluator struct {
+	network *network
+	// source holds the rules of the inventory, which rules index.
+	source []oxide.VpcFirewallRule
+	// rules holds the enabled rules, in the order they apply.
+	rules []*rule
+}
+
+// NewEvaluator returns an evaluator of the firewall rules of an inventory. It fails if a rule, a
+// subnet or a network interface has an invalid address, port range or ICMP code range.
+func NewEvaluator(inv *Inventory) (*Evaluator, error) {
+	n, err := newNetwork(inv)
+	if err != nil {
+		return nil, err
+	}
+	updates := make([]oxide.VpcFirewallRuleUpdate, len(inv.Rules))
+	for i, r := range inv.Rules {
+		updates[i] = r.ToUpdate()
+	}
+	rules, err := parseRules(updates)
+	if err != nil {
+		return nil, err
+	}
+
+	rules = slices.DeleteFunc(rules, func(r *rule) bool {
+		return r.Status != oxide.VpcFirewallRuleStatusEnabled
+	})
+	sortRules(rules)
+	return &Evaluator{network: n, source: slices.Clone(inv.Rules), rules: rules}, nil
+}
+
+// Evaluate decides whether a packet is allowed. It fails if the packet is invalid, e.g. because
+// of an unknown direction or protocol, or if the instance or its network interface aren't in the
+// VPC.
+func (e *Evaluator) Evaluate(p Packet) (*Decision, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	iface, err := e.nic(p.Instance, p.Interface)
+	if err != nil {
+		return nil, err
+	}
+	remote := p.Remote.Unmap()
+
+	d := &Decision{
+		Packet:  p,
+		Allowed: p.Direction == oxide.VpcFirewallRuleDirectionOutbound,
+	}
+	var first *rule
+	for _, r := range e.rules {
+		if !e.matches(r, p, iface, remote) {
+			continue
+		}
+		if first == nil {
+			first = r
+		}
+		d.Matches = append(d.Matches, e.source[r.index])
+	}
+	if first != nil {
+		d.Rule = &d.Matches[0]
+		d.Allowed = first.Action == oxide.VpcFirewallRuleActionAllow
+	}
+	return d, nil
+}
+
+// nic returns the network interface of an instance with the given name, or its primary interface.
+func (e *Evaluator) nic(instance, name oxide.Name) (nic, error) {
+	var found []nic
+	for _, iface := range e.network.nics {
+		if iface.instance == instance {
+			found = append(found, iface)
+		}
+	}
+	if len(found) == 0 {
+		return nic{}, fmt.Errorf(
+			"instance %s has no network interface in VPC %s",
+			instance,
+			e.network.vpc,
+		)
+	}
+	i := slices.IndexFunc(found, func(iface nic) bool {
+		if name != "" {
+			return iface.name == name
+		}
+		return iface.primary
+	})
+	switch {
+	case i >= 0:
+		return found[i], nil
+	case name != "":
+		return nic{}, fmt.Errorf(
+			"instance %s has no network interface %s in VPC %s",
+			instance,
+			name,
+			e.network.vpc,
+		)
+	}
+	// The primary interface of the instance is in another VPC.
+	return found[0], nil
+}
+
+// matches reports whether a rule applies to a packet.
+func (e *Evaluator) matches(r *rule, p Packet, iface nic, remote netip.Addr) bool {
+	if r.Direction != p.Direction {
+		return false
+	}
+	if !slices.ContainsFunc(r.targets, func(s selector) bool {
+		return e.network.matchesTarget(s, iface)
+	}) {
+		return false
+	}
+	if len(r.hosts) > 0 && !slices.ContainsFunc(r.hosts, func(s selector) bool {
+		return e.network.matchesHost(s, remote)
+	}) {
+		return false
+	}
+	if len(r.protocols) > 0 && !slices.ContainsFunc(r.protocols, func(pr protocol) bool {
+		return pr.matches(p)
+	}) {
+		return false
+	}
+	if len(r.ports) > 0 {
+		// Only TCP and UDP packets have ports.
+		if p.Protocol != oxide.VpcFirewallRuleProtocolTypeTcp &&
+			p.Protocol != oxide.VpcFirewallRuleProtocolTypeUdp {
+			return false
+		}
+		if !slices.ContainsFunc(r.ports, func(pr portRange) bool { return pr.contains(p.Port) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether a protocol filter matches a packet.
+func (pr protocol) matches(p Packet) bool {
+	if pr.kind != p.Protocol {
+		return false
+	}
+	if pr.icmpType != nil && *pr.icmpType != p.IcmpType {
+		return false
+	}
+	return pr.icmpCodes == nil || pr.icmpCodes.contains(p.IcmpCode)
+}
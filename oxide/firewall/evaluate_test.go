@@ -0,0 +1,268 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package firewall
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode[T any](t *testing.T, data string) T {
+	t.Helper()
+
+	var v T
+	require.NoError(t, json.Unmarshal([]byte(data), &v))
+	return v
+}
+
+// testInventory returns a VPC with a frontend and a backend subnet, the instance web-1 with an
+// interface in each, and the instance db-1 in the backend subnet.
+func testInventory(t *testing.T) *Inventory {
+	t.Helper()
+
+	return &Inventory{
+		Vpc: oxide.Vpc{Id: "id-web", Name: "web"},
+		Rules: decode[[]oxide.VpcFirewallRule](t, `[
+			{
+				"name": "allow-https", "action": "allow", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "subnet", "value": "frontend"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["443"]}
+			},
+			{
+				"name": "deny-bad-net", "action": "deny", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"hosts": [{"type": "ip_net", "value": "10.1.2.0/24"}]}
+			},
+			{
+				"name": "allow-db", "action": "allow", "direction": "inbound",
+				"priority": 200, "status": "enabled",
+				"targets": [{"type": "instance", "value": "db-1"}],
+				"filters": {
+					"hosts": [{"type": "subnet", "value": "frontend"}],
+					"protocols": [{"type": "tcp"}],
+					"ports": ["5432"]
+				}
+			},
+			{
+				"name": "allow-ping", "action": "allow", "direction": "inbound",
+				"priority": 300, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"protocols": [{"type": "icmp", "value": {"icmp_type": 8, "code": "0"}}]}
+			},
+			{
+				"name": "allow-admin", "action": "allow", "direction": "inbound",
+				"priority": 400, "status": "enabled",
+				"targets": [{"type": "ip_net", "value": "10.0.2.0/24"}],
+				"filters": {
+					"hosts": [{"type": "instance", "value": "web-1"}],
+					"protocols": [{"type": "tcp"}],
+					"ports": ["2000-2999"]
+				}
+			},
+			{
+				"name": "deny-smtp", "action": "deny", "direction": "outbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["25"]}
+			},
+			{
+				"name": "allow-all", "action": "allow", "direction": "inbound",
+				"priority": 0, "status": "disabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {}
+			}
+		]`),
+		Subnets: decode[[]oxide.VpcSubnet](t, `[
+			{
+				"id": "id-frontend", "name": "frontend", "vpc_id": "id-web",
+				"ipv4_block": "10.0.1.0/24", "ipv6_block": "fd00:0:0:1::/64"
+			},
+			{
+				"id": "id-backend", "name": "backend", "vpc_id": "id-web",
+				"ipv4_block": "10.0.2.0/24", "ipv6_block": "fd00:0:0:2::/64"
+			}
+		]`),
+		Instances: []oxide.Instance{
+			{Id: "id-web-1", Name: "web-1"},
+			{Id: "id-db-1", Name: "db-1"},
+			{Id: "id-other", Name: "other"},
+		},
+		NetworkInterfaces: decode[[]oxide.InstanceNetworkInterface](t, `[
+			{
+				"name": "net0", "instance_id": "id-web-1", "primary": true,
+				"subnet_id": "id-frontend", "vpc_id": "id-web",
+				"ip_stack": {"type": "dual_stack", "value": {
+					"v4": {"ip": "10.0.1.5", "transit_ips": []},
+					"v6": {"ip": "fd00:0:0:1::5", "transit_ips": []}
+				}}
+			},
+			{
+				"name": "net1", "instance_id": "id-web-1", "primary": false,
+				"subnet_id": "id-backend", "vpc_id": "id-web",
+				"ip_stack": {"type": "v4", "value": {"ip": "10.0.2.5", "transit_ips": []}}
+			},
+			{
+				"name": "net0", "instance_id": "id-db-1", "primary": true,
+				"subnet_id": "id-backend", "vpc_id": "id-web",
+				"ip_stack": {"type": "v4", "value": {"ip": "10.0.2.10", "transit_ips": []}}
+			},
+			{
+				"name": "net0", "instance_id": "id-other", "primary": true,
+				"subnet_id": "id-elsewhere", "vpc_id": "id-elsewhere",
+				"ip_stack": {"type": "v4", "value": {"ip": "10.0.1.5", "transit_ips": []}}
+			}
+		]`),
+	}
+}
+
+func Test_Evaluator(t *testing.T) {
+	evaluator, err := NewEvaluator(testInventory(t))
+	require.NoError(t, err)
+
+	inbound := func(proto oxide.VpcFirewallRuleProtocolType, port int, from string, to oxide.Name) Packet {
+		return Packet{
+			Direction: oxide.VpcFirewallRuleDirectionInbound,
+			Protocol:  proto,
+			Port:      port,
+			Remote:    netip.MustParseAddr(from),
+			Instance:  to,
+		}
+	}
+	tcp, udp := oxide.VpcFirewallRuleProtocolTypeTcp, oxide.VpcFirewallRuleProtocolTypeUdp
+	toBackend := inbound(tcp, 443, "203.0.113.7", "web-1")
+	toBackend.Interface = "net1"
+	ping := inbound(oxide.VpcFirewallRuleProtocolTypeIcmp, 0, "10.0.2.10", "web-1")
+	ping.IcmpType = 8
+	unreachable := ping
+	unreachable.IcmpType = 3
+	smtp := Packet{
+		Direction: oxide.VpcFirewallRuleDirectionOutbound,
+		Protocol:  tcp,
+		Port:      25,
+		Remote:    netip.MustParseAddr("198.51.100.1"),
+		Instance:  "web-1",
+	}
+	https := smtp
+	https.Port = 443
+
+	tests := []struct {
+		name    string
+		packet  Packet
+		allowed bool
+		rule    oxide.Name
+	}{
+		{"allows by subnet target", inbound(tcp, 443, "203.0.113.7", "web-1"), true, "allow-https"},
+		{"allows IPv6", inbound(tcp, 443, "2001:db8::1", "web-1"), true, "allow-https"},
+		{"prefers deny on ties", inbound(tcp, 443, "10.1.2.3", "web-1"), false, "deny-bad-net"},
+		{"denies inbound by default", inbound(tcp, 80, "203.0.113.7", "web-1"), false, ""},
+		{"filters protocols", inbound(udp, 443, "203.0.113.7", "web-1"), false, ""},
+		{"filters interfaces", toBackend, false, ""},
+		{"filters subnet hosts", inbound(tcp, 5432, "10.0.1.5", "db-1"), true, "allow-db"},
+		{"filters subnet hosts out", inbound(tcp, 5432, "10.0.2.5", "db-1"), false, ""},
+		{"filters instance hosts", inbound(tcp, 2222, "10.0.2.5", "db-1"), true, "allow-admin"},
+		{"filters port ranges", inbound(tcp, 3000, "10.0.2.5", "db-1"), false, ""},
+		{"filters ICMP types", ping, true, "allow-ping"},
+		{"filters ICMP types out", unreachable, false, ""},
+		{"denies outbound by rule", smtp, false, "deny-smtp"},
+		{"allows outbound by default", https, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := evaluator.Evaluate(tt.packet)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, d.Allowed, d.String())
+			if tt.rule == "" {
+				assert.Nil(t, d.Rule, d.String())
+			} else if assert.NotNil(t, d.Rule, d.String()) {
+				assert.Equal(t, tt.rule, d.Rule.Name)
+			}
+		})
+	}
+
+	t.Run("describes decisions", func(t *testing.T) {
+		d, err := evaluator.Evaluate(inbound(tcp, 443, "10.1.2.3", "web-1"))
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			"TCP 443 from 10.1.2.3 to instance web-1: denied by rule deny-bad-net (priority 100)",
+			d.String(),
+		)
+		var matches []oxide.Name
+		for _, r := range d.Matches {
+			matches = append(matches, r.Name)
+		}
+		assert.Equal(t, []oxide.Name{"deny-bad-net", "allow-https"}, matches)
+
+		d, err = evaluator.Evaluate(https)
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			"TCP 443 from instance web-1 to 198.51.100.1: allowed by default, no rule matches",
+			d.String(),
+		)
+	})
+
+	t.Run("rejects unknown instances", func(t *testing.T) {
+		_, err := evaluator.Evaluate(inbound(tcp, 443, "203.0.113.7", "db-2"))
+		assert.EqualError(t, err, "instance db-2 has no network interface in VPC web")
+		_, err = evaluator.Evaluate(inbound(tcp, 443, "203.0.113.7", "other"))
+		assert.EqualError(t, err, "instance other has no network interface in VPC web")
+
+		packet := inbound(tcp, 443, "203.0.113.7", "db-1")
+		packet.Interface = "net1"
+		_, err = evaluator.Evaluate(packet)
+		assert.EqualError(t, err, "instance db-1 has no network interface net1 in VPC web")
+	})
+
+	t.Run("rejects invalid packets", func(t *testing.T) {
+		for _, tt := range []struct {
+			name   string
+			modify func(p *Packet)
+			err    string
+		}{
+			{"direction", func(p *Packet) { p.Direction = "" }, `invalid direction ""`},
+			{"protocol", func(p *Packet) { p.Protocol = "TCP" }, `invalid protocol "TCP"`},
+			{
+				"port",
+				func(p *Packet) { p.Port = 65536 },
+				"invalid port 65536: must be between 0 and 65535",
+			},
+			{
+				"ICMP type",
+				func(p *Packet) { p.Protocol, p.IcmpType = "icmp", -1 },
+				"invalid ICMP type -1: must be between 0 and 255",
+			},
+			{"remote", func(p *Packet) { p.Remote = netip.Addr{} }, "remote address must be set"},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				packet := inbound(tcp, 443, "203.0.113.7", "web-1")
+				tt.modify(&packet)
+				_, err := evaluator.Evaluate(packet)
+				assert.EqualError(t, err, tt.err)
+			})
+		}
+	})
+
+	t.Run("rejects invalid rules", func(t *testing.T) {
+		inv := testInventory(t)
+		inv.Rules[0].Filters.Ports = []oxide.L4PortRange{"443-80"}
+		inv.Rules[1].Priority = nil
+
+		_, err := NewEvaluator(inv)
+		assert.EqualError(
+			t,
+			err,
+			"firewall rule allow-https: invalid port range: invalid range \"443-80\"\n"+
+				"firewall rule deny-bad-net: priority must be set",
+		)
+	})
+}
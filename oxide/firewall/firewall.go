@@ -0,0 +1,294 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package firewall evaluates the firewall rules of a VPC offline: given the rules returned by
+// VpcFirewallRulesView and the subnets, instances and network interfaces of the VPC, it answers
+// questions such as "would TCP 443 from 10.1.2.3 to instance web-1 be allowed?" without sending
+// traffic, and returns the rule that decides.
+//
+//	inventory, err := firewall.FetchInventory(ctx, client, "prod", "default")
+//	if err != nil {
+//		return err
+//	}
+//	evaluator, err := firewall.NewEvaluator(inventory)
+//	if err != nil {
+//		return err
+//	}
+//	decision, err := evaluator.Evaluate(firewall.Packet{
+//		Direction: oxide.VpcFirewallRuleDirectionInbound,
+//		Protocol:  oxide.VpcFirewallRuleProtocolTypeTcp,
+//		Port:      443,
+//		Remote:    netip.MustParseAddr("10.1.2.3"),
+//		Instance:  "web-1",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(decision)
+//
+// The evaluation follows the semantics of the VPC firewall: only enabled rules apply, rules with
+// a lower priority number take precedence, deny rules take precedence over allow rules of the
+// same priority, and packets that no rule matches are denied when inbound and allowed when
+// outbound. Since the firewall is stateful, the evaluation is about the first packet of a
+// connection: the replies are always allowed.
+//
+// Lint checks rules before they are passed to VpcFirewallRulesUpdate, e.g. in CI, and reports
+// the rules that are invalid, reference subnets or instances that don't exist, allow all inbound
+// traffic from anywhere, duplicate another rule, or are shadowed by rules that take precedence:
+//
+//	findings, err := firewall.Lint(inventory, rules)
+//	if err != nil {
+//		return err
+//	}
+//	for _, f := range findings {
+//		fmt.Println(f)
+//	}
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// Inventory is the firewall rules of a VPC, along with the resources of the VPC they reference.
+type Inventory struct {
+	// Vpc is the VPC.
+	Vpc oxide.Vpc
+
+	// Rules are the firewall rules of the VPC.
+	Rules []oxide.VpcFirewallRule
+
+	// Subnets are the subnets of the VPC.
+	Subnets []oxide.VpcSubnet
+
+	// Instances are the instances of the project of the VPC.
+	Instances []oxide.Instance
+
+	// NetworkInterfaces are the network interfaces of the instances. The interfaces that belong
+	// to other VPCs are ignored.
+	NetworkInterfaces []oxide.InstanceNetworkInterface
+}
+
+// FetchInventory returns the firewall rules of a VPC, along with its subnets, and the instances of
+// the project and their network interfaces.
+func FetchInventory(
+	ctx context.Context,
+	client *oxide.Client,
+	project, vpc oxide.NameOrId,
+) (*Inventory, error) {
+	v, err := client.VpcView(ctx, oxide.VpcViewParams{Project: project, Vpc: vpc})
+	if err != nil {
+		return nil, fmt.Errorf("error viewing VPC %s: %w", vpc, err)
+	}
+	inv := &Inventory{Vpc: *v}
+
+	rules, err := client.VpcFirewallRulesView(ctx, oxide.VpcFirewallRulesViewParams{
+		Project: project,
+		Vpc:     vpc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error viewing firewall rules of VPC %s: %w", vpc, err)
+	}
+	inv.Rules = rules.Rules
+
+	inv.Subnets, err = client.VpcSubnetListAllPages(ctx, oxide.VpcSubnetListParams{
+		Project: project,
+		Vpc:     vpc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing subnets of VPC %s: %w", vpc, err)
+	}
+
+	inv.Instances, err = client.InstanceListAllPages(ctx, oxide.InstanceListParams{
+		Project: project,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing instances: %w", err)
+	}
+	for _, instance := range inv.Instances {
+		nics, err := client.InstanceNetworkInterfaceListAllPages(
+			ctx,
+			oxide.InstanceNetworkInterfaceListParams{
+				Project:  project,
+				Instance: oxide.NameOrId(instance.Name),
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error listing network interfaces of instance %s: %w",
+				instance.Name,
+				err,
+			)
+		}
+		for _, nic := range nics {
+			if nic.VpcId == v.Id {
+				inv.NetworkInterfaces = append(inv.NetworkInterfaces, nic)
+			}
+		}
+	}
+	return inv, nil
+}
+
+// network is the addressing of the resources of a VPC.
+type network struct {
+	vpc oxide.Name
+	// subnets holds the IPv4 and IPv6 blocks of the subnets.
+	subnets map[oxide.Name][]netip.Prefix
+	// nics holds the network interfaces of the VPC.
+	nics []nic
+}
+
+// nic is a network interface of an instance.
+type nic struct {
+	name     oxide.Name
+	instance oxide.Name
+	subnet   oxide.Name
+	primary  bool
+	addrs    []netip.Addr
+}
+
+// newNetwork indexes the resources of an inventory by name.
+func newNetwork(inv *Inventory) (*network, error) {
+	n := &network{vpc: inv.Vpc.Name, subnets: make(map[oxide.Name][]netip.Prefix)}
+
+	subnetNames := make(map[string]oxide.Name)
+	for _, subnet := range inv.Subnets {
+		subnetNames[subnet.Id] = subnet.Name
+		var blocks []netip.Prefix
+		for _, block := range []string{string(subnet.Ipv4Block), string(subnet.Ipv6Block)} {
+			if block == "" {
+				continue
+			}
+			prefix, err := netip.ParsePrefix(block)
+			if err != nil {
+				return nil, fmt.Errorf("invalid block of subnet %s: %w", subnet.Name, err)
+			}
+			blocks = append(blocks, prefix.Masked())
+		}
+		n.subnets[subnet.Name] = blocks
+	}
+
+	instanceNames := make(map[string]oxide.Name)
+	for _, instance := range inv.Instances {
+		instanceNames[instance.Id] = instance.Name
+	}
+	for _, iface := range inv.NetworkInterfaces {
+		if iface.VpcId != inv.Vpc.Id {
+			continue
+		}
+		instance, ok := instanceNames[iface.InstanceId]
+		if !ok {
+			return nil, fmt.Errorf(
+				"network interface %s belongs to an unknown instance %s",
+				iface.Name,
+				iface.InstanceId,
+			)
+		}
+		addrs, err := stackAddrs(iface.IpStack)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid address of network interface %s of instance %s: %w",
+				iface.Name,
+				instance,
+				err,
+			)
+		}
+		n.nics = append(n.nics, nic{
+			name:     iface.Name,
+			instance: instance,
+			subnet:   subnetNames[iface.SubnetId],
+			primary:  iface.Primary != nil && *iface.Primary,
+			addrs:    addrs,
+		})
+	}
+	return n, nil
+}
+
+// stackAddrs returns the addresses of an IP stack.
+func stackAddrs(stack oxide.PrivateIpStack) ([]netip.Addr, error) {
+	var ips []string
+	switch v := stack.Value.(type) {
+	case *oxide.PrivateIpStackV4:
+		ips = []string{v.Value.Ip}
+	case *oxide.PrivateIpStackV6:
+		ips = []string{v.Value.Ip}
+	case *oxide.PrivateIpStackDualStack:
+		ips = []string{v.Value.V4.Ip, v.Value.V6.Ip}
+	case oxide.PrivateIpStackV4:
+		ips = []string{v.Value.Ip}
+	case oxide.PrivateIpStackV6:
+		ips = []string{v.Value.Ip}
+	case oxide.PrivateIpStackDualStack:
+		ips = []string{v.Value.V4.Ip, v.Value.V6.Ip}
+	}
+
+	var addrs []netip.Addr
+	for _, ip := range ips {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// contains reports whether one of the blocks of a subnet contains addr.
+func (n *network) contains(subnet oxide.Name, addr netip.Addr) bool {
+	for _, block := range n.subnets[subnet] {
+		if block.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesTarget reports whether a target selects a network interface.
+func (n *network) matchesTarget(s selector, iface nic) bool {
+	switch s.kind {
+	case kindVpc:
+		return s.name == n.vpc
+	case kindSubnet:
+		return s.name == iface.subnet
+	case kindInstance:
+		return s.name == iface.instance
+	}
+	for _, addr := range iface.addrs {
+		if s.prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesHost reports whether a host filter selects the address at the other end of the traffic.
+// The VPC, subnet and instance filters only select the addresses of the resources of the VPC.
+func (n *network) matchesHost(s selector, addr netip.Addr) bool {
+	switch s.kind {
+	case kindVpc:
+		if s.name != n.vpc {
+			return false
+		}
+		for subnet := range n.subnets {
+			if n.contains(subnet, addr) {
+				return true
+			}
+		}
+		return false
+	case kindSubnet:
+		return n.contains(s.name, addr)
+	case kindInstance:
+		for _, iface := range n.nics {
+			if iface.instance == s.name && slices.Contains(iface.addrs, addr) {
+				return true
+			}
+		}
+		return false
+	}
+	return s.prefix.Contains(addr)
+}
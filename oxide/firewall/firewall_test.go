@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package firewall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FetchInventory(t *testing.T) {
+	inv := testInventory(t)
+
+	instanceNICs := func(instance string) []oxide.InstanceNetworkInterface {
+		var nics []oxide.InstanceNetworkInterface
+		for _, nic := range inv.NetworkInterfaces {
+			if nic.InstanceId == "id-"+instance {
+				nics = append(nics, nic)
+			}
+		}
+		return nics
+	}
+	page := func(items any) any { return map[string]any{"items": items} }
+	// responses holds the responses by path, for the project ci.
+	responses := map[string]any{
+		"/v1/vpcs/web":           inv.Vpc,
+		"/v1/vpc-firewall-rules": map[string]any{"rules": inv.Rules},
+		"/v1/vpc-subnets":        page(inv.Subnets),
+		"/v1/instances":          page(inv.Instances),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.Method != http.MethodGet || query.Get("project") != "ci" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			return
+		}
+		if r.URL.Path == "/v1/network-interfaces" {
+			json.NewEncoder(w).Encode(page(instanceNICs(query.Get("instance"))))
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"error_code": "ObjectNotFound"})
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	client, err := oxide.NewClient(oxide.WithHost(server.URL), oxide.WithToken("foo"))
+	require.NoError(t, err)
+
+	t.Run("fetches the VPC", func(t *testing.T) {
+		got, err := FetchInventory(context.Background(), client, "ci", "web")
+		require.NoError(t, err)
+		assert.Equal(t, inv.Vpc, got.Vpc)
+		assert.Equal(t, inv.Rules, got.Rules)
+		assert.Equal(t, inv.Subnets, got.Subnets)
+		assert.Equal(t, inv.Instances, got.Instances)
+		// The interface of the instance other is in another VPC.
+		assert.Equal(t, inv.NetworkInterfaces[:3], got.NetworkInterfaces)
+
+		_, err = NewEvaluator(got)
+		require.NoError(t, err)
+	})
+
+	t.Run("fails on missing VPCs", func(t *testing.T) {
+		_, err := FetchInventory(context.Background(), client, "ci", "db")
+		require.ErrorIs(t, err, oxide.ErrObjectNotFound)
+		assert.ErrorContains(t, err, "error viewing VPC db")
+	})
+}
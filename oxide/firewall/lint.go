@@ -0,0 +1,448 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package firewall
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// Severity is the severity of a Finding.
+type Severity string
+
+const (
+	// SeverityError reports a rule that is invalid or unsafe, and shouldn't be applied.
+	SeverityError Severity = "error"
+
+	// SeverityWarning reports a rule that doesn't do what it appears to do.
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo reports a rule that has no effect, but doesn't change the traffic that is
+	// allowed.
+	SeverityInfo Severity = "info"
+)
+
+// Check is the check that reports a Finding.
+type Check string
+
+const (
+	// CheckInvalid reports the rules that can't be evaluated, e.g. because of an invalid port
+	// range. The other checks skip them.
+	CheckInvalid Check = "invalid"
+
+	// CheckUnknownReference reports the targets and host filters that reference a subnet or an
+	// instance that doesn't exist.
+	CheckUnknownReference Check = "unknown_reference"
+
+	// CheckAllowAnyInbound reports the rules that allow all inbound traffic, from any host, with
+	// any protocol and to any port.
+	CheckAllowAnyInbound Check = "allow_any_inbound"
+
+	// CheckDuplicate reports the rules that match the same traffic as another rule, with the
+	// same priority and action.
+	CheckDuplicate Check = "duplicate"
+
+	// CheckShadowed reports the rules that never decide, because all the traffic they match is
+	// matched by rules that take precedence: by a single rule, or by several rules together, e.g.
+	// a rule for ports 1-1000 after rules for ports 1-500 and 501-1000. Addresses that are only
+	// matched by several targets or host filters together aren't detected.
+	CheckShadowed Check = "shadowed"
+)
+
+// Finding is a problem with a firewall rule.
+type Finding struct {
+	// Severity is the severity of the problem.
+	Severity Severity
+
+	// Check is the check that reports the problem.
+	Check Check
+
+	// Rule is the name of the rule.
+	Rule oxide.Name
+
+	// Related is the name of the other rule involved, for duplicate and shadowed rules. It's the
+	// first of the rules that take precedence when a rule is shadowed by several rules.
+	Related oxide.Name
+
+	// Message describes the problem.
+	Message string
+}
+
+// String describes the finding, e.g. "warning: rule allow-web: never applies: rule deny-all
+// (priority 100) takes precedence and denies all its traffic".
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: rule %s: %s", f.Severity, f.Rule, f.Message)
+}
+
+// Lint checks firewall rules, typically the rules about to be passed to VpcFirewallRulesUpdate,
+// against the subnets, instances and network interfaces of an inventory. The rules of the
+// inventory are ignored: to check them, pass their ToUpdate conversions.
+//
+// Disabled rules are only checked for validity and references. Whether a rule is shadowed is
+// decided against every other rule on its own, and given the current network interfaces when
+// they are selected by instance. The findings are in the order of the rules. It fails if a subnet
+// or a network interface of the inventory has an invalid address.
+func Lint(inv *Inventory, rules []oxide.VpcFirewallRuleUpdate) ([]Finding, error) {
+	n, err := newNetwork(inv)
+	if err != nil {
+		return nil, err
+	}
+	l := &linter{
+		network:   n,
+		instances: make(map[oxide.Name]bool),
+		rules:     rules,
+		findings:  make([][]Finding, len(rules)),
+	}
+	for _, instance := range inv.Instances {
+		l.instances[instance.Name] = true
+	}
+
+	var enabled []*rule
+	for i, r := range rules {
+		p, err := parseRule(r)
+		if err != nil {
+			l.add(i, SeverityError, CheckInvalid, "", err.Error())
+			continue
+		}
+		p.index = i
+		l.checkReferences(p)
+		if p.Status == oxide.VpcFirewallRuleStatusEnabled {
+			l.checkAllowAny(p)
+			enabled = append(enabled, p)
+		}
+	}
+	sortRules(enabled)
+	l.checkShadowed(enabled)
+	return slices.Concat(l.findings...), nil
+}
+
+// linter collects the findings of the rules.
+type linter struct {
+	network   *network
+	instances map[oxide.Name]bool
+	rules     []oxide.VpcFirewallRuleUpdate
+	// findings holds the findings by rule index.
+	findings [][]Finding
+}
+
+func (l *linter) add(index int, severity Severity, check Check, related oxide.Name, msg string) {
+	l.findings[index] = append(l.findings[index], Finding{
+		Severity: severity,
+		Check:    check,
+		Rule:     l.rules[index].Name,
+		Related:  related,
+		Message:  msg,
+	})
+}
+
+func (l *linter) checkReferences(r *rule) {
+	check := func(what string, selectors []selector) {
+		for _, s := range selectors {
+			var known bool
+			switch s.kind {
+			case kindSubnet:
+				_, known = l.network.subnets[s.name]
+			case kindInstance:
+				known = l.instances[s.name]
+			default:
+				continue
+			}
+			if !known {
+				msg := fmt.Sprintf("%s %s doesn't exist", what, s)
+				l.add(r.index, SeverityError, CheckUnknownReference, "", msg)
+			}
+		}
+	}
+	check("target", r.targets)
+	check("host filter", r.hosts)
+}
+
+func (l *linter) checkAllowAny(r *rule) {
+	if r.Direction != oxide.VpcFirewallRuleDirectionInbound ||
+		r.Action != oxide.VpcFirewallRuleActionAllow ||
+		len(r.protocols) > 0 || len(r.ports) > 0 {
+		return
+	}
+	if len(r.hosts) > 0 && !slices.ContainsFunc(r.hosts, func(s selector) bool {
+		return s.kind == kindIPNet && s.prefix.Bits() == 0
+	}) {
+		return
+	}
+	msg := "allows all inbound traffic from anywhere"
+	l.add(r.index, SeverityError, CheckAllowAnyInbound, "", msg)
+}
+
+// checkShadowed reports the rules that are covered by a rule that takes precedence, or by several
+// of them together, given rules in the order they apply.
+func (l *linter) checkShadowed(rules []*rule) {
+	for i, r := range rules {
+		j := slices.IndexFunc(rules[:i], func(first *rule) bool {
+			return l.network.coversRule(first, r)
+		})
+		if j < 0 {
+			if shadowing := l.network.shadowingRules(rules[:i], r); shadowing != nil {
+				l.addShadowed(r, shadowing)
+			}
+			continue
+		}
+		first := rules[j]
+		if first.priority == r.priority && first.Action == r.Action &&
+			l.network.coversRule(r, first) {
+			msg := fmt.Sprintf("duplicates rule %s", first.Name)
+			l.add(r.index, SeverityWarning, CheckDuplicate, first.Name, msg)
+			continue
+		}
+		l.addShadowed(r, []*rule{first})
+	}
+}
+
+// addShadowed reports a rule that is shadowed by rules that take precedence.
+func (l *linter) addShadowed(r *rule, shadowing []*rule) {
+	action := shadowing[0].Action
+	mixed := slices.ContainsFunc(shadowing, func(s *rule) bool { return s.Action != action })
+	names := make([]string, len(shadowing))
+	for i, s := range shadowing {
+		names[i] = fmt.Sprintf("%s (priority %d)", s.Name, s.priority)
+	}
+
+	by := fmt.Sprintf("rule %s takes precedence", names[0])
+	verb := actionVerb(action, false)
+	if len(names) > 1 {
+		by = fmt.Sprintf(
+			"rules %s and %s take precedence",
+			strings.Join(names[:len(names)-1], ", "),
+			names[len(names)-1],
+		)
+		verb = actionVerb(action, true)
+	}
+	switch {
+	case mixed:
+		msg := fmt.Sprintf("never applies: %s and match all its traffic", by)
+		l.add(r.index, SeverityWarning, CheckShadowed, shadowing[0].Name, msg)
+	case action == r.Action:
+		msg := fmt.Sprintf("has no effect: %s and already %s all its traffic", by, verb)
+		l.add(r.index, SeverityInfo, CheckShadowed, shadowing[0].Name, msg)
+	default:
+		msg := fmt.Sprintf("never applies: %s and %s all its traffic", by, verb)
+		l.add(r.index, SeverityWarning, CheckShadowed, shadowing[0].Name, msg)
+	}
+}
+
+// actionVerb returns the verb of an action, e.g. "denies", or "deny" for several rules.
+func actionVerb(action oxide.VpcFirewallRuleAction, plural bool) string {
+	switch {
+	case action == oxide.VpcFirewallRuleActionDeny && plural:
+		return "deny"
+	case action == oxide.VpcFirewallRuleActionDeny:
+		return "denies"
+	case plural:
+		return "allow"
+	}
+	return "allows"
+}
+
+// coversRule reports whether rule a matches all the traffic that rule b matches.
+func (n *network) coversRule(a, b *rule) bool {
+	if a.Direction != b.Direction {
+		return false
+	}
+	if !all(b.targets, func(t selector) bool {
+		return slices.ContainsFunc(a.targets, func(s selector) bool { return n.covers(s, t, true) })
+	}) {
+		return false
+	}
+	if len(a.hosts) > 0 && (len(b.hosts) == 0 || !all(b.hosts, func(h selector) bool {
+		return slices.ContainsFunc(a.hosts, func(s selector) bool { return n.covers(s, h, false) })
+	})) {
+		return false
+	}
+	if len(a.protocols) > 0 && (len(b.protocols) == 0 || !all(b.protocols, func(q protocol) bool {
+		return slices.ContainsFunc(a.protocols, func(p protocol) bool { return p.covers(q) })
+	})) {
+		return false
+	}
+	if len(a.ports) > 0 && (len(b.ports) == 0 || !all(b.ports, func(q portRange) bool {
+		return slices.ContainsFunc(a.ports, func(p portRange) bool { return p.covers(q) })
+	})) {
+		return false
+	}
+	return true
+}
+
+func all[T any](s []T, f func(T) bool) bool {
+	return !slices.ContainsFunc(s, func(v T) bool { return !f(v) })
+}
+
+// shadowingRules returns the rules among earlier, in order, that together match all the traffic
+// that rule r matches, or nil if they don't. Every combination of a target, a host filter and a
+// protocol of r must be matched by rules whose ports together cover the ports of r. The rules
+// that don't match any port of r aren't returned.
+func (n *network) shadowingRules(earlier []*rule, r *rule) []*rule {
+	ports := r.ports
+	if len(ports) == 0 {
+		ports = []portRange{{first: 0, last: 65535}}
+	}
+	used := make([]bool, len(earlier))
+	for _, t := range r.targets {
+		for _, h := range orAny(r.hosts) {
+			for _, q := range orAny(r.protocols) {
+				var covered []portRange
+				for i, e := range earlier {
+					if !n.coversCombination(e, r.Direction, t, h, q) {
+						continue
+					}
+					eports := e.ports
+					if len(eports) == 0 {
+						eports = []portRange{{first: 0, last: 65535}}
+					}
+					if slices.ContainsFunc(eports, func(p portRange) bool {
+						return slices.ContainsFunc(ports, p.overlaps)
+					}) {
+						used[i] = true
+					}
+					covered = append(covered, eports...)
+				}
+				if !rangesCover(covered, ports) {
+					return nil
+				}
+			}
+		}
+	}
+
+	var shadowing []*rule
+	for i, e := range earlier {
+		if used[i] {
+			shadowing = append(shadowing, e)
+		}
+	}
+	return shadowing
+}
+
+// coversCombination reports whether rule a matches all the traffic in direction to target t,
+// from or to host h and with protocol q, on some ports. A nil host or protocol is any host or
+// protocol.
+func (n *network) coversCombination(
+	a *rule,
+	direction oxide.VpcFirewallRuleDirection,
+	t selector,
+	h *selector,
+	q *protocol,
+) bool {
+	if a.Direction != direction ||
+		!slices.ContainsFunc(a.targets, func(s selector) bool { return n.covers(s, t, true) }) {
+		return false
+	}
+	if len(a.hosts) > 0 && (h == nil ||
+		!slices.ContainsFunc(a.hosts, func(s selector) bool { return n.covers(s, *h, false) })) {
+		return false
+	}
+	return len(a.protocols) == 0 ||
+		(q != nil && slices.ContainsFunc(a.protocols, func(p protocol) bool { return p.covers(*q) }))
+}
+
+// orAny returns pointers to the elements of s, or a single nil pointer, which stands for any
+// element, if s is empty.
+func orAny[T any](s []T) []*T {
+	if len(s) == 0 {
+		return []*T{nil}
+	}
+	ptrs := make([]*T, len(s))
+	for i := range s {
+		ptrs[i] = &s[i]
+	}
+	return ptrs
+}
+
+// rangesCover reports whether the union of ranges contains all the ranges of want.
+func rangesCover(ranges, want []portRange) bool {
+	ranges = slices.Clone(ranges)
+	slices.SortFunc(ranges, func(a, b portRange) int { return cmp.Compare(a.first, b.first) })
+	var merged []portRange
+	for _, r := range ranges {
+		if last := len(merged) - 1; last >= 0 && r.first <= merged[last].last+1 {
+			merged[last].last = max(merged[last].last, r.last)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return all(want, func(w portRange) bool {
+		return slices.ContainsFunc(merged, func(m portRange) bool { return m.covers(w) })
+	})
+}
+
+// covers reports whether selector a selects everything that selector b selects, as a target or
+// as a host filter.
+func (n *network) covers(a, b selector, target bool) bool {
+	if a == b {
+		return true
+	}
+	// A VPC target selects all the network interfaces of the VPC, whatever their addresses.
+	if target && a.kind == kindVpc && a.name == n.vpc {
+		return true
+	}
+	inner := n.prefixes(b)
+	if len(inner) == 0 {
+		return false
+	}
+	outer := n.prefixes(a)
+	return all(inner, func(q netip.Prefix) bool {
+		return slices.ContainsFunc(outer, func(p netip.Prefix) bool {
+			return p.Bits() <= q.Bits() && p.Contains(q.Addr())
+		})
+	})
+}
+
+// prefixes returns the addresses that a selector selects, which are unknown for the resources of
+// other VPCs and for the resources that don't exist.
+func (n *network) prefixes(s selector) []netip.Prefix {
+	var prefixes []netip.Prefix
+	switch s.kind {
+	case kindVpc:
+		if s.name == n.vpc {
+			for _, blocks := range n.subnets {
+				prefixes = append(prefixes, blocks...)
+			}
+		}
+	case kindSubnet:
+		prefixes = n.subnets[s.name]
+	case kindInstance:
+		for _, iface := range n.nics {
+			if iface.instance != s.name {
+				continue
+			}
+			for _, addr := range iface.addrs {
+				prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			}
+		}
+	default:
+		prefixes = []netip.Prefix{s.prefix}
+	}
+	return prefixes
+}
+
+// covers reports whether a protocol filter matches all the packets that protocol filter q
+// matches.
+func (p protocol) covers(q protocol) bool {
+	if p.kind != q.kind {
+		return false
+	}
+	if p.icmpType != nil && (q.icmpType == nil || *p.icmpType != *q.icmpType) {
+		return false
+	}
+	return p.icmpCodes == nil || (q.icmpCodes != nil && p.icmpCodes.covers(*q.icmpCodes))
+}
+
+func (r portRange) covers(q portRange) bool {
+	return r.first <= q.first && q.last <= r.last
+}
+
+func (r portRange) overlaps(q portRange) bool {
+	return r.first <= q.last && q.first <= r.last
+}
@@ -0,0 +1,264 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package firewall
+
+import (
+	"testing"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Lint(t *testing.T) {
+	inv := testInventory(t)
+
+	t.Run("reports problems", func(t *testing.T) {
+		rules := decode[[]oxide.VpcFirewallRuleUpdate](t, `[
+			{
+				"name": "allow-https", "action": "allow", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "subnet", "value": "frontend"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["443"]}
+			},
+			{
+				"name": "allow-https-copy", "action": "allow", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "subnet", "value": "frontend"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["443"]}
+			},
+			{
+				"name": "allow-https-web", "action": "allow", "direction": "inbound",
+				"priority": 200, "status": "enabled",
+				"targets": [{"type": "ip_net", "value": "10.0.1.0/28"}],
+				"filters": {
+					"hosts": [{"type": "ip_net", "value": "203.0.113.0/24"}],
+					"protocols": [{"type": "tcp"}],
+					"ports": ["443"]
+				}
+			},
+			{
+				"name": "deny-inbound", "action": "deny", "direction": "inbound",
+				"priority": 150, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {}
+			},
+			{
+				"name": "allow-db", "action": "allow", "direction": "inbound",
+				"priority": 200, "status": "enabled",
+				"targets": [{"type": "instance", "value": "db-1"}],
+				"filters": {
+					"hosts": [{"type": "subnet", "value": "frontend"}],
+					"protocols": [{"type": "tcp"}],
+					"ports": ["5432"]
+				}
+			},
+			{
+				"name": "allow-anything", "action": "allow", "direction": "inbound",
+				"priority": 300, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"hosts": [{"type": "ip_net", "value": "0.0.0.0/0"}]}
+			},
+			{
+				"name": "allow-cache", "action": "allow", "direction": "inbound",
+				"priority": 0, "status": "disabled",
+				"targets": [{"type": "subnet", "value": "cache"}],
+				"filters": {"hosts": [{"type": "instance", "value": "redis"}]}
+			},
+			{
+				"name": "allow-high-ports", "action": "allow", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["1024-70000"]}
+			},
+			{
+				"name": "allow-ping", "action": "allow", "direction": "inbound",
+				"priority": 50, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"protocols": [{"type": "icmp", "value": {"icmp_type": 8}}]}
+			},
+			{
+				"name": "allow-echo", "action": "allow", "direction": "inbound",
+				"priority": 60, "status": "enabled",
+				"targets": [{"type": "subnet", "value": "backend"}],
+				"filters": {"protocols": [{"type": "icmp", "value": {"icmp_type": 8, "code": "0"}}]}
+			},
+			{
+				"name": "allow-egress", "action": "allow", "direction": "outbound",
+				"priority": 65534, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {}
+			}
+		]`)
+
+		findings, err := Lint(inv, rules)
+		require.NoError(t, err)
+		assert.Equal(t, []Finding{
+			{
+				Severity: SeverityWarning,
+				Check:    CheckDuplicate,
+				Rule:     "allow-https-copy",
+				Related:  "allow-https",
+				Message:  "duplicates rule allow-https",
+			},
+			{
+				Severity: SeverityInfo,
+				Check:    CheckShadowed,
+				Rule:     "allow-https-web",
+				Related:  "allow-https",
+				Message: "has no effect: rule allow-https (priority 100) takes precedence and " +
+					"already allows all its traffic",
+			},
+			{
+				Severity: SeverityWarning,
+				Check:    CheckShadowed,
+				Rule:     "allow-db",
+				Related:  "deny-inbound",
+				Message: "never applies: rule deny-inbound (priority 150) takes precedence and " +
+					"denies all its traffic",
+			},
+			{
+				Severity: SeverityError,
+				Check:    CheckAllowAnyInbound,
+				Rule:     "allow-anything",
+				Message:  "allows all inbound traffic from anywhere",
+			},
+			{
+				Severity: SeverityWarning,
+				Check:    CheckShadowed,
+				Rule:     "allow-anything",
+				Related:  "deny-inbound",
+				Message: "never applies: rule deny-inbound (priority 150) takes precedence and " +
+					"denies all its traffic",
+			},
+			{
+				Severity: SeverityError,
+				Check:    CheckUnknownReference,
+				Rule:     "allow-cache",
+				Message:  "target subnet cache doesn't exist",
+			},
+			{
+				Severity: SeverityError,
+				Check:    CheckUnknownReference,
+				Rule:     "allow-cache",
+				Message:  "host filter instance redis doesn't exist",
+			},
+			{
+				Severity: SeverityError,
+				Check:    CheckInvalid,
+				Rule:     "allow-high-ports",
+				Message:  "invalid port range: invalid range \"1024-70000\"",
+			},
+			{
+				Severity: SeverityInfo,
+				Check:    CheckShadowed,
+				Rule:     "allow-echo",
+				Related:  "allow-ping",
+				Message: "has no effect: rule allow-ping (priority 50) takes precedence and " +
+					"already allows all its traffic",
+			},
+		}, findings)
+		assert.Equal(
+			t,
+			"warning: rule allow-https-copy: duplicates rule allow-https",
+			findings[0].String(),
+		)
+	})
+
+	t.Run("reports rules shadowed by several rules", func(t *testing.T) {
+		rules := decode[[]oxide.VpcFirewallRuleUpdate](t, `[
+			{
+				"name": "deny-low", "action": "deny", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["1-500"]}
+			},
+			{
+				"name": "deny-high", "action": "deny", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["501-1000"]}
+			},
+			{
+				"name": "allow-frontend", "action": "allow", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "subnet", "value": "frontend"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["2000-3000"]}
+			},
+			{
+				"name": "allow-backend", "action": "allow", "direction": "inbound",
+				"priority": 100, "status": "enabled",
+				"targets": [{"type": "subnet", "value": "backend"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["2000-3000"]}
+			},
+			{
+				"name": "allow-low", "action": "allow", "direction": "inbound",
+				"priority": 200, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["1-1000"]}
+			},
+			{
+				"name": "allow-both", "action": "allow", "direction": "inbound",
+				"priority": 200, "status": "enabled",
+				"targets": [
+					{"type": "subnet", "value": "frontend"},
+					{"type": "subnet", "value": "backend"}
+				],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["2500"]}
+			},
+			{
+				"name": "deny-mixed", "action": "deny", "direction": "inbound",
+				"priority": 200, "status": "enabled",
+				"targets": [{"type": "subnet", "value": "frontend"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["900-1000", "2000-2100"]}
+			},
+			{
+				"name": "allow-gap", "action": "allow", "direction": "inbound",
+				"priority": 200, "status": "enabled",
+				"targets": [{"type": "vpc", "value": "web"}],
+				"filters": {"protocols": [{"type": "tcp"}], "ports": ["900-2100"]}
+			}
+		]`)
+
+		findings, err := Lint(inv, rules)
+		require.NoError(t, err)
+		assert.Equal(t, []Finding{
+			{
+				Severity: SeverityWarning,
+				Check:    CheckShadowed,
+				Rule:     "allow-low",
+				Related:  "deny-low",
+				Message: "never applies: rules deny-low (priority 100) and deny-high " +
+					"(priority 100) take precedence and deny all its traffic",
+			},
+			{
+				Severity: SeverityInfo,
+				Check:    CheckShadowed,
+				Rule:     "allow-both",
+				Related:  "allow-frontend",
+				Message: "has no effect: rules allow-frontend (priority 100) and allow-backend " +
+					"(priority 100) take precedence and already allow all its traffic",
+			},
+			{
+				Severity: SeverityWarning,
+				Check:    CheckShadowed,
+				Rule:     "deny-mixed",
+				Related:  "deny-high",
+				Message: "never applies: rules deny-high (priority 100) and allow-frontend " +
+					"(priority 100) take precedence and match all its traffic",
+			},
+		}, findings)
+	})
+
+	t.Run("accepts valid rules", func(t *testing.T) {
+		var rules []oxide.VpcFirewallRuleUpdate
+		for _, r := range inv.Rules {
+			rules = append(rules, r.ToUpdate())
+		}
+		findings, err := Lint(inv, rules)
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+}
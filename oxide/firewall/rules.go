@@ -0,0 +1,265 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package firewall
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// The kinds of selectors, which are shared by targets and host filters.
+const (
+	kindVpc      = "vpc"
+	kindSubnet   = "subnet"
+	kindInstance = "instance"
+	kindIP       = "ip"
+	kindIPNet    = "ip_net"
+)
+
+// rule is a firewall rule parsed for evaluation.
+type rule struct {
+	oxide.VpcFirewallRuleUpdate
+	// index is the index of the rule in the rules it was parsed from.
+	index     int
+	priority  int
+	targets   []selector
+	hosts     []selector
+	protocols []protocol
+	ports     []portRange
+}
+
+// selector is a target or a host filter of a rule. Named resources are selected by name, and
+// addresses by prefix, with a single address as a full-length prefix.
+type selector struct {
+	kind   string
+	name   oxide.Name
+	prefix netip.Prefix
+}
+
+func (s selector) String() string {
+	if s.kind == kindIP || s.kind == kindIPNet {
+		return fmt.Sprintf("%s %s", s.kind, s.prefix)
+	}
+	return fmt.Sprintf("%s %s", s.kind, s.name)
+}
+
+// protocol is a protocol filter of a rule. icmpType and icmpCodes are only set for the ICMP
+// filters that restrict them.
+type protocol struct {
+	kind      oxide.VpcFirewallRuleProtocolType
+	icmpType  *int
+	icmpCodes *portRange
+}
+
+// portRange is an inclusive range of ports or of ICMP codes.
+type portRange struct {
+	first, last int
+}
+
+func (r portRange) contains(n int) bool {
+	return r.first <= n && n <= r.last
+}
+
+// sortRules sorts rules in the order they apply: by priority, with deny rules before the allow
+// rules of the same priority.
+func sortRules(rules []*rule) {
+	slices.SortStableFunc(rules, func(a, b *rule) int {
+		if c := cmp.Compare(a.priority, b.priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(actionOrder(a.Action), actionOrder(b.Action))
+	})
+}
+
+func actionOrder(action oxide.VpcFirewallRuleAction) int {
+	if action == oxide.VpcFirewallRuleActionDeny {
+		return 0
+	}
+	return 1
+}
+
+// parseRules parses rules, and returns an error for each rule that can't be evaluated.
+func parseRules(rules []oxide.VpcFirewallRuleUpdate) ([]*rule, error) {
+	var (
+		parsed []*rule
+		errs   []error
+	)
+	for i, r := range rules {
+		p, err := parseRule(r)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("firewall rule %s: %w", r.Name, err))
+			continue
+		}
+		p.index = i
+		parsed = append(parsed, p)
+	}
+	return parsed, errors.Join(errs...)
+}
+
+func parseRule(r oxide.VpcFirewallRuleUpdate) (*rule, error) {
+	p := &rule{VpcFirewallRuleUpdate: r}
+	if r.Priority == nil {
+		return nil, errors.New("priority must be set")
+	}
+	p.priority = *r.Priority
+
+	for _, t := range r.Targets {
+		s, err := parseTarget(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target: %w", err)
+		}
+		p.targets = append(p.targets, s)
+	}
+	for _, h := range r.Filters.Hosts {
+		s, err := parseHost(h)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host filter: %w", err)
+		}
+		p.hosts = append(p.hosts, s)
+	}
+	for _, proto := range r.Filters.Protocols {
+		pr, err := parseProtocol(proto)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protocol filter: %w", err)
+		}
+		p.protocols = append(p.protocols, pr)
+	}
+	for _, port := range r.Filters.Ports {
+		pr, err := parseRange(string(port), 65535)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port range: %w", err)
+		}
+		p.ports = append(p.ports, pr)
+	}
+	return p, nil
+}
+
+func parseTarget(t oxide.VpcFirewallRuleTarget) (selector, error) {
+	switch v := t.Value.(type) {
+	case *oxide.VpcFirewallRuleTargetVpc:
+		return selector{kind: kindVpc, name: v.Value}, nil
+	case *oxide.VpcFirewallRuleTargetSubnet:
+		return selector{kind: kindSubnet, name: v.Value}, nil
+	case *oxide.VpcFirewallRuleTargetInstance:
+		return selector{kind: kindInstance, name: v.Value}, nil
+	case *oxide.VpcFirewallRuleTargetIp:
+		return parseAddr(v.Value)
+	case *oxide.VpcFirewallRuleTargetIpNet:
+		return parseIPNet(v.Value)
+	case oxide.VpcFirewallRuleTargetVpc:
+		return selector{kind: kindVpc, name: v.Value}, nil
+	case oxide.VpcFirewallRuleTargetSubnet:
+		return selector{kind: kindSubnet, name: v.Value}, nil
+	case oxide.VpcFirewallRuleTargetInstance:
+		return selector{kind: kindInstance, name: v.Value}, nil
+	case oxide.VpcFirewallRuleTargetIp:
+		return parseAddr(v.Value)
+	case oxide.VpcFirewallRuleTargetIpNet:
+		return parseIPNet(v.Value)
+	}
+	return selector{}, fmt.Errorf("unknown variant %T", t.Value)
+}
+
+func parseHost(h oxide.VpcFirewallRuleHostFilter) (selector, error) {
+	switch v := h.Value.(type) {
+	case *oxide.VpcFirewallRuleHostFilterVpc:
+		return selector{kind: kindVpc, name: v.Value}, nil
+	case *oxide.VpcFirewallRuleHostFilterSubnet:
+		return selector{kind: kindSubnet, name: v.Value}, nil
+	case *oxide.VpcFirewallRuleHostFilterInstance:
+		return selector{kind: kindInstance, name: v.Value}, nil
+	case *oxide.VpcFirewallRuleHostFilterIp:
+		return parseAddr(v.Value)
+	case *oxide.VpcFirewallRuleHostFilterIpNet:
+		return parseIPNet(v.Value)
+	case oxide.VpcFirewallRuleHostFilterVpc:
+		return selector{kind: kindVpc, name: v.Value}, nil
+	case oxide.VpcFirewallRuleHostFilterSubnet:
+		return selector{kind: kindSubnet, name: v.Value}, nil
+	case oxide.VpcFirewallRuleHostFilterInstance:
+		return selector{kind: kindInstance, name: v.Value}, nil
+	case oxide.VpcFirewallRuleHostFilterIp:
+		return parseAddr(v.Value)
+	case oxide.VpcFirewallRuleHostFilterIpNet:
+		return parseIPNet(v.Value)
+	}
+	return selector{}, fmt.Errorf("unknown variant %T", h.Value)
+}
+
+func parseAddr(s string) (selector, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return selector{}, err
+	}
+	addr = addr.Unmap()
+	return selector{kind: kindIP, prefix: netip.PrefixFrom(addr, addr.BitLen())}, nil
+}
+
+func parseIPNet(n oxide.IpNet) (selector, error) {
+	prefix, err := netip.ParsePrefix(n.String())
+	if err != nil {
+		return selector{}, err
+	}
+	return selector{kind: kindIPNet, prefix: prefix.Masked()}, nil
+}
+
+func parseProtocol(p oxide.VpcFirewallRuleProtocol) (protocol, error) {
+	var filter *oxide.VpcFirewallIcmpFilter
+	switch v := p.Value.(type) {
+	case *oxide.VpcFirewallRuleProtocolIcmp:
+		filter = v.Value
+	case *oxide.VpcFirewallRuleProtocolIcmp6:
+		filter = v.Value
+	case oxide.VpcFirewallRuleProtocolIcmp:
+		filter = v.Value
+	case oxide.VpcFirewallRuleProtocolIcmp6:
+		filter = v.Value
+	}
+	pr := protocol{kind: p.Type()}
+	if pr.kind == "" {
+		return pr, fmt.Errorf("unknown variant %T", p.Value)
+	}
+	if filter == nil {
+		return pr, nil
+	}
+	pr.icmpType = filter.IcmpType
+	if filter.Code != "" {
+		codes, err := parseRange(string(filter.Code), 255)
+		if err != nil {
+			return pr, fmt.Errorf("invalid ICMP code range: %w", err)
+		}
+		pr.icmpCodes = &codes
+	}
+	return pr, nil
+}
+
+// parseRange parses a number, e.g. "443", or an inclusive range, e.g. "8000-8080".
+func parseRange(s string, limit int) (portRange, error) {
+	first, last, ok := strings.Cut(s, "-")
+	if !ok {
+		last = first
+	}
+	var (
+		r   portRange
+		err error
+	)
+	if r.first, err = strconv.Atoi(first); err != nil {
+		return r, fmt.Errorf("invalid range %q", s)
+	}
+	if r.last, err = strconv.Atoi(last); err != nil {
+		return r, fmt.Errorf("invalid range %q", s)
+	}
+	if r.first < 0 || r.last > limit || r.first > r.last {
+		return r, fmt.Errorf("invalid range %q", s)
+	}
+	return r, nil
+}
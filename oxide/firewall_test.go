@@ -0,0 +1,323 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFirewall serves the firewall rules of the VPC web of the project ci. Like the API, it
+// replaces all the rules on update, with new IDs and modification times.
+type fakeFirewall struct {
+	mu      sync.Mutex
+	rules   []map[string]any
+	version int
+	// views counts the reads of the rules.
+	views int
+	// onView is called after every read.
+	onView func(f *fakeFirewall)
+	// updates holds the bodies of the updates.
+	updates []VpcFirewallRuleUpdateParams
+}
+
+func newFakeFirewall(t *testing.T, f *fakeFirewall) *Client {
+	t.Helper()
+
+	f.replace([]map[string]any{
+		{
+			"name":        "allow-ssh",
+			"description": "",
+			"action":      "allow",
+			"direction":   "inbound",
+			"priority":    65534,
+			"status":      "enabled",
+			"targets":     []any{map[string]any{"type": "vpc", "value": "web"}},
+			"filters": map[string]any{
+				"hosts":     nil,
+				"ports":     []any{"22"},
+				"protocols": []any{map[string]any{"type": "tcp"}},
+			},
+		},
+		{
+			"name":        "allow-icmp",
+			"description": "ping",
+			"action":      "allow",
+			"direction":   "inbound",
+			"priority":    65534,
+			"status":      "disabled",
+			"targets":     []any{map[string]any{"type": "subnet", "value": "frontend"}},
+			"filters": map[string]any{
+				"hosts": []any{map[string]any{"type": "ip_net", "value": "10.0.0.0/8"}},
+				"ports": nil,
+				"protocols": []any{map[string]any{
+					"type":  "icmp",
+					"value": map[string]any{"icmp_type": 8, "code": "0"},
+				}},
+			},
+		},
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/vpc-firewall-rules" ||
+			r.URL.Query().Get("project") != "ci" ||
+			r.URL.Query().Get("vpc") != "web" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			return
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]any{"rules": f.rules})
+			f.views++
+			if f.onView != nil {
+				f.onView(f)
+			}
+		case http.MethodPut:
+			var body VpcFirewallRuleUpdateParams
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			f.updates = append(f.updates, body)
+
+			var rules []map[string]any
+			data, err := json.Marshal(body.Rules)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &rules))
+			f.replace(rules)
+			json.NewEncoder(w).Encode(map[string]any{"rules": f.rules})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+	require.NoError(t, err)
+	return client
+}
+
+// replace replaces the rules, with new IDs and modification times.
+func (f *fakeFirewall) replace(rules []map[string]any) {
+	f.version++
+	modified := time.Date(2025, 1, 1, 0, f.version, 0, 0, time.UTC)
+	for _, rule := range rules {
+		rule["id"] = fmt.Sprintf("%s-%d", rule["name"], f.version)
+		rule["vpc_id"] = "id-web"
+		rule["time_created"] = modified
+		rule["time_modified"] = modified
+	}
+	f.rules = rules
+}
+
+// names returns the names of the rules of the last update.
+func (f *fakeFirewall) names() []Name {
+	var names []Name
+	for _, rule := range f.updates[len(f.updates)-1].Rules {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+func Test_FirewallRules(t *testing.T) {
+	ctx := context.Background()
+	params := VpcFirewallRulesViewParams{Project: "ci", Vpc: "web"}
+	allowHTTPS := VpcFirewallRuleUpdate{
+		Action:    VpcFirewallRuleActionAllow,
+		Direction: VpcFirewallRuleDirectionInbound,
+		Filters: VpcFirewallRuleFilter{
+			Ports:     []L4PortRange{"443"},
+			Protocols: []VpcFirewallRuleProtocol{{Value: &VpcFirewallRuleProtocolTcp{}}},
+		},
+		Name:     "allow-https",
+		Priority: NewPointer(100),
+		Status:   VpcFirewallRuleStatusEnabled,
+		Targets:  []VpcFirewallRuleTarget{{Value: &VpcFirewallRuleTargetVpc{Value: "web"}}},
+	}
+
+	t.Run("converts rules losslessly", func(t *testing.T) {
+		f := &fakeFirewall{}
+		client := newFakeFirewall(t, f)
+		current, err := client.VpcFirewallRulesView(ctx, params)
+		require.NoError(t, err)
+
+		// Writing the converted rules back must not change them.
+		updates := firewallRuleUpdates(current.Rules)
+		data, err := json.Marshal(VpcFirewallRuleUpdateParams{Rules: updates})
+		require.NoError(t, err)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(data, &body))
+		for i, rule := range body["rules"].([]any) {
+			for key, value := range rule.(map[string]any) {
+				assert.Equal(t, mustJSON(t, f.rules[i][key]), mustJSON(t, value), key)
+			}
+		}
+
+		updates[0].Targets[0] = VpcFirewallRuleTarget{Value: &VpcFirewallRuleTargetSubnet{}}
+		*updates[0].Priority = 1
+		assert.IsType(t, &VpcFirewallRuleTargetVpc{}, current.Rules[0].Targets[0].Value)
+		assert.Equal(t, 65534, *current.Rules[0].Priority)
+
+		// The variants of the targets and filters are copied too.
+		updates = firewallRuleUpdates(current.Rules)
+		updates[0].Targets[0].Value.(*VpcFirewallRuleTargetVpc).Value = "db"
+		host := updates[1].Filters.Hosts[0].Value.(*VpcFirewallRuleHostFilterIpNet)
+		*host.Value.Value.(*Ipv4Net) = "10.1.0.0/16"
+		icmp := updates[1].Filters.Protocols[0].Value.(*VpcFirewallRuleProtocolIcmp)
+		*icmp.Value.IcmpType = 0
+		icmp.Value.Code = "1"
+		assert.Equal(t, firewallRuleUpdates(current.Rules)[0].Targets, []VpcFirewallRuleTarget{
+			{Value: &VpcFirewallRuleTargetVpc{Value: "web"}},
+		})
+		assert.Equal(
+			t,
+			&VpcFirewallRuleHostFilterIpNet{Value: IpNet{Value: NewPointer(Ipv4Net("10.0.0.0/8"))}},
+			current.Rules[1].Filters.Hosts[0].Value,
+		)
+		assert.Equal(t, &VpcFirewallRuleProtocolIcmp{
+			Value: &VpcFirewallIcmpFilter{Code: "0", IcmpType: NewPointer(8)},
+		}, current.Rules[1].Filters.Protocols[0].Value)
+	})
+
+	t.Run("writes rules modified in place", func(t *testing.T) {
+		f := &fakeFirewall{}
+		client := newFakeFirewall(t, f)
+
+		_, err := client.PatchFirewallRules(
+			ctx,
+			params,
+			func(rules []VpcFirewallRuleUpdate) ([]VpcFirewallRuleUpdate, error) {
+				rules[0].Targets[0].Value.(*VpcFirewallRuleTargetVpc).Value = "db"
+				return rules, nil
+			},
+		)
+		require.NoError(t, err)
+		require.Len(t, f.updates, 1)
+		assert.Equal(
+			t,
+			&VpcFirewallRuleTargetVpc{Value: "db"},
+			f.updates[0].Rules[0].Targets[0].Value,
+		)
+	})
+
+	t.Run("adds a rule", func(t *testing.T) {
+		f := &fakeFirewall{}
+		client := newFakeFirewall(t, f)
+
+		rules, err := client.AddFirewallRule(ctx, params, allowHTTPS)
+		require.NoError(t, err)
+		assert.Len(t, rules.Rules, 3)
+		assert.Equal(t, []Name{"allow-ssh", "allow-icmp", "allow-https"}, f.names())
+		assert.Equal(t, firewallRuleUpdates(rules.Rules)[2], allowHTTPS)
+
+		_, err = client.AddFirewallRule(ctx, params, allowHTTPS)
+		require.ErrorIs(t, err, ErrObjectAlreadyExists)
+		assert.Len(t, f.updates, 1)
+	})
+
+	t.Run("removes a rule", func(t *testing.T) {
+		f := &fakeFirewall{}
+		client := newFakeFirewall(t, f)
+
+		_, err := client.RemoveFirewallRule(ctx, params, "allow-ssh")
+		require.NoError(t, err)
+		assert.Equal(t, []Name{"allow-icmp"}, f.names())
+
+		_, err = client.RemoveFirewallRule(ctx, params, "allow-ssh")
+		require.ErrorIs(t, err, ErrObjectNotFound)
+
+		_, err = client.RemoveFirewallRule(ctx, params, "allow-icmp")
+		require.NoError(t, err)
+		assert.NotNil(t, f.updates[1].Rules)
+		assert.Empty(t, f.updates[1].Rules)
+	})
+
+	t.Run("replaces a rule", func(t *testing.T) {
+		f := &fakeFirewall{}
+		client := newFakeFirewall(t, f)
+
+		_, err := client.ReplaceFirewallRule(ctx, params, "allow-ssh", allowHTTPS)
+		require.NoError(t, err)
+		assert.Equal(t, []Name{"allow-https", "allow-icmp"}, f.names())
+
+		_, err = client.ReplaceFirewallRule(ctx, params, "allow-ssh", allowHTTPS)
+		require.ErrorIs(t, err, ErrObjectNotFound)
+		_, err = client.ReplaceFirewallRule(ctx, params, "allow-icmp", allowHTTPS)
+		require.ErrorIs(t, err, ErrObjectAlreadyExists)
+		assert.Len(t, f.updates, 1)
+	})
+
+	t.Run("skips unchanged rules", func(t *testing.T) {
+		f := &fakeFirewall{}
+		client := newFakeFirewall(t, f)
+
+		rules, err := client.PatchFirewallRules(
+			ctx,
+			params,
+			func(rules []VpcFirewallRuleUpdate) ([]VpcFirewallRuleUpdate, error) {
+				return rules, nil
+			},
+		)
+		require.NoError(t, err)
+		assert.Len(t, rules.Rules, 2)
+		assert.Empty(t, f.updates)
+		assert.Equal(t, 1, f.views)
+	})
+
+	t.Run("detects concurrent modifications", func(t *testing.T) {
+		f := &fakeFirewall{}
+		f.onView = func(f *fakeFirewall) {
+			if f.views == 1 {
+				f.replace(f.rules)
+			}
+		}
+		client := newFakeFirewall(t, f)
+
+		_, err := client.AddFirewallRule(ctx, params, allowHTTPS)
+		require.ErrorIs(t, err, ErrFirewallRulesModified)
+		assert.Empty(t, f.updates)
+
+		// Retrying reads the new rules.
+		_, err = client.AddFirewallRule(ctx, params, allowHTTPS)
+		require.NoError(t, err)
+		assert.Len(t, f.updates, 1)
+	})
+
+	t.Run("returns patch errors", func(t *testing.T) {
+		f := &fakeFirewall{}
+		client := newFakeFirewall(t, f)
+
+		_, err := client.PatchFirewallRules(
+			ctx,
+			params,
+			func(rules []VpcFirewallRuleUpdate) ([]VpcFirewallRuleUpdate, error) {
+				return nil, assert.AnError
+			},
+		)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, f.updates)
+	})
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var decoded any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	data, err = json.Marshal(decoded)
+	require.NoError(t, err)
+	return string(data)
+}
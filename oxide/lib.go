@@ -7,14 +7,18 @@ package oxide
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pelletier/go-toml"
@@ -64,6 +68,17 @@ type clientConfig struct {
 	configDir         string
 	httpClient        *http.Client
 	userAgent         string
+	retryPolicy       *RetryPolicy
+	middleware        []Middleware
+	logger            *slog.Logger
+	tokenSource       TokenSource
+	resolve           map[string]string
+	dialContext       DialContextFunc
+	apiVersion        string
+	strictAPIVersion  bool
+	// callerHTTPClient is set while httpClient is the client passed to [WithHTTPClient], which
+	// is copied before being modified.
+	callerHTTPClient bool
 
 	// These fields track whether the options were set from [ClientOption]. This
 	// is used to determine whether values set via environment variables should
@@ -124,20 +139,19 @@ func WithConfigDir(dir string) ClientOption {
 // is set after this option.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return clientOptionFunc(func(cfg *clientConfig) error {
-		if cfg.httpClient == nil {
-			cfg.httpClient = defaultHTTPClient()
-		}
-		cfg.httpClient.Timeout = timeout
+		cfg.ownHTTPClient().Timeout = timeout
 		return nil
 	})
 }
 
 // WithHTTPClient sets a custom HTTP client, replacing the default HTTP client entirely. This
-// overrides [WithTimeout] and [WithInsecureSkipVerify] if called after those options, and should
-// only be used in advanced use cases such as configuring a proxy or changing TLS configuration.
+// overrides [WithTimeout] and the TLS options such as [WithInsecureSkipVerify] if called after
+// those options, and should only be used in advanced use cases such as configuring a proxy. The
+// client is copied, not modified, by the options that follow it and by the CA file of a profile.
 func WithHTTPClient(client *http.Client) ClientOption {
 	return clientOptionFunc(func(cfg *clientConfig) error {
 		cfg.httpClient = client
+		cfg.callerHTTPClient = client != nil
 		return nil
 	})
 }
@@ -147,22 +161,117 @@ func WithHTTPClient(client *http.Client) ClientOption {
 // set after this option.
 func WithInsecureSkipVerify() ClientOption {
 	return clientOptionFunc(func(cfg *clientConfig) error {
-		if cfg.httpClient == nil {
-			cfg.httpClient = defaultHTTPClient()
+		cfg.tlsConfig().InsecureSkipVerify = true
+		return nil
+	})
+}
+
+// WithRootCAs sets the certificate authorities used to verify the API's TLS certificate, replacing
+// the system roots. This option is overridden if [WithHTTPClient] is set after this option.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		if pool == nil {
+			return errors.New("root CA pool must not be nil")
 		}
-		transport, ok := cfg.httpClient.Transport.(*http.Transport)
-		if !ok || transport == nil {
-			transport = http.DefaultTransport.(*http.Transport).Clone()
+		cfg.tlsConfig().RootCAs = pool
+		return nil
+	})
+}
+
+// WithCACertFile reads PEM-encoded certificate authorities from path and uses them to verify the
+// API's TLS certificate, replacing the system roots. This is useful for racks whose certificate is
+// signed by a private CA. This option is overridden if [WithHTTPClient] is set after this option.
+func WithCACertFile(path string) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		pool, err := loadCACertFile(path)
+		if err != nil {
+			return err
 		}
-		if transport.TLSClientConfig == nil {
-			transport.TLSClientConfig = &tls.Config{}
+		cfg.tlsConfig().RootCAs = pool
+		return nil
+	})
+}
+
+// WithClientCertificate reads a PEM-encoded certificate and private key and presents them to the
+// API for mutual TLS. This option is overridden if [WithHTTPClient] is set after this option.
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
 		}
-		transport.TLSClientConfig.InsecureSkipVerify = true
-		cfg.httpClient.Transport = transport
+		tlsConfig := cfg.tlsConfig()
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
 		return nil
 	})
 }
 
+// transport returns the transport of the HTTP client, creating the client and cloning the default
+// transport as needed so that transport options compose with each other and with [WithTimeout].
+func (cfg *clientConfig) transport() *http.Transport {
+	client := cfg.ownHTTPClient()
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	client.Transport = transport
+	return transport
+}
+
+// ownHTTPClient returns the HTTP client, creating it as needed, or copying it and its transport
+// if it was passed to [WithHTTPClient] so that the caller's client isn't modified.
+func (cfg *clientConfig) ownHTTPClient() *http.Client {
+	if cfg.httpClient == nil {
+		cfg.httpClient = defaultHTTPClient()
+	}
+	if cfg.callerHTTPClient {
+		client := *cfg.httpClient
+		if transport, ok := client.Transport.(*http.Transport); ok && transport != nil {
+			client.Transport = transport.Clone()
+		}
+		cfg.httpClient = &client
+		cfg.callerHTTPClient = false
+	}
+	return cfg.httpClient
+}
+
+// hasRootCAs reports whether the certificate authorities used to verify the API's TLS
+// certificate are configured, by an option or by the client passed to [WithHTTPClient]. Clients
+// with a transport other than [http.Transport] are assumed to configure them.
+func (cfg *clientConfig) hasRootCAs() bool {
+	if cfg.httpClient == nil || cfg.httpClient.Transport == nil {
+		return false
+	}
+	transport, ok := cfg.httpClient.Transport.(*http.Transport)
+	if !ok {
+		return true
+	}
+	return transport != nil && transport.TLSClientConfig != nil &&
+		transport.TLSClientConfig.RootCAs != nil
+}
+
+// tlsConfig returns the TLS configuration of the HTTP client's transport, creating it as needed.
+func (cfg *clientConfig) tlsConfig() *tls.Config {
+	transport := cfg.transport()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	return transport.TLSClientConfig
+}
+
+// loadCACertFile reads a pool of PEM-encoded certificate authorities from path.
+func loadCACertFile(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %q", path)
+	}
+	return pool, nil
+}
+
 // WithUserAgent sets the user agent string for the client.
 func WithUserAgent(userAgent string) ClientOption {
 	return clientOptionFunc(func(cfg *clientConfig) error {
@@ -179,11 +288,29 @@ type Client struct {
 	// Oxide API authentication token.
 	token string
 
+	// Source of API tokens, used instead of token when set.
+	tokenSource *cachedTokenSource
+
 	// HTTP client to make API requests.
 	client *http.Client
 
 	// The user agent string to add to every API request.
 	userAgent string
+
+	// Policy used to retry transient failures. Requests aren't retried when nil.
+	retryPolicy *RetryPolicy
+
+	// Middleware wrapped around every API call, outermost first.
+	middleware []Middleware
+
+	// Logger used to emit a record for every API call. Nothing is logged when nil.
+	logger *slog.Logger
+
+	// API version sent in the API-Version header. The generated version is used when empty.
+	apiVersion string
+
+	// Checks the API version before the first API call in strict mode. Nothing is checked when nil.
+	apiVersionGate *apiVersionGate
 }
 
 // Host returns the base URL of the Oxide API.
@@ -192,8 +319,9 @@ func (c *Client) Host() string {
 }
 
 type authCredentials struct {
-	host  string
-	token string
+	host   string
+	token  string
+	caFile string
 }
 
 // NewClient creates an Oxide API client. When called with no options, it reads configuration from
@@ -227,6 +355,12 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		(cfg.hostSetFromOption || cfg.tokenSetFromOption) {
 		return nil, errors.New("cannot authenticate with both a profile and host/token")
 	}
+	if cfg.tokenSource != nil &&
+		(cfg.tokenSetFromOption || cfg.profileSetFromOption || cfg.defaultProfileSetFromOption) {
+		return nil, errors.New(
+			"cannot authenticate with both a token source and a token or profile",
+		)
+	}
 	if cfg.profileSetFromOption && cfg.defaultProfileSetFromOption {
 		return nil, errors.New(
 			"cannot authenticate with both default profile and a defined profile",
@@ -254,11 +388,11 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 	if cfg.profile != "" || cfg.useDefaultProfile {
 		configDir := cfg.configDir
 		if configDir == "" {
-			homeDir, err := os.UserHomeDir()
+			var err error
+			configDir, err = defaultConfigDirPath()
 			if err != nil {
-				return nil, fmt.Errorf("unable to find user's home directory: %w", err)
+				return nil, err
 			}
-			configDir = filepath.Join(homeDir, defaultConfigDir)
 		}
 
 		authCredentials, err := getProfile(configDir, cfg.profile, cfg.useDefaultProfile)
@@ -268,6 +402,19 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 
 		cfg.host = authCredentials.host
 		cfg.token = authCredentials.token
+
+		// Relative CA file paths are relative to the configuration directory. The certificate
+		// authorities configured by options take precedence.
+		if caFile := authCredentials.caFile; caFile != "" && !cfg.hasRootCAs() {
+			if !filepath.IsAbs(caFile) {
+				caFile = filepath.Join(configDir, caFile)
+			}
+			pool, err := loadCACertFile(caFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to load CA file for profile: %w", err)
+			}
+			cfg.tlsConfig().RootCAs = pool
+		}
 	}
 
 	errs := make([]error, 0)
@@ -276,7 +423,7 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		errs = append(errs, fmt.Errorf("failed parsing host address: %w", err))
 	}
 
-	if cfg.token == "" {
+	if cfg.token == "" && cfg.tokenSource == nil {
 		errs = append(errs, errors.New("token is required"))
 	}
 
@@ -285,16 +432,33 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		return nil, fmt.Errorf("invalid client configuration:\n%w", err)
 	}
 
-	client := &Client{
-		token:     cfg.token,
-		host:      host,
-		userAgent: cfg.userAgent,
-		client:    cfg.httpClient,
+	client := cfg.newClient(host)
+	client.token = cfg.token
+	if cfg.strictAPIVersion {
+		client.apiVersionGate = &apiVersionGate{}
+	}
+	if cfg.tokenSource != nil {
+		client.token = ""
+		client.tokenSource = &cachedTokenSource{source: cfg.tokenSource}
 	}
 
 	return client, nil
 }
 
+// newClient returns an unauthenticated client of the API at host that sends requests as configured
+// by cfg.
+func (cfg *clientConfig) newClient(host string) *Client {
+	return &Client{
+		host:        host,
+		userAgent:   cfg.userAgent,
+		client:      cfg.httpClient,
+		retryPolicy: cfg.retryPolicy,
+		middleware:  cfg.middleware,
+		logger:      cfg.logger,
+		apiVersion:  cfg.apiVersion,
+	}
+}
+
 // defaultHTTPClient builds and returns the default HTTP client.
 func defaultHTTPClient() *http.Client {
 	return &http.Client{
@@ -307,6 +471,15 @@ func defaultUserAgent() string {
 	return fmt.Sprintf("oxide.go/%s", sdkVersion)
 }
 
+// defaultConfigDirPath returns the directory used by the Oxide CLI for configuration files.
+func defaultConfigDirPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find user's home directory: %w", err)
+	}
+	return filepath.Join(homeDir, defaultConfigDir), nil
+}
+
 // getProfile determines the path of the user's credentials file and returns the host and token for
 // the requested profile.
 func getProfile(
@@ -371,7 +544,7 @@ func parseCredentialsFile(credentialsPath, profileName string) (*authCredentials
 
 	profile, ok := credentialsFile.Get("profile." + profileName).(*toml.Tree)
 	if !ok {
-		return nil, errors.New("profile not found")
+		return nil, ErrProfileNotFound
 	}
 
 	var hostTokenErr error
@@ -385,7 +558,9 @@ func parseCredentialsFile(credentialsPath, profileName string) (*authCredentials
 		hostTokenErr = errors.Join(errors.New("host not found"))
 	}
 
-	return &authCredentials{host: host, token: token}, hostTokenErr
+	caFile, _ := profile.Get("ca_file").(string)
+
+	return &authCredentials{host: host, token: token, caFile: caFile}, hostTokenErr
 }
 
 // parseBaseURL parses the base URL from the host URL.
@@ -427,13 +602,40 @@ func (c *Client) buildRequest(
 		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
+	// Requests built from in-memory buffers can already be rewound. Make seekable bodies, such as
+	// files, rewindable too so that retries resend the full body.
+	if seeker, ok := body.(io.ReadSeeker); ok && req.GetBody == nil {
+		if getBody, err := seekableBody(seeker); err == nil {
+			req.Body, _ = getBody()
+			req.GetBody = getBody
+		}
+	}
+
 	if c.userAgent != "" {
 		req.Header.Set("User-Agent", c.userAgent)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	req.Header.Set("API-Version", openAPIVersion)
+	token := c.token
+	if c.tokenSource != nil {
+		token, err = c.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("API-Version", c.APIVersion())
+
+	// Record the operation before expanding the URL, which escapes the parameters in place.
+	opID, _ := ctx.Value(operationIDKey{}).(string)
+	op := Operation{
+		ID:           opID,
+		Method:       method,
+		PathTemplate: pathTemplate(req.URL.Path),
+		Params:       maps.Clone(params),
+		Query:        maps.Clone(queries),
+	}
+	req = req.WithContext(context.WithValue(ctx, operationKey{}, op))
 
 	// Add the parameters to the url.
 	if err := expandURL(req.URL, params); err != nil {
@@ -446,6 +648,52 @@ func (c *Client) buildRequest(
 	return req, nil
 }
 
+// seekableBody returns a GetBody function for a body read from a seeker. Every copy reads the body
+// from its current offset to its end through its own [io.SectionReader], so that reading a copy,
+// e.g. to log it, doesn't consume the body that is sent, and the transport can still be reading a
+// copy while a retry reads the next one.
+func seekableBody(r io.ReadSeeker) (func() (io.ReadCloser, error), error) {
+	offset, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	ra, ok := r.(io.ReaderAt)
+	if !ok {
+		ra = &seekerAt{r: r}
+	}
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(io.NewSectionReader(ra, offset, end-offset)), nil
+	}, nil
+}
+
+// seekerAt implements io.ReaderAt for a seeker. Since every read seeks first, the reads hold a
+// lock.
+type seekerAt struct {
+	mu sync.Mutex
+	r  io.ReadSeeker
+}
+
+func (s *seekerAt) ReadAt(p []byte, off int64) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.r.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(s.r, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
 type Request struct {
 	Method string
 	Path   string
@@ -462,5 +710,5 @@ func (c *Client) MakeRequest(ctx context.Context, req Request) (*http.Response,
 	if err != nil {
 		return nil, fmt.Errorf("building request failed: %v", err)
 	}
-	return c.client.Do(httpReq)
+	return c.do(httpReq)
 }
@@ -7,16 +7,25 @@ package oxide
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -175,6 +184,74 @@ func Test_buildRequest(t *testing.T) {
 	}
 }
 
+func Test_buildRequest_seekableBody(t *testing.T) {
+	c, err := NewClient(WithHost("http://localhost:3000"), WithToken("foo"))
+	require.NoError(t, err)
+
+	body := io.NewSectionReader(strings.NewReader("xxpayload"), 0, 9)
+	_, err = body.Seek(2, io.SeekStart)
+	require.NoError(t, err)
+	req, err := c.buildRequest(context.TODO(), body, http.MethodPut, "http://localhost:3000/v1/x",
+		map[string]string{}, map[string]string{})
+	require.NoError(t, err)
+
+	// Reading a copy of the body, e.g. to log it, doesn't consume the body that is sent.
+	copied, err := req.GetBody()
+	require.NoError(t, err)
+	data, err := io.ReadAll(copied)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+
+	data, err = io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+
+	// Copies taken after the body was sent start over too.
+	copied, err = req.GetBody()
+	require.NoError(t, err)
+	data, err = io.ReadAll(copied)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+}
+
+func Test_buildRequest_seekableBody_copies(t *testing.T) {
+	c, err := NewClient(WithHost("http://localhost:3000"), WithToken("foo"))
+	require.NoError(t, err)
+
+	// A seeker that isn't an io.ReaderAt, like the reader of repository uploads.
+	body := struct{ io.ReadSeeker }{strings.NewReader("xxpayload")}
+	_, err = body.Seek(2, io.SeekStart)
+	require.NoError(t, err)
+	req, err := c.buildRequest(context.TODO(), body, http.MethodPut, "http://localhost:3000/v1/x",
+		map[string]string{}, map[string]string{})
+	require.NoError(t, err)
+
+	// A retry can read a new copy while the transport is still reading the previous one.
+	first := make([]byte, 3)
+	_, err = io.ReadFull(req.Body, first)
+	require.NoError(t, err)
+	copied, err := req.GetBody()
+	require.NoError(t, err)
+	data, err := io.ReadAll(copied)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(data))
+	rest, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(first)+string(rest))
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			copied, err := req.GetBody()
+			assert.NoError(t, err)
+			data, err := io.ReadAll(iotest.OneByteReader(copied))
+			assert.NoError(t, err)
+			assert.Equal(t, "payload", string(data))
+		})
+	}
+	wg.Wait()
+}
+
 func Test_NewClient(t *testing.T) {
 	tt := map[string]struct {
 		options        func(string) []ClientOption
@@ -678,3 +755,188 @@ func Test_NewClient_HTTPOptions(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewClient_TLSOptions(t *testing.T) {
+	// The server requires a client certificate only when one is configured in the test.
+	server := httptest.NewUnstartedServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"name":"my-project"}`))
+		}),
+	)
+	clientCertFile, clientKeyFile, clientPool := writeTestCertificate(t)
+	server.TLS = &tls.Config{ClientCAs: clientPool, ClientAuth: tls.VerifyClientCertIfGiven}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}), 0o600))
+	serverPool := x509.NewCertPool()
+	serverPool.AddCert(server.Certificate())
+
+	tests := []struct {
+		name          string
+		options       []ClientOption
+		expectedError string
+	}{
+		{
+			name:          "untrusted CA",
+			expectedError: "certificate signed by unknown authority",
+		},
+		{
+			name:    "root CAs",
+			options: []ClientOption{WithRootCAs(serverPool)},
+		},
+		{
+			name:    "CA file then timeout",
+			options: []ClientOption{WithCACertFile(caFile), WithTimeout(30 * time.Second)},
+		},
+		{
+			name: "timeout then CA file and client certificate",
+			options: []ClientOption{
+				WithTimeout(30 * time.Second),
+				WithCACertFile(caFile),
+				WithClientCertificate(clientCertFile, clientKeyFile),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]ClientOption{WithHost(server.URL), WithToken("foo")}, tc.options...)
+			client, err := NewClient(opts...)
+			require.NoError(t, err)
+
+			_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("client certificate is presented", func(t *testing.T) {
+		var verified bool
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			verified = len(r.TLS.VerifiedChains) > 0
+			w.Write([]byte(`{"name":"my-project"}`))
+		})
+
+		client, err := NewClient(
+			WithHost(server.URL),
+			WithToken("foo"),
+			WithCACertFile(caFile),
+			WithClientCertificate(clientCertFile, clientKeyFile),
+		)
+		require.NoError(t, err)
+
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+		require.NoError(t, err)
+		assert.True(t, verified)
+	})
+
+	t.Run("profile CA file", func(t *testing.T) {
+		credentials := "[profile.rack]\nhost = \"" + server.URL + "\"\n" +
+			"token = \"foo\"\nca_file = \"ca.pem\"\n"
+		require.NoError(
+			t,
+			os.WriteFile(filepath.Join(dir, "credentials.toml"), []byte(credentials), 0o600),
+		)
+
+		client, err := NewClient(WithConfigDir(dir), WithProfile("rack"))
+		require.NoError(t, err)
+
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+		require.NoError(t, err)
+
+		// The profile CA file is applied to a copy of the caller's client.
+		httpClient := &http.Client{}
+		client, err = NewClient(WithConfigDir(dir), WithProfile("rack"), WithHTTPClient(httpClient))
+		require.NoError(t, err)
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+		require.NoError(t, err)
+		assert.Nil(t, httpClient.Transport)
+	})
+
+	t.Run("explicit root CAs take precedence over the profile CA file", func(t *testing.T) {
+		// The profile trusts another CA than the one of the server.
+		credentials := "[profile.other]\nhost = \"" + server.URL + "\"\n" +
+			"token = \"foo\"\nca_file = \"" + clientCertFile + "\"\n"
+		require.NoError(
+			t,
+			os.WriteFile(filepath.Join(dir, "credentials.toml"), []byte(credentials), 0o600),
+		)
+
+		client, err := NewClient(WithConfigDir(dir), WithProfile("other"))
+		require.NoError(t, err)
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+		assert.ErrorContains(t, err, "certificate signed by unknown authority")
+
+		for _, opt := range []ClientOption{WithRootCAs(serverPool), WithCACertFile(caFile)} {
+			client, err := NewClient(WithConfigDir(dir), WithProfile("other"), opt)
+			require.NoError(t, err)
+			_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		_, err := NewClient(
+			WithHost(server.URL),
+			WithToken("foo"),
+			WithCACertFile(clientKeyFile),
+			WithClientCertificate(caFile, caFile),
+			WithRootCAs(nil),
+		)
+		assert.ErrorContains(t, err, "no certificates found in")
+		assert.ErrorContains(t, err, "failed to load client certificate")
+		assert.ErrorContains(t, err, "root CA pool must not be nil")
+	})
+}
+
+// writeTestCertificate writes a self-signed client certificate and its key to temporary files and
+// returns their paths along with a pool that trusts the certificate.
+func writeTestCertificate(t *testing.T) (string, string, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "oxide.go test client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	require.NoError(t, os.WriteFile(
+		certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		0o600,
+	))
+	require.NoError(t, os.WriteFile(
+		keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		0o600,
+	))
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return certFile, keyFile, pool
+}
@@ -0,0 +1,246 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// redactedValue replaces the value of secret fields in logged bodies.
+	redactedValue = "[REDACTED]"
+
+	// maxLoggedBodySize is the maximum number of body bytes included in a debug log record.
+	maxLoggedBodySize = 64 << 10
+)
+
+// secretFields lists the JSON fields whose values are always redacted from logged bodies. They
+// cover fields such as WebhookCreate.Secrets, ScimClientBearerTokenValue.BearerToken and
+// CertificateCreate.Key.
+var secretFields = map[string]bool{
+	"access_token": true,
+	"bearer_token": true,
+	"key":          true,
+	"password":     true,
+	"private_key":  true,
+	"secret":       true,
+	"secrets":      true,
+	"token":        true,
+}
+
+// WithLogger sets a structured logger that [Client] uses to emit one record per API call, with the
+// operation ID, method, expanded path, status, latency, Nexus request ID, and the number of bytes
+// in the response body.
+//
+// When the logger is enabled for [slog.LevelDebug], request and response bodies are logged too.
+// Request bodies that aren't JSON, such as uploads, are summarized by their length without being
+// read.
+// The Authorization header is never logged and secret fields in bodies are always redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		if logger == nil {
+			return errors.New("logger must not be nil")
+		}
+		cfg.logger = logger
+		return nil
+	})
+}
+
+// loggingDoer returns a [Doer] that logs every request sent through next.
+func loggingDoer(logger *slog.Logger, next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		op, _ := OperationFromContext(ctx)
+		attrs := []slog.Attr{
+			slog.String("operation", op.ID),
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+		}
+
+		debug := logger.Enabled(ctx, slog.LevelDebug)
+		if debug {
+			logRequestBody(logger, req)
+		}
+
+		start := time.Now()
+		resp, err := next.Do(req)
+		if err != nil {
+			attrs = append(
+				attrs,
+				slog.Duration("latency", time.Since(start)),
+				slog.String("error", err.Error()),
+			)
+			logger.LogAttrs(ctx, slog.LevelError, "oxide API request failed", attrs...)
+			return resp, err
+		}
+
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		requestID := resp.Header.Get("X-Request-Id")
+
+		// Read error responses right away so that the Nexus request ID can be logged.
+		if resp.StatusCode >= http.StatusBadRequest {
+			var raw string
+			if herr, ok := peekHTTPError(resp).(*HTTPError); ok {
+				raw = herr.RawBody
+				if herr.ErrorResponse != nil && herr.ErrorResponse.RequestId != "" {
+					requestID = herr.ErrorResponse.RequestId
+				}
+			}
+			if debug {
+				logBody(logger, req, "response body", io.NopCloser(strings.NewReader(raw)))
+			}
+			attrs = append(
+				attrs,
+				slog.Duration("latency", time.Since(start)),
+				slog.String("request_id", requestID),
+				slog.Int("bytes", len(raw)),
+			)
+			logger.LogAttrs(ctx, slog.LevelInfo, "oxide API request", attrs...)
+			return resp, nil
+		}
+
+		// Successful response bodies are streamed to the caller, so the record is emitted once the
+		// body is closed and its size is known.
+		attrs = append(attrs, slog.String("request_id", requestID))
+		body := &loggedBody{
+			ReadCloser: resp.Body,
+			debug:      debug,
+			emit: func(n int64, dump []byte) {
+				if debug {
+					logBody(logger, req, "response body", io.NopCloser(bytes.NewReader(dump)))
+				}
+				attrs = append(
+					attrs,
+					slog.Duration("latency", time.Since(start)),
+					slog.Int64("bytes", n),
+				)
+				logger.LogAttrs(ctx, slog.LevelInfo, "oxide API request", attrs...)
+			},
+		}
+		resp.Body = body
+		return resp, nil
+	})
+}
+
+// loggedBody counts the bytes read from a response body and calls emit once it's closed.
+type loggedBody struct {
+	io.ReadCloser
+	debug bool
+	emit  func(n int64, dump []byte)
+
+	n    int64
+	dump bytes.Buffer
+	once sync.Once
+}
+
+// Read implements io.Reader.
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if b.debug && b.dump.Len() < maxLoggedBodySize {
+		b.dump.Write(p[:min(n, maxLoggedBodySize-b.dump.Len())])
+	}
+	return n, err
+}
+
+// Close implements io.Closer.
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.emit(b.n, b.dump.Bytes()) })
+	return err
+}
+
+// logRequestBody logs the body of a request at debug level. Only JSON bodies are read, from a copy
+// returned by GetBody: other bodies, such as uploads, may be large or costly to read, and they are
+// summarized by their length instead.
+func logRequestBody(logger *slog.Logger, req *http.Request) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		if req.ContentLength > 0 {
+			op, _ := OperationFromContext(req.Context())
+			logger.LogAttrs(
+				req.Context(),
+				slog.LevelDebug,
+				"oxide API request body",
+				slog.String("operation", op.ID),
+				slog.String("body", fmt.Sprintf("<%d bytes of non-JSON data>", req.ContentLength)),
+			)
+		}
+		return
+	}
+	if req.GetBody == nil {
+		return
+	}
+	if body, err := req.GetBody(); err == nil {
+		logBody(logger, req, "request body", body)
+	}
+}
+
+// logBody logs a request or response body at debug level with secret fields redacted.
+func logBody(logger *slog.Logger, req *http.Request, msg string, body io.ReadCloser) {
+	defer body.Close()
+	raw, err := io.ReadAll(io.LimitReader(body, maxLoggedBodySize))
+	if err != nil || len(raw) == 0 {
+		return
+	}
+
+	op, _ := OperationFromContext(req.Context())
+	logger.LogAttrs(
+		req.Context(),
+		slog.LevelDebug,
+		"oxide API "+msg,
+		slog.String("operation", op.ID),
+		slog.String("body", redactBody(raw)),
+	)
+}
+
+// redactBody returns a loggable representation of a JSON body with the values of secret fields
+// replaced. Bodies that aren't JSON, such as binary uploads, are summarized instead.
+func redactBody(raw []byte) string {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON data>", len(raw))
+	}
+
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of unloggable data>", len(raw))
+	}
+	return string(redacted)
+}
+
+// redactValue recursively replaces the values of secret fields in a decoded JSON value.
+func redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, field := range val {
+			if secretFields[strings.ToLower(k)] {
+				val[k] = redactedValue
+				continue
+			}
+			val[k] = redactValue(field)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,180 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logRecords decodes the JSON log records written to buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func Test_WithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("X-Request-Id", "req-1")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"b9b3b1c4-4d3f-4b46-8b8e-8f1f1bcbf1a1","name":"hook",` +
+				`"secrets":[{"id":"s1"}],"endpoint":"https://example.com"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"request_id":"req-2","error_code":"ObjectNotFound","message":"nope"}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("info level logs one record per request", func(t *testing.T) {
+		var buf bytes.Buffer
+		client, err := NewClient(
+			WithHost(server.URL),
+			WithToken("super-secret-token"),
+			WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		)
+		require.NoError(t, err)
+
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "missing"})
+		assert.ErrorIs(t, err, ErrObjectNotFound)
+
+		records := logRecords(t, &buf)
+		require.Len(t, records, 1)
+		assert.Equal(t, "project_view", records[0]["operation"])
+		assert.Equal(t, http.MethodGet, records[0]["method"])
+		assert.Equal(t, "/v1/projects/missing", records[0]["path"])
+		assert.Equal(t, float64(http.StatusNotFound), records[0]["status"])
+		assert.Equal(t, "req-2", records[0]["request_id"])
+		assert.Contains(t, records[0], "latency")
+		assert.Contains(t, records[0], "bytes")
+		assert.NotContains(t, buf.String(), "super-secret-token")
+	})
+
+	t.Run("debug level dumps redacted bodies", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		client, err := NewClient(
+			WithHost(server.URL),
+			WithToken("super-secret-token"),
+			WithLogger(logger),
+		)
+		require.NoError(t, err)
+
+		_, err = client.WebhookReceiverCreate(context.Background(), WebhookReceiverCreateParams{
+			Body: &WebhookCreate{
+				Name:        "hook",
+				Description: "test",
+				Endpoint:    "https://example.com",
+				Secrets:     []string{"hunter2"},
+			},
+		})
+		require.NoError(t, err)
+
+		records := logRecords(t, &buf)
+		require.Len(t, records, 3)
+		assert.Equal(t, "oxide API request body", records[0]["msg"])
+		assert.Contains(t, records[0]["body"], `"secrets":"[REDACTED]"`)
+		assert.Equal(t, "oxide API response body", records[1]["msg"])
+		assert.Equal(t, "oxide API request", records[2]["msg"])
+		assert.Equal(t, "req-1", records[2]["request_id"])
+		assert.Equal(t, float64(http.StatusCreated), records[2]["status"])
+		assert.NotContains(t, buf.String(), "hunter2")
+		assert.NotContains(t, buf.String(), "super-secret-token")
+	})
+}
+
+func Test_redactBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "redacts nested secrets",
+			body:     `{"name":"scim","token":{"bearer_token":"abc","id":"1"},"items":[{"password":"p"}]}`,
+			expected: `{"items":[{"password":"[REDACTED]"}],"name":"scim","token":"[REDACTED]"}`,
+		},
+		{
+			name:     "preserves large numbers",
+			body:     `{"size":18446744073709551615}`,
+			expected: `{"size":18446744073709551615}`,
+		},
+		{
+			name:     "summarizes binary data",
+			body:     "\x00\x01\x02",
+			expected: "<3 bytes of non-JSON data>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, redactBody([]byte(tc.body)))
+		})
+	}
+}
+
+func Test_WithLogger_seekableBodies(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	t.Run("sends the full JSON body after logging it", func(t *testing.T) {
+		buf.Reset()
+		server, _, bodies := flakyServer(t, 0, http.StatusOK, "")
+		client, err := NewClient(WithHost(server.URL), WithToken("foo"), WithLogger(logger))
+		require.NoError(t, err)
+
+		body := `{"name":"my-project"}`
+		resp, err := client.MakeRequest(ctx, Request{
+			Method: http.MethodPut,
+			Path:   "/v1/projects/my-project",
+			Body:   io.NewSectionReader(strings.NewReader(body), 0, int64(len(body))),
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, []string{body}, *bodies)
+		assert.Equal(t, body, logRecords(t, &buf)[0]["body"])
+	})
+
+	t.Run("uploads without reading the body", func(t *testing.T) {
+		buf.Reset()
+		path, sum := testRepositoryFile(t)
+		client, server := newFakeUpdateRepositoryServer(t, WithLogger(logger))
+
+		result, err := client.UploadUpdateRepository(ctx, path, &UploadUpdateRepositoryOptions{
+			SystemVersion: "18.0.0",
+			Wait:          []WaitOption{WithWaitBackoff(time.Millisecond, time.Millisecond)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, sum, result.Repo.Hash)
+		assert.Equal(t, []int64{1 << 20}, server.lengths)
+		records := logRecords(t, &buf)
+		assert.Equal(t, "oxide API request body", records[0]["msg"])
+		assert.Equal(t, "<1048576 bytes of non-JSON data>", records[0]["body"])
+	})
+}
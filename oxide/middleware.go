@@ -0,0 +1,123 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Doer sends an HTTP request and returns an HTTP response. [http.Client] implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as a [Doer].
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the [Doer] that sends API requests. A middleware can inspect or modify the
+// request before calling next, and inspect or replace the response returned by next. Use
+// [OperationFromContext] with the request context to learn which API operation is being called.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds a middleware around every API call made by [Client]. Unlike other options,
+// passing WithMiddleware multiple times adds every middleware to the chain. The first middleware
+// is the outermost one, so it sees the request first and the response last.
+//
+// Middlewares wrap the whole call, including any retries made according to [WithRetryPolicy].
+func WithMiddleware(middleware Middleware) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		if middleware == nil {
+			return errors.New("middleware must not be nil")
+		}
+		cfg.middleware = append(cfg.middleware, middleware)
+		return nil
+	})
+}
+
+// Operation describes the API operation a request is made for.
+type Operation struct {
+	// ID is the OpenAPI operation ID (e.g., "instance_view"). It's empty for requests made with
+	// [Client.MakeRequest].
+	ID string
+
+	// Method is the HTTP method of the operation.
+	Method string
+
+	// PathTemplate is the unexpanded path of the operation (e.g., "/v1/instances/{instance}").
+	PathTemplate string
+
+	// Params holds the path parameters used to expand PathTemplate.
+	Params map[string]string
+
+	// Query holds the query parameters of the request. Empty values are omitted from the request.
+	Query map[string]string
+}
+
+// operationIDKey is the context key used to pass an operation ID to buildRequest.
+type operationIDKey struct{}
+
+// operationKey is the context key used to store the [Operation] of a request.
+type operationKey struct{}
+
+// withOperationID returns a copy of ctx that carries the ID of the operation being called.
+func withOperationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, operationIDKey{}, id)
+}
+
+// OperationFromContext returns the [Operation] stored in the context of a request sent by
+// [Client], if any. Middlewares can call it with the request context.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// do sends an HTTP request built by buildRequest through the client's middleware chain, once the
+// API version has been checked in strict mode.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.apiVersionGate != nil {
+		if err := c.apiVersionGate.check(req.Context(), c); err != nil {
+			return nil, err
+		}
+	}
+	return c.chain().Do(req)
+}
+
+// chain returns the client's middleware chain. Logging happens inside the middleware chain so that
+// records reflect the requests actually sent.
+func (c *Client) chain() Doer {
+	var next Doer = DoerFunc(c.send)
+	if c.logger != nil {
+		next = loggingDoer(c.logger, next)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+	return next
+}
+
+// send sends an HTTP request, retrying transient failures when the client has a retry policy.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	var (
+		resp *http.Response
+		err  error
+	)
+	if c.retryPolicy == nil {
+		resp, err = c.client.Do(req)
+	} else {
+		resp, err = c.retryPolicy.do(c.client, req)
+	}
+
+	if err == nil && c.tokenSource != nil {
+		c.tokenSource.invalidateOnUnauthenticated(req, resp)
+	}
+	return resp, err
+}
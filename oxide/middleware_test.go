@@ -0,0 +1,136 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithMiddleware(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Policy")
+		w.Write([]byte(`{"name":"my-instance"}`))
+	}))
+	defer server.Close()
+
+	var (
+		calls []string
+		ops   []Operation
+	)
+	record := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				op, ok := OperationFromContext(req.Context())
+				require.True(t, ok)
+				ops = append(ops, op)
+				req.Header.Set("X-Policy", name)
+				resp, err := next.Do(req)
+				calls = append(calls, name+" after")
+				return resp, err
+			})
+		}
+	}
+
+	client, err := NewClient(
+		WithHost(server.URL),
+		WithToken("test-token"),
+		WithMiddleware(record("outer")),
+		WithMiddleware(record("inner")),
+	)
+	require.NoError(t, err)
+
+	instance, err := client.InstanceView(context.Background(), InstanceViewParams{
+		Instance: "my-instance",
+		Project:  "my-project",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Name("my-instance"), instance.Name)
+
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+	assert.Equal(t, "inner", gotHeader)
+	require.Len(t, ops, 2)
+	assert.Equal(t, Operation{
+		ID:           "instance_view",
+		Method:       http.MethodGet,
+		PathTemplate: "/v1/instances/{instance}",
+		Params:       map[string]string{"instance": "my-instance"},
+		Query:        map[string]string{"project": "my-project"},
+	}, ops[0])
+}
+
+func Test_WithMiddleware_shortCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	}))
+	defer server.Close()
+
+	errDenied := errors.New("denied by policy")
+	client, err := NewClient(
+		WithHost(server.URL),
+		WithToken("test-token"),
+		WithMiddleware(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				if op, _ := OperationFromContext(req.Context()); op.Method == http.MethodDelete {
+					return nil, errDenied
+				}
+				return next.Do(req)
+			})
+		}),
+	)
+	require.NoError(t, err)
+
+	err = client.ProjectDelete(context.Background(), ProjectDeleteParams{Project: "prod"})
+	assert.ErrorContains(t, err, errDenied.Error())
+}
+
+func Test_WithMiddleware_nil(t *testing.T) {
+	_, err := NewClient(
+		WithHost("http://localhost"),
+		WithToken("test-token"),
+		WithMiddleware(nil),
+	)
+	assert.ErrorContains(t, err, "middleware must not be nil")
+}
+
+func Test_OperationFromContext_makeRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var got Operation
+	client, err := NewClient(
+		WithHost(server.URL),
+		WithToken("test-token"),
+		WithMiddleware(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				got, _ = OperationFromContext(req.Context())
+				return next.Do(req)
+			})
+		}),
+	)
+	require.NoError(t, err)
+
+	resp, err := client.MakeRequest(context.Background(), Request{
+		Method: http.MethodGet,
+		Path:   "/v1/disks/{{.disk}}",
+		Params: map[string]string{"disk": "my disk"},
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "", got.ID)
+	assert.Equal(t, "/v1/disks/{disk}", got.PathTemplate)
+	assert.Equal(t, map[string]string{"disk": "my disk"}, got.Params)
+}
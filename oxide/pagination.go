@@ -0,0 +1,92 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import "iter"
+
+// defaultPageSize is the number of items fetched per page by list iterators when the Limit
+// parameter is unset.
+const defaultPageSize = 100
+
+// ListCursor records the position of a list iterator so that a long listing can be resumed, e.g.
+// after a failure or in another process.
+type ListCursor struct {
+	// PageToken is the token of the page that holds the last item yielded. It's empty for the first
+	// page.
+	PageToken string
+
+	// Offset is the number of items of that page that were yielded.
+	Offset int
+}
+
+// listConfig holds the configuration of a list iterator.
+type listConfig struct {
+	cursor *ListCursor
+}
+
+// ListOption configures the iterators returned by the XxxListIter methods.
+type ListOption func(*listConfig)
+
+// WithListCursor makes a list iterator start at the position recorded in cursor, if any, and keep
+// cursor up to date as items are yielded. When the cursor is set, it takes precedence over the
+// PageToken parameter.
+func WithListCursor(cursor *ListCursor) ListOption {
+	return func(cfg *listConfig) {
+		cfg.cursor = cursor
+	}
+}
+
+// listIter returns an iterator over the items of a paginated list operation. Pages are fetched
+// lazily with fetch, starting at pageToken, with limit items per page. Iteration stops after the
+// first error.
+func listIter[T any](
+	pageToken string,
+	limit *int,
+	opts []ListOption,
+	fetch func(pageToken string, limit *int) ([]T, string, error),
+) iter.Seq2[T, error] {
+	cfg := &listConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if limit == nil {
+		limit = NewPointer(defaultPageSize)
+	}
+
+	return func(yield func(T, error) bool) {
+		token, skip := pageToken, 0
+		if cursor := cfg.cursor; cursor != nil && (cursor.PageToken != "" || cursor.Offset > 0) {
+			token, skip = cursor.PageToken, cursor.Offset
+		}
+
+		for {
+			items, nextPage, err := fetch(token, limit)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for i := skip; i < len(items); i++ {
+				if cfg.cursor != nil {
+					cfg.cursor.PageToken, cfg.cursor.Offset = token, i+1
+				}
+				if !yield(items[i], nil) {
+					return
+				}
+			}
+
+			if nextPage == "" || nextPage == token {
+				return
+			}
+			token, skip = nextPage, 0
+
+			// Move the cursor past the page that was fully yielded.
+			if cfg.cursor != nil {
+				cfg.cursor.PageToken, cfg.cursor.Offset = token, 0
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPaginatedServer returns a client for a server that lists n projects named project-0 to
+// project-<n-1>. Page tokens are the index of the first item of the page. The server fails with a
+// 500 when asked for the page that starts at failAt.
+func newPaginatedServer(t *testing.T, n int, failAt string) (*Client, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		token := r.URL.Query().Get("page_token")
+		if token != "" && token == failAt {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"request_id":"1","error_code":"Internal","message":"boom"}`))
+			return
+		}
+
+		start, _ := strconv.Atoi(token)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page := ProjectResultsPage{Items: []Project{}}
+		for i := start; i < min(start+limit, n); i++ {
+			page.Items = append(page.Items, Project{Name: Name(fmt.Sprintf("project-%d", i))})
+		}
+		if start+limit < n {
+			page.NextPage = strconv.Itoa(start + limit)
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+	require.NoError(t, err)
+	return client, &requests
+}
+
+func Test_ListIter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("streams every item", func(t *testing.T) {
+		client, requests := newPaginatedServer(t, 250, "")
+
+		var names []Name
+		for project, err := range client.ProjectListIter(ctx, ProjectListParams{}) {
+			require.NoError(t, err)
+			names = append(names, project.Name)
+		}
+		assert.Len(t, names, 250)
+		assert.Equal(t, Name("project-249"), names[249])
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("uses the limit as the page size", func(t *testing.T) {
+		client, requests := newPaginatedServer(t, 25, "")
+
+		var count int
+		params := ProjectListParams{Limit: NewPointer(10)}
+		for _, err := range client.ProjectListIter(ctx, params) {
+			require.NoError(t, err)
+			count++
+		}
+		assert.Equal(t, 25, count)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("fetches pages lazily", func(t *testing.T) {
+		client, requests := newPaginatedServer(t, 250, "")
+
+		var count int
+		for _, err := range client.ProjectListIter(ctx, ProjectListParams{}) {
+			require.NoError(t, err)
+			count++
+			if count == 150 {
+				break
+			}
+		}
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("yields fetched items before an error", func(t *testing.T) {
+		client, _ := newPaginatedServer(t, 250, "200")
+
+		var (
+			count   int
+			iterErr error
+		)
+		for _, err := range client.ProjectListIter(ctx, ProjectListParams{}) {
+			if err != nil {
+				iterErr = err
+				break
+			}
+			count++
+		}
+		assert.Equal(t, 200, count)
+		assert.ErrorIs(t, iterErr, ErrInternalError)
+	})
+
+	t.Run("resumes from a cursor", func(t *testing.T) {
+		client, _ := newPaginatedServer(t, 250, "200")
+		params := ProjectListParams{Limit: NewPointer(50)}
+
+		var (
+			cursor ListCursor
+			names  []Name
+		)
+		for project, err := range client.ProjectListIter(ctx, params, WithListCursor(&cursor)) {
+			if err != nil {
+				break
+			}
+			names = append(names, project.Name)
+			if len(names) == 120 {
+				break
+			}
+		}
+		assert.Equal(t, ListCursor{PageToken: "100", Offset: 20}, cursor)
+
+		for project, err := range client.ProjectListIter(ctx, params, WithListCursor(&cursor)) {
+			if err != nil {
+				break
+			}
+			names = append(names, project.Name)
+		}
+		assert.Len(t, names, 200)
+		assert.Equal(t, Name("project-120"), names[120])
+		assert.Equal(t, ListCursor{PageToken: "200"}, cursor)
+
+		// The failed page is fetched again once the server recovers.
+		client, _ = newPaginatedServer(t, 250, "")
+		for project, err := range client.ProjectListIter(ctx, params, WithListCursor(&cursor)) {
+			require.NoError(t, err)
+			names = append(names, project.Name)
+		}
+		assert.Len(t, names, 250)
+		for i, name := range names {
+			assert.Equal(t, Name(fmt.Sprintf("project-%d", i)), name)
+		}
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"strconv"
 )
 
@@ -19,7 +20,8 @@ import (
 //
 // ExperimentalProbeList: List instrumentation probes
 //
-// To iterate over all pages, use the `ExperimentalProbeListAllPages` method, instead.
+// To iterate over all pages, use the `ExperimentalProbeListIter` or `ExperimentalProbeListAllPages`
+// methods, instead.
 func (c *Client) ExperimentalProbeList(
 	ctx context.Context,
 	params ProbeListParams,
@@ -29,7 +31,7 @@ func (c *Client) ExperimentalProbeList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "probe_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/experimental/v1/probes"),
@@ -46,7 +48,7 @@ func (c *Client) ExperimentalProbeList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -102,6 +104,33 @@ func (c *Client) ExperimentalProbeListAllPages(
 	return allPages, nil
 }
 
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalProbeListIter: List instrumentation probes
+//
+// This method is a wrapper around the `ExperimentalProbeList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExperimentalProbeListIter(
+	ctx context.Context,
+	params ProbeListParams,
+	opts ...ListOption,
+) iter.Seq2[ProbeInfo, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]ProbeInfo, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExperimentalProbeList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
 //
 // ExperimentalProbeCreate: Create instrumentation probe
@@ -120,7 +149,7 @@ func (c *Client) ExperimentalProbeCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "probe_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/experimental/v1/probes"),
@@ -134,7 +163,7 @@ func (c *Client) ExperimentalProbeCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -171,7 +200,7 @@ func (c *Client) ExperimentalProbeView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "probe_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/experimental/v1/probes/{{.probe}}"),
@@ -187,7 +216,7 @@ func (c *Client) ExperimentalProbeView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -221,7 +250,7 @@ func (c *Client) ExperimentalProbeDelete(ctx context.Context, params ProbeDelete
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "probe_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/experimental/v1/probes/{{.probe}}"),
@@ -237,7 +266,7 @@ func (c *Client) ExperimentalProbeDelete(ctx context.Context, params ProbeDelete
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -255,7 +284,9 @@ func (c *Client) ExperimentalProbeDelete(ctx context.Context, params ProbeDelete
 //
 // ExperimentalSupportBundleList: List all support bundles
 //
-// To iterate over all pages, use the `ExperimentalSupportBundleListAllPages` method, instead.
+// To iterate over all pages, use the `ExperimentalSupportBundleListIter` or
+// `ExperimentalSupportBundleListAllPages`
+// methods, instead.
 func (c *Client) ExperimentalSupportBundleList(
 	ctx context.Context,
 	params SupportBundleListParams,
@@ -265,7 +296,7 @@ func (c *Client) ExperimentalSupportBundleList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/experimental/v1/system/support-bundles"),
@@ -281,7 +312,7 @@ func (c *Client) ExperimentalSupportBundleList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -337,6 +368,33 @@ func (c *Client) ExperimentalSupportBundleListAllPages(
 	return allPages, nil
 }
 
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalSupportBundleListIter: List all support bundles
+//
+// This method is a wrapper around the `ExperimentalSupportBundleList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExperimentalSupportBundleListIter(
+	ctx context.Context,
+	params SupportBundleListParams,
+	opts ...ListOption,
+) iter.Seq2[SupportBundleInfo, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SupportBundleInfo, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExperimentalSupportBundleList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
 //
 // ExperimentalSupportBundleCreate: Create support bundle
@@ -355,7 +413,7 @@ func (c *Client) ExperimentalSupportBundleCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/experimental/v1/system/support-bundles"),
@@ -367,7 +425,7 @@ func (c *Client) ExperimentalSupportBundleCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -404,7 +462,7 @@ func (c *Client) ExperimentalSupportBundleView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/experimental/v1/system/support-bundles/{{.bundle_id}}"),
@@ -418,7 +476,7 @@ func (c *Client) ExperimentalSupportBundleView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -461,7 +519,7 @@ func (c *Client) ExperimentalSupportBundleUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/experimental/v1/system/support-bundles/{{.bundle_id}}"),
@@ -475,7 +533,7 @@ func (c *Client) ExperimentalSupportBundleUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -515,7 +573,7 @@ func (c *Client) ExperimentalSupportBundleDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/experimental/v1/system/support-bundles/{{.bundle_id}}"),
@@ -529,7 +587,7 @@ func (c *Client) ExperimentalSupportBundleDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -555,7 +613,7 @@ func (c *Client) ExperimentalSupportBundleDownload(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_download"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/experimental/v1/system/support-bundles/{{.bundle_id}}/download"),
@@ -569,7 +627,7 @@ func (c *Client) ExperimentalSupportBundleDownload(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -595,7 +653,7 @@ func (c *Client) ExperimentalSupportBundleHead(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_head"),
 		nil,
 		"HEAD",
 		resolveRelative(c.host, "/experimental/v1/system/support-bundles/{{.bundle_id}}/download"),
@@ -609,7 +667,7 @@ func (c *Client) ExperimentalSupportBundleHead(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -635,7 +693,7 @@ func (c *Client) ExperimentalSupportBundleDownloadFile(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_download_file"),
 		nil,
 		"GET",
 		resolveRelative(
@@ -653,7 +711,7 @@ func (c *Client) ExperimentalSupportBundleDownloadFile(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -679,7 +737,7 @@ func (c *Client) ExperimentalSupportBundleHeadFile(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_head_file"),
 		nil,
 		"HEAD",
 		resolveRelative(
@@ -697,7 +755,7 @@ func (c *Client) ExperimentalSupportBundleHeadFile(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -723,7 +781,7 @@ func (c *Client) ExperimentalSupportBundleIndex(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "support_bundle_index"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/experimental/v1/system/support-bundles/{{.bundle_id}}/index"),
@@ -737,7 +795,7 @@ func (c *Client) ExperimentalSupportBundleIndex(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -760,7 +818,7 @@ func (c *Client) LoginSaml(ctx context.Context, params LoginSamlParams) error {
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "login_saml"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/login/{{.silo_name}}/saml/{{.provider_name}}"),
@@ -775,7 +833,7 @@ func (c *Client) LoginSaml(ctx context.Context, params LoginSamlParams) error {
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -793,7 +851,9 @@ func (c *Client) LoginSaml(ctx context.Context, params LoginSamlParams) error {
 //
 // ExperimentalAffinityGroupList: List affinity groups
 //
-// To iterate over all pages, use the `ExperimentalAffinityGroupListAllPages` method, instead.
+// To iterate over all pages, use the `ExperimentalAffinityGroupListIter` or
+// `ExperimentalAffinityGroupListAllPages`
+// methods, instead.
 func (c *Client) ExperimentalAffinityGroupList(
 	ctx context.Context,
 	params AffinityGroupListParams,
@@ -803,7 +863,7 @@ func (c *Client) ExperimentalAffinityGroupList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/affinity-groups"),
@@ -820,7 +880,7 @@ func (c *Client) ExperimentalAffinityGroupList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -876,6 +936,33 @@ func (c *Client) ExperimentalAffinityGroupListAllPages(
 	return allPages, nil
 }
 
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalAffinityGroupListIter: List affinity groups
+//
+// This method is a wrapper around the `ExperimentalAffinityGroupList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExperimentalAffinityGroupListIter(
+	ctx context.Context,
+	params AffinityGroupListParams,
+	opts ...ListOption,
+) iter.Seq2[AffinityGroup, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AffinityGroup, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExperimentalAffinityGroupList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
 //
 // ExperimentalAffinityGroupCreate: Create affinity group
@@ -894,7 +981,7 @@ func (c *Client) ExperimentalAffinityGroupCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/affinity-groups"),
@@ -908,7 +995,7 @@ func (c *Client) ExperimentalAffinityGroupCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -945,7 +1032,7 @@ func (c *Client) ExperimentalAffinityGroupView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/affinity-groups/{{.affinity_group}}"),
@@ -961,7 +1048,7 @@ func (c *Client) ExperimentalAffinityGroupView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1004,7 +1091,7 @@ func (c *Client) ExperimentalAffinityGroupUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/affinity-groups/{{.affinity_group}}"),
@@ -1020,7 +1107,7 @@ func (c *Client) ExperimentalAffinityGroupUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1057,7 +1144,7 @@ func (c *Client) ExperimentalAffinityGroupDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/affinity-groups/{{.affinity_group}}"),
@@ -1073,7 +1160,7 @@ func (c *Client) ExperimentalAffinityGroupDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -1091,7 +1178,9 @@ func (c *Client) ExperimentalAffinityGroupDelete(
 //
 // ExperimentalAffinityGroupMemberList: List affinity group members
 //
-// To iterate over all pages, use the `ExperimentalAffinityGroupMemberListAllPages` method, instead.
+// To iterate over all pages, use the `ExperimentalAffinityGroupMemberListIter` or
+// `ExperimentalAffinityGroupMemberListAllPages`
+// methods, instead.
 func (c *Client) ExperimentalAffinityGroupMemberList(
 	ctx context.Context,
 	params AffinityGroupMemberListParams,
@@ -1101,7 +1190,7 @@ func (c *Client) ExperimentalAffinityGroupMemberList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_member_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/affinity-groups/{{.affinity_group}}/members"),
@@ -1120,7 +1209,7 @@ func (c *Client) ExperimentalAffinityGroupMemberList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1176,6 +1265,33 @@ func (c *Client) ExperimentalAffinityGroupMemberListAllPages(
 	return allPages, nil
 }
 
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalAffinityGroupMemberListIter: List affinity group members
+//
+// This method is a wrapper around the `ExperimentalAffinityGroupMemberList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExperimentalAffinityGroupMemberListIter(
+	ctx context.Context,
+	params AffinityGroupMemberListParams,
+	opts ...ListOption,
+) iter.Seq2[AffinityGroupMember, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AffinityGroupMember, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExperimentalAffinityGroupMemberList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
 //
 // ExperimentalAffinityGroupMemberInstanceView: Fetch affinity group member
@@ -1188,7 +1304,7 @@ func (c *Client) ExperimentalAffinityGroupMemberInstanceView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_member_instance_view"),
 		nil,
 		"GET",
 		resolveRelative(
@@ -1208,7 +1324,7 @@ func (c *Client) ExperimentalAffinityGroupMemberInstanceView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1245,7 +1361,7 @@ func (c *Client) ExperimentalAffinityGroupMemberInstanceAdd(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_member_instance_add"),
 		nil,
 		"POST",
 		resolveRelative(
@@ -1265,7 +1381,7 @@ func (c *Client) ExperimentalAffinityGroupMemberInstanceAdd(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1302,7 +1418,7 @@ func (c *Client) ExperimentalAffinityGroupMemberInstanceDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "affinity_group_member_instance_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(
@@ -1322,7 +1438,7 @@ func (c *Client) ExperimentalAffinityGroupMemberInstanceDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -1338,7 +1454,8 @@ func (c *Client) ExperimentalAffinityGroupMemberInstanceDelete(
 
 // AlertClassList: List alert classes
 //
-// To iterate over all pages, use the `AlertClassListAllPages` method, instead.
+// To iterate over all pages, use the `AlertClassListIter` or `AlertClassListAllPages`
+// methods, instead.
 func (c *Client) AlertClassList(
 	ctx context.Context,
 	params AlertClassListParams,
@@ -1348,7 +1465,7 @@ func (c *Client) AlertClassList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_class_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/alert-classes"),
@@ -1364,7 +1481,7 @@ func (c *Client) AlertClassList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1418,9 +1535,35 @@ func (c *Client) AlertClassListAllPages(
 	return allPages, nil
 }
 
+// AlertClassListIter: List alert classes
+//
+// This method is a wrapper around the `AlertClassList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) AlertClassListIter(
+	ctx context.Context,
+	params AlertClassListParams,
+	opts ...ListOption,
+) iter.Seq2[AlertClass, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AlertClass, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.AlertClassList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // AlertReceiverList: List alert receivers
 //
-// To iterate over all pages, use the `AlertReceiverListAllPages` method, instead.
+// To iterate over all pages, use the `AlertReceiverListIter` or `AlertReceiverListAllPages`
+// methods, instead.
 func (c *Client) AlertReceiverList(
 	ctx context.Context,
 	params AlertReceiverListParams,
@@ -1430,7 +1573,7 @@ func (c *Client) AlertReceiverList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_receiver_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/alert-receivers"),
@@ -1446,7 +1589,7 @@ func (c *Client) AlertReceiverList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1500,6 +1643,31 @@ func (c *Client) AlertReceiverListAllPages(
 	return allPages, nil
 }
 
+// AlertReceiverListIter: List alert receivers
+//
+// This method is a wrapper around the `AlertReceiverList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) AlertReceiverListIter(
+	ctx context.Context,
+	params AlertReceiverListParams,
+	opts ...ListOption,
+) iter.Seq2[AlertReceiver, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AlertReceiver, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.AlertReceiverList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // AlertReceiverView: Fetch alert receiver
 func (c *Client) AlertReceiverView(
 	ctx context.Context,
@@ -1510,7 +1678,7 @@ func (c *Client) AlertReceiverView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_receiver_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/alert-receivers/{{.receiver}}"),
@@ -1524,7 +1692,7 @@ func (c *Client) AlertReceiverView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1556,7 +1724,7 @@ func (c *Client) AlertReceiverDelete(ctx context.Context, params AlertReceiverDe
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_receiver_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/alert-receivers/{{.receiver}}"),
@@ -1570,7 +1738,7 @@ func (c *Client) AlertReceiverDelete(ctx context.Context, params AlertReceiverDe
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -1590,7 +1758,8 @@ func (c *Client) AlertReceiverDelete(ctx context.Context, params AlertReceiverDe
 // If one or more of these
 // parameters are provided, only those which are set to "true" are included in the response.
 //
-// To iterate over all pages, use the `AlertDeliveryListAllPages` method, instead.
+// To iterate over all pages, use the `AlertDeliveryListIter` or `AlertDeliveryListAllPages`
+// methods, instead.
 func (c *Client) AlertDeliveryList(
 	ctx context.Context,
 	params AlertDeliveryListParams,
@@ -1600,7 +1769,7 @@ func (c *Client) AlertDeliveryList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_delivery_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/alert-receivers/{{.receiver}}/deliveries"),
@@ -1621,7 +1790,7 @@ func (c *Client) AlertDeliveryList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1679,6 +1848,35 @@ func (c *Client) AlertDeliveryListAllPages(
 	return allPages, nil
 }
 
+// AlertDeliveryListIter: List delivery attempts to alert receiver
+// Optional query parameters to this endpoint may be used to filter deliveries by state. If none of
+// the `failed`, `pending` or `delivered` query parameters are present, all deliveries are returned.
+// If one or more of these
+// parameters are provided, only those which are set to "true" are included in the response.
+//
+// This method is a wrapper around the `AlertDeliveryList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) AlertDeliveryListIter(
+	ctx context.Context,
+	params AlertDeliveryListParams,
+	opts ...ListOption,
+) iter.Seq2[AlertDelivery, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AlertDelivery, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.AlertDeliveryList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // AlertReceiverProbe: Send liveness probe to alert receiver
 // This endpoint synchronously sends a liveness probe to the selected alert receiver. The response
 // message describes the outcome of the probe: either the successful response (as appropriate), or
@@ -1707,7 +1905,7 @@ func (c *Client) AlertReceiverProbe(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_receiver_probe"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/alert-receivers/{{.receiver}}/probe"),
@@ -1723,7 +1921,7 @@ func (c *Client) AlertReceiverProbe(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1764,7 +1962,7 @@ func (c *Client) AlertReceiverSubscriptionAdd(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_receiver_subscription_add"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/alert-receivers/{{.receiver}}/subscriptions"),
@@ -1778,7 +1976,7 @@ func (c *Client) AlertReceiverSubscriptionAdd(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1813,7 +2011,7 @@ func (c *Client) AlertReceiverSubscriptionRemove(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_receiver_subscription_remove"),
 		nil,
 		"DELETE",
 		resolveRelative(
@@ -1831,7 +2029,7 @@ func (c *Client) AlertReceiverSubscriptionRemove(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -1855,7 +2053,7 @@ func (c *Client) AlertDeliveryResend(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "alert_delivery_resend"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/alerts/{{.alert_id}}/resend"),
@@ -1871,7 +2069,7 @@ func (c *Client) AlertDeliveryResend(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1898,7 +2096,8 @@ func (c *Client) AlertDeliveryResend(
 
 // AntiAffinityGroupList: List anti-affinity groups
 //
-// To iterate over all pages, use the `AntiAffinityGroupListAllPages` method, instead.
+// To iterate over all pages, use the `AntiAffinityGroupListIter` or `AntiAffinityGroupListAllPages`
+// methods, instead.
 func (c *Client) AntiAffinityGroupList(
 	ctx context.Context,
 	params AntiAffinityGroupListParams,
@@ -1908,7 +2107,7 @@ func (c *Client) AntiAffinityGroupList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/anti-affinity-groups"),
@@ -1925,7 +2124,7 @@ func (c *Client) AntiAffinityGroupList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -1979,6 +2178,31 @@ func (c *Client) AntiAffinityGroupListAllPages(
 	return allPages, nil
 }
 
+// AntiAffinityGroupListIter: List anti-affinity groups
+//
+// This method is a wrapper around the `AntiAffinityGroupList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) AntiAffinityGroupListIter(
+	ctx context.Context,
+	params AntiAffinityGroupListParams,
+	opts ...ListOption,
+) iter.Seq2[AntiAffinityGroup, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AntiAffinityGroup, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.AntiAffinityGroupList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // AntiAffinityGroupCreate: Create anti-affinity group
 func (c *Client) AntiAffinityGroupCreate(
 	ctx context.Context,
@@ -1995,7 +2219,7 @@ func (c *Client) AntiAffinityGroupCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/anti-affinity-groups"),
@@ -2009,7 +2233,7 @@ func (c *Client) AntiAffinityGroupCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2044,7 +2268,7 @@ func (c *Client) AntiAffinityGroupView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/anti-affinity-groups/{{.anti_affinity_group}}"),
@@ -2060,7 +2284,7 @@ func (c *Client) AntiAffinityGroupView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2101,7 +2325,7 @@ func (c *Client) AntiAffinityGroupUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/anti-affinity-groups/{{.anti_affinity_group}}"),
@@ -2117,7 +2341,7 @@ func (c *Client) AntiAffinityGroupUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2152,7 +2376,7 @@ func (c *Client) AntiAffinityGroupDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/anti-affinity-groups/{{.anti_affinity_group}}"),
@@ -2168,7 +2392,7 @@ func (c *Client) AntiAffinityGroupDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -2184,7 +2408,9 @@ func (c *Client) AntiAffinityGroupDelete(
 
 // AntiAffinityGroupMemberList: List anti-affinity group members
 //
-// To iterate over all pages, use the `AntiAffinityGroupMemberListAllPages` method, instead.
+// To iterate over all pages, use the `AntiAffinityGroupMemberListIter` or
+// `AntiAffinityGroupMemberListAllPages`
+// methods, instead.
 func (c *Client) AntiAffinityGroupMemberList(
 	ctx context.Context,
 	params AntiAffinityGroupMemberListParams,
@@ -2194,7 +2420,7 @@ func (c *Client) AntiAffinityGroupMemberList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_member_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/anti-affinity-groups/{{.anti_affinity_group}}/members"),
@@ -2213,7 +2439,7 @@ func (c *Client) AntiAffinityGroupMemberList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2267,6 +2493,31 @@ func (c *Client) AntiAffinityGroupMemberListAllPages(
 	return allPages, nil
 }
 
+// AntiAffinityGroupMemberListIter: List anti-affinity group members
+//
+// This method is a wrapper around the `AntiAffinityGroupMemberList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) AntiAffinityGroupMemberListIter(
+	ctx context.Context,
+	params AntiAffinityGroupMemberListParams,
+	opts ...ListOption,
+) iter.Seq2[AntiAffinityGroupMember, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AntiAffinityGroupMember, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.AntiAffinityGroupMemberList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // AntiAffinityGroupMemberInstanceView: Fetch anti-affinity group member
 func (c *Client) AntiAffinityGroupMemberInstanceView(
 	ctx context.Context,
@@ -2277,7 +2528,7 @@ func (c *Client) AntiAffinityGroupMemberInstanceView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_member_instance_view"),
 		nil,
 		"GET",
 		resolveRelative(
@@ -2297,7 +2548,7 @@ func (c *Client) AntiAffinityGroupMemberInstanceView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2332,7 +2583,7 @@ func (c *Client) AntiAffinityGroupMemberInstanceAdd(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_member_instance_add"),
 		nil,
 		"POST",
 		resolveRelative(
@@ -2352,7 +2603,7 @@ func (c *Client) AntiAffinityGroupMemberInstanceAdd(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2387,7 +2638,7 @@ func (c *Client) AntiAffinityGroupMemberInstanceDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "anti_affinity_group_member_instance_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(
@@ -2407,7 +2658,7 @@ func (c *Client) AntiAffinityGroupMemberInstanceDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -2425,7 +2676,7 @@ func (c *Client) AntiAffinityGroupMemberInstanceDelete(
 func (c *Client) AuthSettingsView(ctx context.Context) (*SiloAuthSettings, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "auth_settings_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/auth-settings"),
@@ -2437,7 +2688,7 @@ func (c *Client) AuthSettingsView(ctx context.Context) (*SiloAuthSettings, error
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2478,7 +2729,7 @@ func (c *Client) AuthSettingsUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "auth_settings_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/auth-settings"),
@@ -2490,7 +2741,7 @@ func (c *Client) AuthSettingsUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2520,7 +2771,8 @@ func (c *Client) AuthSettingsUpdate(
 // sorted by
 // creation date, with the most recent certificates appearing first.
 //
-// To iterate over all pages, use the `CertificateListAllPages` method, instead.
+// To iterate over all pages, use the `CertificateListIter` or `CertificateListAllPages`
+// methods, instead.
 func (c *Client) CertificateList(
 	ctx context.Context,
 	params CertificateListParams,
@@ -2530,7 +2782,7 @@ func (c *Client) CertificateList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "certificate_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/certificates"),
@@ -2546,7 +2798,7 @@ func (c *Client) CertificateList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2603,6 +2855,34 @@ func (c *Client) CertificateListAllPages(
 	return allPages, nil
 }
 
+// CertificateListIter: List certificates for external endpoints
+// Returns a list of TLS certificates used for the external API (for the current Silo).  These are
+// sorted by
+// creation date, with the most recent certificates appearing first.
+//
+// This method is a wrapper around the `CertificateList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) CertificateListIter(
+	ctx context.Context,
+	params CertificateListParams,
+	opts ...ListOption,
+) iter.Seq2[Certificate, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Certificate, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.CertificateList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // CertificateCreate: Create system-wide x.509 certificate
 // This certificate is automatically used by the Oxide Control plane to serve external connections.
 func (c *Client) CertificateCreate(
@@ -2620,7 +2900,7 @@ func (c *Client) CertificateCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "certificate_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/certificates"),
@@ -2632,7 +2912,7 @@ func (c *Client) CertificateCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2668,7 +2948,7 @@ func (c *Client) CertificateView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "certificate_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/certificates/{{.certificate}}"),
@@ -2682,7 +2962,7 @@ func (c *Client) CertificateView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2715,7 +2995,7 @@ func (c *Client) CertificateDelete(ctx context.Context, params CertificateDelete
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "certificate_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/certificates/{{.certificate}}"),
@@ -2729,7 +3009,7 @@ func (c *Client) CertificateDelete(ctx context.Context, params CertificateDelete
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -2745,14 +3025,15 @@ func (c *Client) CertificateDelete(ctx context.Context, params CertificateDelete
 
 // DiskList: List disks
 //
-// To iterate over all pages, use the `DiskListAllPages` method, instead.
+// To iterate over all pages, use the `DiskListIter` or `DiskListAllPages`
+// methods, instead.
 func (c *Client) DiskList(ctx context.Context, params DiskListParams) (*DiskResultsPage, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "disk_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/disks"),
@@ -2769,7 +3050,7 @@ func (c *Client) DiskList(ctx context.Context, params DiskListParams) (*DiskResu
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2820,6 +3101,31 @@ func (c *Client) DiskListAllPages(ctx context.Context, params DiskListParams) ([
 	return allPages, nil
 }
 
+// DiskListIter: List disks
+//
+// This method is a wrapper around the `DiskList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) DiskListIter(
+	ctx context.Context,
+	params DiskListParams,
+	opts ...ListOption,
+) iter.Seq2[Disk, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Disk, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.DiskList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // DiskCreate: Create disk
 func (c *Client) DiskCreate(ctx context.Context, params DiskCreateParams) (*Disk, error) {
 	if err := params.Validate(); err != nil {
@@ -2833,7 +3139,7 @@ func (c *Client) DiskCreate(ctx context.Context, params DiskCreateParams) (*Disk
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "disk_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/disks"),
@@ -2847,7 +3153,7 @@ func (c *Client) DiskCreate(ctx context.Context, params DiskCreateParams) (*Disk
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2879,7 +3185,7 @@ func (c *Client) DiskView(ctx context.Context, params DiskViewParams) (*Disk, er
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "disk_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/disks/{{.disk}}"),
@@ -2895,7 +3201,7 @@ func (c *Client) DiskView(ctx context.Context, params DiskViewParams) (*Disk, er
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -2927,7 +3233,7 @@ func (c *Client) DiskDelete(ctx context.Context, params DiskDeleteParams) error
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "disk_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/disks/{{.disk}}"),
@@ -2943,7 +3249,7 @@ func (c *Client) DiskDelete(ctx context.Context, params DiskDeleteParams) error
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -2970,7 +3276,7 @@ func (c *Client) DiskBulkWriteImport(ctx context.Context, params DiskBulkWriteIm
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "disk_bulk_write_import"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/disks/{{.disk}}/bulk-write"),
@@ -2986,7 +3292,7 @@ func (c *Client) DiskBulkWriteImport(ctx context.Context, params DiskBulkWriteIm
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -3011,7 +3317,7 @@ func (c *Client) DiskBulkWriteImportStart(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "disk_bulk_write_import_start"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/disks/{{.disk}}/bulk-write-start"),
@@ -3027,7 +3333,7 @@ func (c *Client) DiskBulkWriteImportStart(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -3052,7 +3358,7 @@ func (c *Client) DiskBulkWriteImportStop(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "disk_bulk_write_import_stop"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/disks/{{.disk}}/bulk-write-stop"),
@@ -3068,7 +3374,7 @@ func (c *Client) DiskBulkWriteImportStop(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -3095,7 +3401,7 @@ func (c *Client) DiskFinalizeImport(ctx context.Context, params DiskFinalizeImpo
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "disk_finalize_import"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/disks/{{.disk}}/finalize"),
@@ -3111,7 +3417,7 @@ func (c *Client) DiskFinalizeImport(ctx context.Context, params DiskFinalizeImpo
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -3127,7 +3433,8 @@ func (c *Client) DiskFinalizeImport(ctx context.Context, params DiskFinalizeImpo
 
 // ExternalSubnetList: List external subnets
 //
-// To iterate over all pages, use the `ExternalSubnetListAllPages` method, instead.
+// To iterate over all pages, use the `ExternalSubnetListIter` or `ExternalSubnetListAllPages`
+// methods, instead.
 func (c *Client) ExternalSubnetList(
 	ctx context.Context,
 	params ExternalSubnetListParams,
@@ -3137,7 +3444,7 @@ func (c *Client) ExternalSubnetList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "external_subnet_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/external-subnets"),
@@ -3154,7 +3461,7 @@ func (c *Client) ExternalSubnetList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3208,6 +3515,31 @@ func (c *Client) ExternalSubnetListAllPages(
 	return allPages, nil
 }
 
+// ExternalSubnetListIter: List external subnets
+//
+// This method is a wrapper around the `ExternalSubnetList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExternalSubnetListIter(
+	ctx context.Context,
+	params ExternalSubnetListParams,
+	opts ...ListOption,
+) iter.Seq2[ExternalSubnet, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]ExternalSubnet, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExternalSubnetList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // ExternalSubnetCreate: Create external subnet
 func (c *Client) ExternalSubnetCreate(
 	ctx context.Context,
@@ -3224,7 +3556,7 @@ func (c *Client) ExternalSubnetCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "external_subnet_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/external-subnets"),
@@ -3238,7 +3570,7 @@ func (c *Client) ExternalSubnetCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3273,7 +3605,7 @@ func (c *Client) ExternalSubnetView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "external_subnet_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/external-subnets/{{.external_subnet}}"),
@@ -3289,7 +3621,7 @@ func (c *Client) ExternalSubnetView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3330,7 +3662,7 @@ func (c *Client) ExternalSubnetUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "external_subnet_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/external-subnets/{{.external_subnet}}"),
@@ -3346,7 +3678,7 @@ func (c *Client) ExternalSubnetUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3381,7 +3713,7 @@ func (c *Client) ExternalSubnetDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "external_subnet_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/external-subnets/{{.external_subnet}}"),
@@ -3397,7 +3729,7 @@ func (c *Client) ExternalSubnetDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -3427,7 +3759,7 @@ func (c *Client) ExternalSubnetAttach(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "external_subnet_attach"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/external-subnets/{{.external_subnet}}/attach"),
@@ -3443,7 +3775,7 @@ func (c *Client) ExternalSubnetAttach(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3478,7 +3810,7 @@ func (c *Client) ExternalSubnetDetach(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "external_subnet_detach"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/external-subnets/{{.external_subnet}}/detach"),
@@ -3494,7 +3826,7 @@ func (c *Client) ExternalSubnetDetach(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3521,7 +3853,8 @@ func (c *Client) ExternalSubnetDetach(
 
 // FloatingIpList: List floating IPs
 //
-// To iterate over all pages, use the `FloatingIpListAllPages` method, instead.
+// To iterate over all pages, use the `FloatingIpListIter` or `FloatingIpListAllPages`
+// methods, instead.
 func (c *Client) FloatingIpList(
 	ctx context.Context,
 	params FloatingIpListParams,
@@ -3531,7 +3864,7 @@ func (c *Client) FloatingIpList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "floating_ip_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/floating-ips"),
@@ -3548,7 +3881,7 @@ func (c *Client) FloatingIpList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3602,6 +3935,31 @@ func (c *Client) FloatingIpListAllPages(
 	return allPages, nil
 }
 
+// FloatingIpListIter: List floating IPs
+//
+// This method is a wrapper around the `FloatingIpList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) FloatingIpListIter(
+	ctx context.Context,
+	params FloatingIpListParams,
+	opts ...ListOption,
+) iter.Seq2[FloatingIp, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]FloatingIp, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.FloatingIpList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // FloatingIpCreate: Create floating IP
 // A specific IP address can be reserved, or an IP can be auto-allocated from a specific pool or the
 // silo's default
@@ -3621,7 +3979,7 @@ func (c *Client) FloatingIpCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "floating_ip_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/floating-ips"),
@@ -3635,7 +3993,7 @@ func (c *Client) FloatingIpCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3670,7 +4028,7 @@ func (c *Client) FloatingIpView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "floating_ip_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/floating-ips/{{.floating_ip}}"),
@@ -3686,7 +4044,7 @@ func (c *Client) FloatingIpView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3727,7 +4085,7 @@ func (c *Client) FloatingIpUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "floating_ip_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/floating-ips/{{.floating_ip}}"),
@@ -3743,7 +4101,7 @@ func (c *Client) FloatingIpUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3775,7 +4133,7 @@ func (c *Client) FloatingIpDelete(ctx context.Context, params FloatingIpDeletePa
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "floating_ip_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/floating-ips/{{.floating_ip}}"),
@@ -3791,7 +4149,7 @@ func (c *Client) FloatingIpDelete(ctx context.Context, params FloatingIpDeletePa
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -3822,7 +4180,7 @@ func (c *Client) FloatingIpAttach(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "floating_ip_attach"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/floating-ips/{{.floating_ip}}/attach"),
@@ -3838,7 +4196,7 @@ func (c *Client) FloatingIpAttach(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3873,7 +4231,7 @@ func (c *Client) FloatingIpDetach(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "floating_ip_detach"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/floating-ips/{{.floating_ip}}/detach"),
@@ -3889,7 +4247,7 @@ func (c *Client) FloatingIpDetach(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3916,14 +4274,15 @@ func (c *Client) FloatingIpDetach(
 
 // GroupList: List groups
 //
-// To iterate over all pages, use the `GroupListAllPages` method, instead.
+// To iterate over all pages, use the `GroupListIter` or `GroupListAllPages`
+// methods, instead.
 func (c *Client) GroupList(ctx context.Context, params GroupListParams) (*GroupResultsPage, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "group_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/groups"),
@@ -3939,7 +4298,7 @@ func (c *Client) GroupList(ctx context.Context, params GroupListParams) (*GroupR
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -3990,6 +4349,31 @@ func (c *Client) GroupListAllPages(ctx context.Context, params GroupListParams)
 	return allPages, nil
 }
 
+// GroupListIter: List groups
+//
+// This method is a wrapper around the `GroupList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) GroupListIter(
+	ctx context.Context,
+	params GroupListParams,
+	opts ...ListOption,
+) iter.Seq2[Group, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Group, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.GroupList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // GroupView: Fetch group
 func (c *Client) GroupView(ctx context.Context, params GroupViewParams) (*Group, error) {
 	if err := params.Validate(); err != nil {
@@ -3997,7 +4381,7 @@ func (c *Client) GroupView(ctx context.Context, params GroupViewParams) (*Group,
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "group_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/groups/{{.group_id}}"),
@@ -4011,7 +4395,7 @@ func (c *Client) GroupView(ctx context.Context, params GroupViewParams) (*Group,
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4041,14 +4425,15 @@ func (c *Client) GroupView(ctx context.Context, params GroupViewParams) (*Group,
 // by creation date,
 // with the most recent images appearing first.
 //
-// To iterate over all pages, use the `ImageListAllPages` method, instead.
+// To iterate over all pages, use the `ImageListIter` or `ImageListAllPages`
+// methods, instead.
 func (c *Client) ImageList(ctx context.Context, params ImageListParams) (*ImageResultsPage, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "image_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/images"),
@@ -4065,7 +4450,7 @@ func (c *Client) ImageList(ctx context.Context, params ImageListParams) (*ImageR
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4119,6 +4504,34 @@ func (c *Client) ImageListAllPages(ctx context.Context, params ImageListParams)
 	return allPages, nil
 }
 
+// ImageListIter: List images
+// List images which are global or scoped to the specified project. The images are returned sorted
+// by creation date,
+// with the most recent images appearing first.
+//
+// This method is a wrapper around the `ImageList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ImageListIter(
+	ctx context.Context,
+	params ImageListParams,
+	opts ...ListOption,
+) iter.Seq2[Image, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Image, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ImageList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // ImageCreate: Create image
 // Create a new image in a project.
 func (c *Client) ImageCreate(ctx context.Context, params ImageCreateParams) (*Image, error) {
@@ -4133,7 +4546,7 @@ func (c *Client) ImageCreate(ctx context.Context, params ImageCreateParams) (*Im
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "image_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/images"),
@@ -4147,7 +4560,7 @@ func (c *Client) ImageCreate(ctx context.Context, params ImageCreateParams) (*Im
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4180,7 +4593,7 @@ func (c *Client) ImageView(ctx context.Context, params ImageViewParams) (*Image,
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "image_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/images/{{.image}}"),
@@ -4196,7 +4609,7 @@ func (c *Client) ImageView(ctx context.Context, params ImageViewParams) (*Image,
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4231,7 +4644,7 @@ func (c *Client) ImageDelete(ctx context.Context, params ImageDeleteParams) erro
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "image_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/images/{{.image}}"),
@@ -4247,7 +4660,7 @@ func (c *Client) ImageDelete(ctx context.Context, params ImageDeleteParams) erro
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -4269,7 +4682,7 @@ func (c *Client) ImageDemote(ctx context.Context, params ImageDemoteParams) (*Im
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "image_demote"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/images/{{.image}}/demote"),
@@ -4285,7 +4698,7 @@ func (c *Client) ImageDemote(ctx context.Context, params ImageDemoteParams) (*Im
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4318,7 +4731,7 @@ func (c *Client) ImagePromote(ctx context.Context, params ImagePromoteParams) (*
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "image_promote"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/images/{{.image}}/promote"),
@@ -4334,7 +4747,7 @@ func (c *Client) ImagePromote(ctx context.Context, params ImagePromoteParams) (*
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4361,7 +4774,8 @@ func (c *Client) ImagePromote(ctx context.Context, params ImagePromoteParams) (*
 
 // InstanceList: List instances
 //
-// To iterate over all pages, use the `InstanceListAllPages` method, instead.
+// To iterate over all pages, use the `InstanceListIter` or `InstanceListAllPages`
+// methods, instead.
 func (c *Client) InstanceList(
 	ctx context.Context,
 	params InstanceListParams,
@@ -4371,7 +4785,7 @@ func (c *Client) InstanceList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances"),
@@ -4388,7 +4802,7 @@ func (c *Client) InstanceList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4442,6 +4856,31 @@ func (c *Client) InstanceListAllPages(
 	return allPages, nil
 }
 
+// InstanceListIter: List instances
+//
+// This method is a wrapper around the `InstanceList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) InstanceListIter(
+	ctx context.Context,
+	params InstanceListParams,
+	opts ...ListOption,
+) iter.Seq2[Instance, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Instance, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.InstanceList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InstanceCreate: Create instance
 func (c *Client) InstanceCreate(
 	ctx context.Context,
@@ -4458,7 +4897,7 @@ func (c *Client) InstanceCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/instances"),
@@ -4472,7 +4911,7 @@ func (c *Client) InstanceCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4504,7 +4943,7 @@ func (c *Client) InstanceView(ctx context.Context, params InstanceViewParams) (*
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}"),
@@ -4520,7 +4959,7 @@ func (c *Client) InstanceView(ctx context.Context, params InstanceViewParams) (*
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4561,7 +5000,7 @@ func (c *Client) InstanceUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}"),
@@ -4577,7 +5016,7 @@ func (c *Client) InstanceUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4609,7 +5048,7 @@ func (c *Client) InstanceDelete(ctx context.Context, params InstanceDeleteParams
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}"),
@@ -4625,7 +5064,7 @@ func (c *Client) InstanceDelete(ctx context.Context, params InstanceDeleteParams
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -4643,8 +5082,9 @@ func (c *Client) InstanceDelete(ctx context.Context, params InstanceDeleteParams
 //
 // ExperimentalInstanceAffinityGroupList: List affinity groups containing instance
 //
-// To iterate over all pages, use the `ExperimentalInstanceAffinityGroupListAllPages` method,
-// instead.
+// To iterate over all pages, use the `ExperimentalInstanceAffinityGroupListIter` or
+// `ExperimentalInstanceAffinityGroupListAllPages`
+// methods, instead.
 func (c *Client) ExperimentalInstanceAffinityGroupList(
 	ctx context.Context,
 	params InstanceAffinityGroupListParams,
@@ -4654,7 +5094,7 @@ func (c *Client) ExperimentalInstanceAffinityGroupList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_affinity_group_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/affinity-groups"),
@@ -4673,7 +5113,7 @@ func (c *Client) ExperimentalInstanceAffinityGroupList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4729,9 +5169,38 @@ func (c *Client) ExperimentalInstanceAffinityGroupListAllPages(
 	return allPages, nil
 }
 
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalInstanceAffinityGroupListIter: List affinity groups containing instance
+//
+// This method is a wrapper around the `ExperimentalInstanceAffinityGroupList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExperimentalInstanceAffinityGroupListIter(
+	ctx context.Context,
+	params InstanceAffinityGroupListParams,
+	opts ...ListOption,
+) iter.Seq2[AffinityGroup, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AffinityGroup, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExperimentalInstanceAffinityGroupList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InstanceAntiAffinityGroupList: List anti-affinity groups containing instance
 //
-// To iterate over all pages, use the `InstanceAntiAffinityGroupListAllPages` method, instead.
+// To iterate over all pages, use the `InstanceAntiAffinityGroupListIter` or
+// `InstanceAntiAffinityGroupListAllPages`
+// methods, instead.
 func (c *Client) InstanceAntiAffinityGroupList(
 	ctx context.Context,
 	params InstanceAntiAffinityGroupListParams,
@@ -4741,7 +5210,7 @@ func (c *Client) InstanceAntiAffinityGroupList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_anti_affinity_group_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/anti-affinity-groups"),
@@ -4760,7 +5229,7 @@ func (c *Client) InstanceAntiAffinityGroupList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4814,9 +5283,35 @@ func (c *Client) InstanceAntiAffinityGroupListAllPages(
 	return allPages, nil
 }
 
+// InstanceAntiAffinityGroupListIter: List anti-affinity groups containing instance
+//
+// This method is a wrapper around the `InstanceAntiAffinityGroupList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) InstanceAntiAffinityGroupListIter(
+	ctx context.Context,
+	params InstanceAntiAffinityGroupListParams,
+	opts ...ListOption,
+) iter.Seq2[AntiAffinityGroup, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AntiAffinityGroup, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.InstanceAntiAffinityGroupList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InstanceDiskList: List disks for instance
 //
-// To iterate over all pages, use the `InstanceDiskListAllPages` method, instead.
+// To iterate over all pages, use the `InstanceDiskListIter` or `InstanceDiskListAllPages`
+// methods, instead.
 func (c *Client) InstanceDiskList(
 	ctx context.Context,
 	params InstanceDiskListParams,
@@ -4826,7 +5321,7 @@ func (c *Client) InstanceDiskList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_disk_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/disks"),
@@ -4845,7 +5340,7 @@ func (c *Client) InstanceDiskList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4899,6 +5394,31 @@ func (c *Client) InstanceDiskListAllPages(
 	return allPages, nil
 }
 
+// InstanceDiskListIter: List disks for instance
+//
+// This method is a wrapper around the `InstanceDiskList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) InstanceDiskListIter(
+	ctx context.Context,
+	params InstanceDiskListParams,
+	opts ...ListOption,
+) iter.Seq2[Disk, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Disk, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.InstanceDiskList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InstanceDiskAttach: Attach disk to instance
 func (c *Client) InstanceDiskAttach(
 	ctx context.Context,
@@ -4915,7 +5435,7 @@ func (c *Client) InstanceDiskAttach(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_disk_attach"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/disks/attach"),
@@ -4931,7 +5451,7 @@ func (c *Client) InstanceDiskAttach(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -4972,7 +5492,7 @@ func (c *Client) InstanceDiskDetach(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_disk_detach"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/disks/detach"),
@@ -4988,7 +5508,7 @@ func (c *Client) InstanceDiskDetach(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5023,7 +5543,7 @@ func (c *Client) InstanceExternalIpList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_external_ip_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/external-ips"),
@@ -5039,7 +5559,7 @@ func (c *Client) InstanceExternalIpList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5080,7 +5600,7 @@ func (c *Client) InstanceEphemeralIpAttach(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_ephemeral_ip_attach"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/external-ips/ephemeral"),
@@ -5096,7 +5616,7 @@ func (c *Client) InstanceEphemeralIpAttach(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5134,7 +5654,7 @@ func (c *Client) InstanceEphemeralIpDetach(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_ephemeral_ip_detach"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/external-ips/ephemeral"),
@@ -5151,7 +5671,7 @@ func (c *Client) InstanceEphemeralIpDetach(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -5175,7 +5695,7 @@ func (c *Client) InstanceExternalSubnetList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_external_subnet_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/external-subnets"),
@@ -5191,7 +5711,7 @@ func (c *Client) InstanceExternalSubnetList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5220,8 +5740,9 @@ func (c *Client) InstanceExternalSubnetList(
 //
 // ExperimentalInstanceMulticastGroupList: List multicast groups for an instance
 //
-// To iterate over all pages, use the `ExperimentalInstanceMulticastGroupListAllPages` method,
-// instead.
+// To iterate over all pages, use the `ExperimentalInstanceMulticastGroupListIter` or
+// `ExperimentalInstanceMulticastGroupListAllPages`
+// methods, instead.
 func (c *Client) ExperimentalInstanceMulticastGroupList(
 	ctx context.Context,
 	params InstanceMulticastGroupListParams,
@@ -5231,7 +5752,7 @@ func (c *Client) ExperimentalInstanceMulticastGroupList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_multicast_group_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/multicast-groups"),
@@ -5250,7 +5771,7 @@ func (c *Client) ExperimentalInstanceMulticastGroupList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5306,6 +5827,33 @@ func (c *Client) ExperimentalInstanceMulticastGroupListAllPages(
 	return allPages, nil
 }
 
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalInstanceMulticastGroupListIter: List multicast groups for an instance
+//
+// This method is a wrapper around the `ExperimentalInstanceMulticastGroupList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExperimentalInstanceMulticastGroupListIter(
+	ctx context.Context,
+	params InstanceMulticastGroupListParams,
+	opts ...ListOption,
+) iter.Seq2[MulticastGroupMember, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]MulticastGroupMember, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExperimentalInstanceMulticastGroupList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
 //
 // ExperimentalInstanceMulticastGroupJoin: Join multicast group by name, IP address, or UUID
@@ -5332,7 +5880,7 @@ func (c *Client) ExperimentalInstanceMulticastGroupJoin(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_multicast_group_join"),
 		b,
 		"PUT",
 		resolveRelative(
@@ -5352,7 +5900,7 @@ func (c *Client) ExperimentalInstanceMulticastGroupJoin(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5389,7 +5937,7 @@ func (c *Client) ExperimentalInstanceMulticastGroupLeave(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_multicast_group_leave"),
 		nil,
 		"DELETE",
 		resolveRelative(
@@ -5409,7 +5957,7 @@ func (c *Client) ExperimentalInstanceMulticastGroupLeave(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -5433,7 +5981,7 @@ func (c *Client) InstanceReboot(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_reboot"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/reboot"),
@@ -5449,7 +5997,7 @@ func (c *Client) InstanceReboot(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5484,7 +6032,7 @@ func (c *Client) InstanceSerialConsole(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_serial_console"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/serial-console"),
@@ -5503,7 +6051,7 @@ func (c *Client) InstanceSerialConsole(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5538,7 +6086,7 @@ func (c *Client) InstanceSerialConsoleStream(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_serial_console_stream"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/serial-console/stream"),
@@ -5555,7 +6103,7 @@ func (c *Client) InstanceSerialConsoleStream(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -5574,7 +6122,9 @@ func (c *Client) InstanceSerialConsoleStream(
 // snapshot in
 // time and will not reflect updates made after the instance is created.
 //
-// To iterate over all pages, use the `InstanceSshPublicKeyListAllPages` method, instead.
+// To iterate over all pages, use the `InstanceSshPublicKeyListIter` or
+// `InstanceSshPublicKeyListAllPages`
+// methods, instead.
 func (c *Client) InstanceSshPublicKeyList(
 	ctx context.Context,
 	params InstanceSshPublicKeyListParams,
@@ -5584,7 +6134,7 @@ func (c *Client) InstanceSshPublicKeyList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_ssh_public_key_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/ssh-public-keys"),
@@ -5603,7 +6153,7 @@ func (c *Client) InstanceSshPublicKeyList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5660,6 +6210,34 @@ func (c *Client) InstanceSshPublicKeyListAllPages(
 	return allPages, nil
 }
 
+// InstanceSshPublicKeyListIter: List SSH public keys for instance
+// List SSH public keys injected via cloud-init during instance creation. Note that this list is a
+// snapshot in
+// time and will not reflect updates made after the instance is created.
+//
+// This method is a wrapper around the `InstanceSshPublicKeyList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) InstanceSshPublicKeyListIter(
+	ctx context.Context,
+	params InstanceSshPublicKeyListParams,
+	opts ...ListOption,
+) iter.Seq2[SshKey, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SshKey, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.InstanceSshPublicKeyList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InstanceStart: Boot instance
 func (c *Client) InstanceStart(ctx context.Context, params InstanceStartParams) (*Instance, error) {
 	if err := params.Validate(); err != nil {
@@ -5667,7 +6245,7 @@ func (c *Client) InstanceStart(ctx context.Context, params InstanceStartParams)
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_start"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/start"),
@@ -5683,7 +6261,7 @@ func (c *Client) InstanceStart(ctx context.Context, params InstanceStartParams)
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5715,7 +6293,7 @@ func (c *Client) InstanceStop(ctx context.Context, params InstanceStopParams) (*
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_stop"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/instances/{{.instance}}/stop"),
@@ -5731,7 +6309,7 @@ func (c *Client) InstanceStop(ctx context.Context, params InstanceStopParams) (*
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5758,7 +6336,9 @@ func (c *Client) InstanceStop(ctx context.Context, params InstanceStopParams) (*
 
 // InternetGatewayIpAddressList: List IP addresses attached to internet gateway
 //
-// To iterate over all pages, use the `InternetGatewayIpAddressListAllPages` method, instead.
+// To iterate over all pages, use the `InternetGatewayIpAddressListIter` or
+// `InternetGatewayIpAddressListAllPages`
+// methods, instead.
 func (c *Client) InternetGatewayIpAddressList(
 	ctx context.Context,
 	params InternetGatewayIpAddressListParams,
@@ -5768,7 +6348,7 @@ func (c *Client) InternetGatewayIpAddressList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_ip_address_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/internet-gateway-ip-addresses"),
@@ -5787,7 +6367,7 @@ func (c *Client) InternetGatewayIpAddressList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5841,6 +6421,31 @@ func (c *Client) InternetGatewayIpAddressListAllPages(
 	return allPages, nil
 }
 
+// InternetGatewayIpAddressListIter: List IP addresses attached to internet gateway
+//
+// This method is a wrapper around the `InternetGatewayIpAddressList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) InternetGatewayIpAddressListIter(
+	ctx context.Context,
+	params InternetGatewayIpAddressListParams,
+	opts ...ListOption,
+) iter.Seq2[InternetGatewayIpAddress, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]InternetGatewayIpAddress, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.InternetGatewayIpAddressList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InternetGatewayIpAddressCreate: Attach IP address to internet gateway
 func (c *Client) InternetGatewayIpAddressCreate(
 	ctx context.Context,
@@ -5857,7 +6462,7 @@ func (c *Client) InternetGatewayIpAddressCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_ip_address_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/internet-gateway-ip-addresses"),
@@ -5873,7 +6478,7 @@ func (c *Client) InternetGatewayIpAddressCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -5908,7 +6513,7 @@ func (c *Client) InternetGatewayIpAddressDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_ip_address_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/internet-gateway-ip-addresses/{{.address}}"),
@@ -5927,7 +6532,7 @@ func (c *Client) InternetGatewayIpAddressDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -5943,7 +6548,9 @@ func (c *Client) InternetGatewayIpAddressDelete(
 
 // InternetGatewayIpPoolList: List IP pools attached to internet gateway
 //
-// To iterate over all pages, use the `InternetGatewayIpPoolListAllPages` method, instead.
+// To iterate over all pages, use the `InternetGatewayIpPoolListIter` or
+// `InternetGatewayIpPoolListAllPages`
+// methods, instead.
 func (c *Client) InternetGatewayIpPoolList(
 	ctx context.Context,
 	params InternetGatewayIpPoolListParams,
@@ -5953,7 +6560,7 @@ func (c *Client) InternetGatewayIpPoolList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_ip_pool_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/internet-gateway-ip-pools"),
@@ -5972,7 +6579,7 @@ func (c *Client) InternetGatewayIpPoolList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6026,6 +6633,31 @@ func (c *Client) InternetGatewayIpPoolListAllPages(
 	return allPages, nil
 }
 
+// InternetGatewayIpPoolListIter: List IP pools attached to internet gateway
+//
+// This method is a wrapper around the `InternetGatewayIpPoolList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) InternetGatewayIpPoolListIter(
+	ctx context.Context,
+	params InternetGatewayIpPoolListParams,
+	opts ...ListOption,
+) iter.Seq2[InternetGatewayIpPool, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]InternetGatewayIpPool, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.InternetGatewayIpPoolList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InternetGatewayIpPoolCreate: Attach IP pool to internet gateway
 func (c *Client) InternetGatewayIpPoolCreate(
 	ctx context.Context,
@@ -6042,7 +6674,7 @@ func (c *Client) InternetGatewayIpPoolCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_ip_pool_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/internet-gateway-ip-pools"),
@@ -6058,7 +6690,7 @@ func (c *Client) InternetGatewayIpPoolCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6093,7 +6725,7 @@ func (c *Client) InternetGatewayIpPoolDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_ip_pool_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/internet-gateway-ip-pools/{{.pool}}"),
@@ -6112,7 +6744,7 @@ func (c *Client) InternetGatewayIpPoolDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -6128,7 +6760,8 @@ func (c *Client) InternetGatewayIpPoolDelete(
 
 // InternetGatewayList: List internet gateways
 //
-// To iterate over all pages, use the `InternetGatewayListAllPages` method, instead.
+// To iterate over all pages, use the `InternetGatewayListIter` or `InternetGatewayListAllPages`
+// methods, instead.
 func (c *Client) InternetGatewayList(
 	ctx context.Context,
 	params InternetGatewayListParams,
@@ -6138,7 +6771,7 @@ func (c *Client) InternetGatewayList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/internet-gateways"),
@@ -6156,7 +6789,7 @@ func (c *Client) InternetGatewayList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6210,6 +6843,31 @@ func (c *Client) InternetGatewayListAllPages(
 	return allPages, nil
 }
 
+// InternetGatewayListIter: List internet gateways
+//
+// This method is a wrapper around the `InternetGatewayList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) InternetGatewayListIter(
+	ctx context.Context,
+	params InternetGatewayListParams,
+	opts ...ListOption,
+) iter.Seq2[InternetGateway, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]InternetGateway, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.InternetGatewayList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InternetGatewayCreate: Create VPC internet gateway
 func (c *Client) InternetGatewayCreate(
 	ctx context.Context,
@@ -6226,7 +6884,7 @@ func (c *Client) InternetGatewayCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/internet-gateways"),
@@ -6241,7 +6899,7 @@ func (c *Client) InternetGatewayCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6276,7 +6934,7 @@ func (c *Client) InternetGatewayView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/internet-gateways/{{.gateway}}"),
@@ -6293,7 +6951,7 @@ func (c *Client) InternetGatewayView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6328,7 +6986,7 @@ func (c *Client) InternetGatewayDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "internet_gateway_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/internet-gateways/{{.gateway}}"),
@@ -6346,7 +7004,7 @@ func (c *Client) InternetGatewayDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -6362,7 +7020,8 @@ func (c *Client) InternetGatewayDelete(
 
 // IpPoolList: List IP pools
 //
-// To iterate over all pages, use the `IpPoolListAllPages` method, instead.
+// To iterate over all pages, use the `IpPoolListIter` or `IpPoolListAllPages`
+// methods, instead.
 func (c *Client) IpPoolList(
 	ctx context.Context,
 	params IpPoolListParams,
@@ -6372,7 +7031,7 @@ func (c *Client) IpPoolList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "ip_pool_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/ip-pools"),
@@ -6388,7 +7047,7 @@ func (c *Client) IpPoolList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6442,6 +7101,31 @@ func (c *Client) IpPoolListAllPages(
 	return allPages, nil
 }
 
+// IpPoolListIter: List IP pools
+//
+// This method is a wrapper around the `IpPoolList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) IpPoolListIter(
+	ctx context.Context,
+	params IpPoolListParams,
+	opts ...ListOption,
+) iter.Seq2[SiloIpPool, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SiloIpPool, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.IpPoolList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // IpPoolView: Fetch IP pool
 func (c *Client) IpPoolView(ctx context.Context, params IpPoolViewParams) (*SiloIpPool, error) {
 	if err := params.Validate(); err != nil {
@@ -6449,7 +7133,7 @@ func (c *Client) IpPoolView(ctx context.Context, params IpPoolViewParams) (*Silo
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "ip_pool_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/ip-pools/{{.pool}}"),
@@ -6463,7 +7147,7 @@ func (c *Client) IpPoolView(ctx context.Context, params IpPoolViewParams) (*Silo
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6501,7 +7185,7 @@ func (c *Client) LoginLocal(ctx context.Context, params LoginLocalParams) error
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "login_local"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/login/{{.silo_name}}/local"),
@@ -6515,7 +7199,7 @@ func (c *Client) LoginLocal(ctx context.Context, params LoginLocalParams) error
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -6533,7 +7217,7 @@ func (c *Client) LoginLocal(ctx context.Context, params LoginLocalParams) error
 func (c *Client) CurrentUserView(ctx context.Context) (*CurrentUser, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "current_user_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/me"),
@@ -6545,7 +7229,7 @@ func (c *Client) CurrentUserView(ctx context.Context) (*CurrentUser, error) {
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6573,7 +7257,9 @@ func (c *Client) CurrentUserView(ctx context.Context) (*CurrentUser, error) {
 // CurrentUserAccessTokenList: List access tokens
 // List device access tokens for the currently authenticated user.
 //
-// To iterate over all pages, use the `CurrentUserAccessTokenListAllPages` method, instead.
+// To iterate over all pages, use the `CurrentUserAccessTokenListIter` or
+// `CurrentUserAccessTokenListAllPages`
+// methods, instead.
 func (c *Client) CurrentUserAccessTokenList(
 	ctx context.Context,
 	params CurrentUserAccessTokenListParams,
@@ -6583,7 +7269,7 @@ func (c *Client) CurrentUserAccessTokenList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "current_user_access_token_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/me/access-tokens"),
@@ -6599,7 +7285,7 @@ func (c *Client) CurrentUserAccessTokenList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6654,6 +7340,32 @@ func (c *Client) CurrentUserAccessTokenListAllPages(
 	return allPages, nil
 }
 
+// CurrentUserAccessTokenListIter: List access tokens
+// List device access tokens for the currently authenticated user.
+//
+// This method is a wrapper around the `CurrentUserAccessTokenList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) CurrentUserAccessTokenListIter(
+	ctx context.Context,
+	params CurrentUserAccessTokenListParams,
+	opts ...ListOption,
+) iter.Seq2[DeviceAccessToken, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]DeviceAccessToken, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.CurrentUserAccessTokenList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // CurrentUserAccessTokenDelete: Delete access token
 // Delete a device access token for the currently authenticated user.
 func (c *Client) CurrentUserAccessTokenDelete(
@@ -6665,7 +7377,7 @@ func (c *Client) CurrentUserAccessTokenDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "current_user_access_token_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/me/access-tokens/{{.token_id}}"),
@@ -6679,7 +7391,7 @@ func (c *Client) CurrentUserAccessTokenDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -6695,7 +7407,8 @@ func (c *Client) CurrentUserAccessTokenDelete(
 
 // CurrentUserGroups: Fetch current user's groups
 //
-// To iterate over all pages, use the `CurrentUserGroupsAllPages` method, instead.
+// To iterate over all pages, use the `CurrentUserGroupsIter` or `CurrentUserGroupsAllPages`
+// methods, instead.
 func (c *Client) CurrentUserGroups(
 	ctx context.Context,
 	params CurrentUserGroupsParams,
@@ -6705,7 +7418,7 @@ func (c *Client) CurrentUserGroups(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "current_user_groups"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/me/groups"),
@@ -6721,7 +7434,7 @@ func (c *Client) CurrentUserGroups(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6775,10 +7488,36 @@ func (c *Client) CurrentUserGroupsAllPages(
 	return allPages, nil
 }
 
+// CurrentUserGroupsIter: Fetch current user's groups
+//
+// This method is a wrapper around the `CurrentUserGroups` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) CurrentUserGroupsIter(
+	ctx context.Context,
+	params CurrentUserGroupsParams,
+	opts ...ListOption,
+) iter.Seq2[Group, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Group, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.CurrentUserGroups(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // CurrentUserSshKeyList: List SSH public keys
 // Lists SSH public keys for the currently authenticated user.
 //
-// To iterate over all pages, use the `CurrentUserSshKeyListAllPages` method, instead.
+// To iterate over all pages, use the `CurrentUserSshKeyListIter` or `CurrentUserSshKeyListAllPages`
+// methods, instead.
 func (c *Client) CurrentUserSshKeyList(
 	ctx context.Context,
 	params CurrentUserSshKeyListParams,
@@ -6788,7 +7527,7 @@ func (c *Client) CurrentUserSshKeyList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "current_user_ssh_key_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/me/ssh-keys"),
@@ -6804,7 +7543,7 @@ func (c *Client) CurrentUserSshKeyList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6859,6 +7598,32 @@ func (c *Client) CurrentUserSshKeyListAllPages(
 	return allPages, nil
 }
 
+// CurrentUserSshKeyListIter: List SSH public keys
+// Lists SSH public keys for the currently authenticated user.
+//
+// This method is a wrapper around the `CurrentUserSshKeyList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) CurrentUserSshKeyListIter(
+	ctx context.Context,
+	params CurrentUserSshKeyListParams,
+	opts ...ListOption,
+) iter.Seq2[SshKey, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SshKey, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.CurrentUserSshKeyList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // CurrentUserSshKeyCreate: Create SSH public key
 // Create an SSH public key for the currently authenticated user.
 func (c *Client) CurrentUserSshKeyCreate(
@@ -6876,7 +7641,7 @@ func (c *Client) CurrentUserSshKeyCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "current_user_ssh_key_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/me/ssh-keys"),
@@ -6888,7 +7653,7 @@ func (c *Client) CurrentUserSshKeyCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6924,7 +7689,7 @@ func (c *Client) CurrentUserSshKeyView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "current_user_ssh_key_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/me/ssh-keys/{{.ssh_key}}"),
@@ -6938,7 +7703,7 @@ func (c *Client) CurrentUserSshKeyView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -6974,7 +7739,7 @@ func (c *Client) CurrentUserSshKeyDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "current_user_ssh_key_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/me/ssh-keys/{{.ssh_key}}"),
@@ -6988,7 +7753,7 @@ func (c *Client) CurrentUserSshKeyDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -7005,7 +7770,8 @@ func (c *Client) CurrentUserSshKeyDelete(
 // SiloMetric: View metrics
 // View CPU, memory, or storage utilization metrics at the silo or project level.
 //
-// To iterate over all pages, use the `SiloMetricAllPages` method, instead.
+// To iterate over all pages, use the `SiloMetricIter` or `SiloMetricAllPages`
+// methods, instead.
 func (c *Client) SiloMetric(
 	ctx context.Context,
 	params SiloMetricParams,
@@ -7015,7 +7781,7 @@ func (c *Client) SiloMetric(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_metric"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/metrics/{{.metric_name}}"),
@@ -7036,7 +7802,7 @@ func (c *Client) SiloMetric(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7091,11 +7857,39 @@ func (c *Client) SiloMetricAllPages(
 	return allPages, nil
 }
 
+// SiloMetricIter: View metrics
+// View CPU, memory, or storage utilization metrics at the silo or project level.
+//
+// This method is a wrapper around the `SiloMetric` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SiloMetricIter(
+	ctx context.Context,
+	params SiloMetricParams,
+	opts ...ListOption,
+) iter.Seq2[Measurement, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Measurement, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SiloMetric(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
 //
 // ExperimentalMulticastGroupList: List multicast groups
 //
-// To iterate over all pages, use the `ExperimentalMulticastGroupListAllPages` method, instead.
+// To iterate over all pages, use the `ExperimentalMulticastGroupListIter` or
+// `ExperimentalMulticastGroupListAllPages`
+// methods, instead.
 func (c *Client) ExperimentalMulticastGroupList(
 	ctx context.Context,
 	params MulticastGroupListParams,
@@ -7105,7 +7899,7 @@ func (c *Client) ExperimentalMulticastGroupList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "multicast_group_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/multicast-groups"),
@@ -7121,7 +7915,7 @@ func (c *Client) ExperimentalMulticastGroupList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7177,6 +7971,33 @@ func (c *Client) ExperimentalMulticastGroupListAllPages(
 	return allPages, nil
 }
 
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalMulticastGroupListIter: List multicast groups
+//
+// This method is a wrapper around the `ExperimentalMulticastGroupList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExperimentalMulticastGroupListIter(
+	ctx context.Context,
+	params MulticastGroupListParams,
+	opts ...ListOption,
+) iter.Seq2[MulticastGroup, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]MulticastGroup, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExperimentalMulticastGroupList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
 //
 // ExperimentalMulticastGroupView: Fetch multicast group
@@ -7191,7 +8012,7 @@ func (c *Client) ExperimentalMulticastGroupView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "multicast_group_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/multicast-groups/{{.multicast_group}}"),
@@ -7205,7 +8026,7 @@ func (c *Client) ExperimentalMulticastGroupView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7235,8 +8056,9 @@ func (c *Client) ExperimentalMulticastGroupView(
 // ExperimentalMulticastGroupMemberList: List members of multicast group
 // The group can be specified by name, UUID, or multicast IP address.
 //
-// To iterate over all pages, use the `ExperimentalMulticastGroupMemberListAllPages` method,
-// instead.
+// To iterate over all pages, use the `ExperimentalMulticastGroupMemberListIter` or
+// `ExperimentalMulticastGroupMemberListAllPages`
+// methods, instead.
 func (c *Client) ExperimentalMulticastGroupMemberList(
 	ctx context.Context,
 	params MulticastGroupMemberListParams,
@@ -7246,7 +8068,7 @@ func (c *Client) ExperimentalMulticastGroupMemberList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "multicast_group_member_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/multicast-groups/{{.multicast_group}}/members"),
@@ -7264,7 +8086,7 @@ func (c *Client) ExperimentalMulticastGroupMemberList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7321,9 +8143,39 @@ func (c *Client) ExperimentalMulticastGroupMemberListAllPages(
 	return allPages, nil
 }
 
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalMulticastGroupMemberListIter: List members of multicast group
+// The group can be specified by name, UUID, or multicast IP address.
+//
+// This method is a wrapper around the `ExperimentalMulticastGroupMemberList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ExperimentalMulticastGroupMemberListIter(
+	ctx context.Context,
+	params MulticastGroupMemberListParams,
+	opts ...ListOption,
+) iter.Seq2[MulticastGroupMember, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]MulticastGroupMember, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ExperimentalMulticastGroupMemberList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InstanceNetworkInterfaceList: List network interfaces
 //
-// To iterate over all pages, use the `InstanceNetworkInterfaceListAllPages` method, instead.
+// To iterate over all pages, use the `InstanceNetworkInterfaceListIter` or
+// `InstanceNetworkInterfaceListAllPages`
+// methods, instead.
 func (c *Client) InstanceNetworkInterfaceList(
 	ctx context.Context,
 	params InstanceNetworkInterfaceListParams,
@@ -7333,7 +8185,7 @@ func (c *Client) InstanceNetworkInterfaceList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_network_interface_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/network-interfaces"),
@@ -7351,7 +8203,7 @@ func (c *Client) InstanceNetworkInterfaceList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7405,6 +8257,31 @@ func (c *Client) InstanceNetworkInterfaceListAllPages(
 	return allPages, nil
 }
 
+// InstanceNetworkInterfaceListIter: List network interfaces
+//
+// This method is a wrapper around the `InstanceNetworkInterfaceList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) InstanceNetworkInterfaceListIter(
+	ctx context.Context,
+	params InstanceNetworkInterfaceListParams,
+	opts ...ListOption,
+) iter.Seq2[InstanceNetworkInterface, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]InstanceNetworkInterface, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.InstanceNetworkInterfaceList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // InstanceNetworkInterfaceCreate: Create network interface
 func (c *Client) InstanceNetworkInterfaceCreate(
 	ctx context.Context,
@@ -7421,7 +8298,7 @@ func (c *Client) InstanceNetworkInterfaceCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_network_interface_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/network-interfaces"),
@@ -7436,7 +8313,7 @@ func (c *Client) InstanceNetworkInterfaceCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7471,7 +8348,7 @@ func (c *Client) InstanceNetworkInterfaceView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_network_interface_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/network-interfaces/{{.interface}}"),
@@ -7488,7 +8365,7 @@ func (c *Client) InstanceNetworkInterfaceView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7529,7 +8406,7 @@ func (c *Client) InstanceNetworkInterfaceUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_network_interface_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/network-interfaces/{{.interface}}"),
@@ -7546,7 +8423,7 @@ func (c *Client) InstanceNetworkInterfaceUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7585,7 +8462,7 @@ func (c *Client) InstanceNetworkInterfaceDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "instance_network_interface_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/network-interfaces/{{.interface}}"),
@@ -7602,7 +8479,7 @@ func (c *Client) InstanceNetworkInterfaceDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -7621,7 +8498,7 @@ func (c *Client) InstanceNetworkInterfaceDelete(
 func (c *Client) Ping(ctx context.Context) (*Ping, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "ping"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/ping"),
@@ -7633,7 +8510,7 @@ func (c *Client) Ping(ctx context.Context) (*Ping, error) {
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7662,7 +8539,7 @@ func (c *Client) Ping(ctx context.Context) (*Ping, error) {
 func (c *Client) PolicyView(ctx context.Context) (*SiloRolePolicy, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "policy_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/policy"),
@@ -7674,7 +8551,7 @@ func (c *Client) PolicyView(ctx context.Context) (*SiloRolePolicy, error) {
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7715,7 +8592,7 @@ func (c *Client) PolicyUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "policy_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/policy"),
@@ -7727,7 +8604,7 @@ func (c *Client) PolicyUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7754,7 +8631,8 @@ func (c *Client) PolicyUpdate(
 
 // ProjectList: List projects
 //
-// To iterate over all pages, use the `ProjectListAllPages` method, instead.
+// To iterate over all pages, use the `ProjectListIter` or `ProjectListAllPages`
+// methods, instead.
 func (c *Client) ProjectList(
 	ctx context.Context,
 	params ProjectListParams,
@@ -7764,7 +8642,7 @@ func (c *Client) ProjectList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "project_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/projects"),
@@ -7780,7 +8658,7 @@ func (c *Client) ProjectList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7834,6 +8712,31 @@ func (c *Client) ProjectListAllPages(
 	return allPages, nil
 }
 
+// ProjectListIter: List projects
+//
+// This method is a wrapper around the `ProjectList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) ProjectListIter(
+	ctx context.Context,
+	params ProjectListParams,
+	opts ...ListOption,
+) iter.Seq2[Project, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Project, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.ProjectList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // ProjectCreate: Create project
 func (c *Client) ProjectCreate(ctx context.Context, params ProjectCreateParams) (*Project, error) {
 	if err := params.Validate(); err != nil {
@@ -7847,7 +8750,7 @@ func (c *Client) ProjectCreate(ctx context.Context, params ProjectCreateParams)
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "project_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/projects"),
@@ -7859,7 +8762,7 @@ func (c *Client) ProjectCreate(ctx context.Context, params ProjectCreateParams)
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7891,7 +8794,7 @@ func (c *Client) ProjectView(ctx context.Context, params ProjectViewParams) (*Pr
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "project_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/projects/{{.project}}"),
@@ -7905,7 +8808,7 @@ func (c *Client) ProjectView(ctx context.Context, params ProjectViewParams) (*Pr
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7943,7 +8846,7 @@ func (c *Client) ProjectUpdate(ctx context.Context, params ProjectUpdateParams)
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "project_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/projects/{{.project}}"),
@@ -7957,7 +8860,7 @@ func (c *Client) ProjectUpdate(ctx context.Context, params ProjectUpdateParams)
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -7989,7 +8892,7 @@ func (c *Client) ProjectDelete(ctx context.Context, params ProjectDeleteParams)
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "project_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/projects/{{.project}}"),
@@ -8003,7 +8906,7 @@ func (c *Client) ProjectDelete(ctx context.Context, params ProjectDeleteParams)
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -8027,7 +8930,7 @@ func (c *Client) ProjectPolicyView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "project_policy_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/projects/{{.project}}/policy"),
@@ -8041,7 +8944,7 @@ func (c *Client) ProjectPolicyView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8082,7 +8985,7 @@ func (c *Client) ProjectPolicyUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "project_policy_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/projects/{{.project}}/policy"),
@@ -8096,7 +8999,7 @@ func (c *Client) ProjectPolicyUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8123,7 +9026,8 @@ func (c *Client) ProjectPolicyUpdate(
 
 // SnapshotList: List snapshots
 //
-// To iterate over all pages, use the `SnapshotListAllPages` method, instead.
+// To iterate over all pages, use the `SnapshotListIter` or `SnapshotListAllPages`
+// methods, instead.
 func (c *Client) SnapshotList(
 	ctx context.Context,
 	params SnapshotListParams,
@@ -8133,7 +9037,7 @@ func (c *Client) SnapshotList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "snapshot_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/snapshots"),
@@ -8150,7 +9054,7 @@ func (c *Client) SnapshotList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8204,6 +9108,31 @@ func (c *Client) SnapshotListAllPages(
 	return allPages, nil
 }
 
+// SnapshotListIter: List snapshots
+//
+// This method is a wrapper around the `SnapshotList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SnapshotListIter(
+	ctx context.Context,
+	params SnapshotListParams,
+	opts ...ListOption,
+) iter.Seq2[Snapshot, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Snapshot, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SnapshotList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SnapshotCreate: Create snapshot
 // Creates a point-in-time snapshot from a disk.
 func (c *Client) SnapshotCreate(
@@ -8221,7 +9150,7 @@ func (c *Client) SnapshotCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "snapshot_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/snapshots"),
@@ -8235,7 +9164,7 @@ func (c *Client) SnapshotCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8267,7 +9196,7 @@ func (c *Client) SnapshotView(ctx context.Context, params SnapshotViewParams) (*
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "snapshot_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/snapshots/{{.snapshot}}"),
@@ -8283,7 +9212,7 @@ func (c *Client) SnapshotView(ctx context.Context, params SnapshotViewParams) (*
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8315,7 +9244,7 @@ func (c *Client) SnapshotDelete(ctx context.Context, params SnapshotDeleteParams
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "snapshot_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/snapshots/{{.snapshot}}"),
@@ -8331,7 +9260,7 @@ func (c *Client) SnapshotDelete(ctx context.Context, params SnapshotDeleteParams
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -8347,7 +9276,8 @@ func (c *Client) SnapshotDelete(ctx context.Context, params SnapshotDeleteParams
 
 // SubnetPoolList: List subnet pools
 //
-// To iterate over all pages, use the `SubnetPoolListAllPages` method, instead.
+// To iterate over all pages, use the `SubnetPoolListIter` or `SubnetPoolListAllPages`
+// methods, instead.
 func (c *Client) SubnetPoolList(
 	ctx context.Context,
 	params SubnetPoolListParams,
@@ -8357,7 +9287,7 @@ func (c *Client) SubnetPoolList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "subnet_pool_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/subnet-pools"),
@@ -8373,7 +9303,7 @@ func (c *Client) SubnetPoolList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8427,6 +9357,31 @@ func (c *Client) SubnetPoolListAllPages(
 	return allPages, nil
 }
 
+// SubnetPoolListIter: List subnet pools
+//
+// This method is a wrapper around the `SubnetPoolList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SubnetPoolListIter(
+	ctx context.Context,
+	params SubnetPoolListParams,
+	opts ...ListOption,
+) iter.Seq2[SiloSubnetPool, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SiloSubnetPool, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SubnetPoolList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SubnetPoolView: Fetch subnet pool
 func (c *Client) SubnetPoolView(
 	ctx context.Context,
@@ -8437,7 +9392,7 @@ func (c *Client) SubnetPoolView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "subnet_pool_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/subnet-pools/{{.pool}}"),
@@ -8451,7 +9406,7 @@ func (c *Client) SubnetPoolView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8489,7 +9444,8 @@ func (c *Client) SubnetPoolView(
 // guaranteed to be complete, i.e., fetching the
 // same timespan again later will always produce the same set of entries.
 //
-// To iterate over all pages, use the `AuditLogListAllPages` method, instead.
+// To iterate over all pages, use the `AuditLogListIter` or `AuditLogListAllPages`
+// methods, instead.
 func (c *Client) AuditLogList(
 	ctx context.Context,
 	params AuditLogListParams,
@@ -8499,7 +9455,7 @@ func (c *Client) AuditLogList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "audit_log_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/audit-log"),
@@ -8517,7 +9473,7 @@ func (c *Client) AuditLogList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8582,6 +9538,42 @@ func (c *Client) AuditLogListAllPages(
 	return allPages, nil
 }
 
+// AuditLogListIter: View audit log
+// A single item in the audit log represents both the beginning and end of the logged operation
+// (represented by `time_started` and `time_completed`) so that clients do not have to find multiple
+// entries and match them up by request ID to get the full picture of an operation. Because
+// timestamps may not be unique, entries have also have a unique `id` that can be used to
+// deduplicate items fetched from overlapping time intervals.
+//
+// Audit log entries are designed to be immutable: once you see an entry, fetching it again will
+// never get you a different result. The list is ordered by `time_completed`, not `time_started`. If
+// you fetch the audit log for a time range that is fully in the past, the resulting list is
+// guaranteed to be complete, i.e., fetching the
+// same timespan again later will always produce the same set of entries.
+//
+// This method is a wrapper around the `AuditLogList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) AuditLogListIter(
+	ctx context.Context,
+	params AuditLogListParams,
+	opts ...ListOption,
+) iter.Seq2[AuditLogEntry, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AuditLogEntry, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.AuditLogList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // PhysicalDiskEnableAdoption: Enable adoption of a physical disk for general use
 func (c *Client) PhysicalDiskEnableAdoption(
 	ctx context.Context,
@@ -8598,7 +9590,7 @@ func (c *Client) PhysicalDiskEnableAdoption(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "physical_disk_enable_adoption"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/hardware/disk-adoption-request"),
@@ -8610,7 +9602,7 @@ func (c *Client) PhysicalDiskEnableAdoption(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8645,7 +9637,7 @@ func (c *Client) PhysicalDiskDisableAdoption(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "physical_disk_disable_adoption"),
 		nil,
 		"DELETE",
 		resolveRelative(
@@ -8662,7 +9654,7 @@ func (c *Client) PhysicalDiskDisableAdoption(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -8678,7 +9670,9 @@ func (c *Client) PhysicalDiskDisableAdoption(
 
 // PhysicalDiskListAdoptionRequests: List physical disk adoption requests
 //
-// To iterate over all pages, use the `PhysicalDiskListAdoptionRequestsAllPages` method, instead.
+// To iterate over all pages, use the `PhysicalDiskListAdoptionRequestsIter` or
+// `PhysicalDiskListAdoptionRequestsAllPages`
+// methods, instead.
 func (c *Client) PhysicalDiskListAdoptionRequests(
 	ctx context.Context,
 	params PhysicalDiskListAdoptionRequestsParams,
@@ -8688,7 +9682,7 @@ func (c *Client) PhysicalDiskListAdoptionRequests(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "physical_disk_list_adoption_requests"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/disk-adoption-requests"),
@@ -8704,7 +9698,7 @@ func (c *Client) PhysicalDiskListAdoptionRequests(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8758,9 +9752,35 @@ func (c *Client) PhysicalDiskListAdoptionRequestsAllPages(
 	return allPages, nil
 }
 
+// PhysicalDiskListAdoptionRequestsIter: List physical disk adoption requests
+//
+// This method is a wrapper around the `PhysicalDiskListAdoptionRequests` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) PhysicalDiskListAdoptionRequestsIter(
+	ctx context.Context,
+	params PhysicalDiskListAdoptionRequestsParams,
+	opts ...ListOption,
+) iter.Seq2[PhysicalDiskAdoptionRequest, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]PhysicalDiskAdoptionRequest, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.PhysicalDiskListAdoptionRequests(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // PhysicalDiskList: List physical disks
 //
-// To iterate over all pages, use the `PhysicalDiskListAllPages` method, instead.
+// To iterate over all pages, use the `PhysicalDiskListIter` or `PhysicalDiskListAllPages`
+// methods, instead.
 func (c *Client) PhysicalDiskList(
 	ctx context.Context,
 	params PhysicalDiskListParams,
@@ -8770,7 +9790,7 @@ func (c *Client) PhysicalDiskList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "physical_disk_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/disks"),
@@ -8786,7 +9806,7 @@ func (c *Client) PhysicalDiskList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8840,9 +9860,36 @@ func (c *Client) PhysicalDiskListAllPages(
 	return allPages, nil
 }
 
+// PhysicalDiskListIter: List physical disks
+//
+// This method is a wrapper around the `PhysicalDiskList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) PhysicalDiskListIter(
+	ctx context.Context,
+	params PhysicalDiskListParams,
+	opts ...ListOption,
+) iter.Seq2[PhysicalDisk, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]PhysicalDisk, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.PhysicalDiskList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // PhysicalDiskListUnadopted: List physical disks that have not yet been adopted for use
 //
-// To iterate over all pages, use the `PhysicalDiskListUnadoptedAllPages` method, instead.
+// To iterate over all pages, use the `PhysicalDiskListUnadoptedIter` or
+// `PhysicalDiskListUnadoptedAllPages`
+// methods, instead.
 func (c *Client) PhysicalDiskListUnadopted(
 	ctx context.Context,
 	params PhysicalDiskListUnadoptedParams,
@@ -8852,7 +9899,7 @@ func (c *Client) PhysicalDiskListUnadopted(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "physical_disk_list_unadopted"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/disks-unadopted"),
@@ -8867,7 +9914,7 @@ func (c *Client) PhysicalDiskListUnadopted(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8921,6 +9968,31 @@ func (c *Client) PhysicalDiskListUnadoptedAllPages(
 	return allPages, nil
 }
 
+// PhysicalDiskListUnadoptedIter: List physical disks that have not yet been adopted for use
+//
+// This method is a wrapper around the `PhysicalDiskListUnadopted` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) PhysicalDiskListUnadoptedIter(
+	ctx context.Context,
+	params PhysicalDiskListUnadoptedParams,
+	opts ...ListOption,
+) iter.Seq2[UnadoptedPhysicalDisk, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]UnadoptedPhysicalDisk, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.PhysicalDiskListUnadopted(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // PhysicalDiskView: Get physical disk
 func (c *Client) PhysicalDiskView(
 	ctx context.Context,
@@ -8931,7 +10003,7 @@ func (c *Client) PhysicalDiskView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "physical_disk_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/disks/{{.disk_id}}"),
@@ -8945,7 +10017,7 @@ func (c *Client) PhysicalDiskView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -8972,7 +10044,9 @@ func (c *Client) PhysicalDiskView(
 
 // NetworkingSwitchPortLldpNeighbors: Fetch LLDP neighbors for switch port
 //
-// To iterate over all pages, use the `NetworkingSwitchPortLldpNeighborsAllPages` method, instead.
+// To iterate over all pages, use the `NetworkingSwitchPortLldpNeighborsIter` or
+// `NetworkingSwitchPortLldpNeighborsAllPages`
+// methods, instead.
 func (c *Client) NetworkingSwitchPortLldpNeighbors(
 	ctx context.Context,
 	params NetworkingSwitchPortLldpNeighborsParams,
@@ -8982,7 +10056,7 @@ func (c *Client) NetworkingSwitchPortLldpNeighbors(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_lldp_neighbors"),
 		nil,
 		"GET",
 		resolveRelative(
@@ -9005,7 +10079,7 @@ func (c *Client) NetworkingSwitchPortLldpNeighbors(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9059,16 +10133,42 @@ func (c *Client) NetworkingSwitchPortLldpNeighborsAllPages(
 	return allPages, nil
 }
 
+// NetworkingSwitchPortLldpNeighborsIter: Fetch LLDP neighbors for switch port
+//
+// This method is a wrapper around the `NetworkingSwitchPortLldpNeighbors` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) NetworkingSwitchPortLldpNeighborsIter(
+	ctx context.Context,
+	params NetworkingSwitchPortLldpNeighborsParams,
+	opts ...ListOption,
+) iter.Seq2[LldpNeighbor, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]LldpNeighbor, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.NetworkingSwitchPortLldpNeighbors(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // RackList: List racks
 //
-// To iterate over all pages, use the `RackListAllPages` method, instead.
+// To iterate over all pages, use the `RackListIter` or `RackListAllPages`
+// methods, instead.
 func (c *Client) RackList(ctx context.Context, params RackListParams) (*RackResultsPage, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "rack_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/racks"),
@@ -9084,7 +10184,7 @@ func (c *Client) RackList(ctx context.Context, params RackListParams) (*RackResu
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9135,6 +10235,31 @@ func (c *Client) RackListAllPages(ctx context.Context, params RackListParams) ([
 	return allPages, nil
 }
 
+// RackListIter: List racks
+//
+// This method is a wrapper around the `RackList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) RackListIter(
+	ctx context.Context,
+	params RackListParams,
+	opts ...ListOption,
+) iter.Seq2[Rack, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Rack, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.RackList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // RackView: Fetch rack
 func (c *Client) RackView(ctx context.Context, params RackViewParams) (*Rack, error) {
 	if err := params.Validate(); err != nil {
@@ -9142,7 +10267,7 @@ func (c *Client) RackView(ctx context.Context, params RackViewParams) (*Rack, er
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "rack_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/racks/{{.rack_id}}"),
@@ -9156,7 +10281,7 @@ func (c *Client) RackView(ctx context.Context, params RackViewParams) (*Rack, er
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9194,7 +10319,7 @@ func (c *Client) ExperimentalRackMembershipStatus(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "rack_membership_status"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/racks/{{.rack_id}}/membership"),
@@ -9210,7 +10335,7 @@ func (c *Client) ExperimentalRackMembershipStatus(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9251,7 +10376,7 @@ func (c *Client) ExperimentalRackMembershipAbort(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "rack_membership_abort"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/system/hardware/racks/{{.rack_id}}/membership/abort"),
@@ -9265,7 +10390,7 @@ func (c *Client) ExperimentalRackMembershipAbort(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9308,7 +10433,7 @@ func (c *Client) ExperimentalRackMembershipAddSleds(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "rack_membership_add_sleds"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/hardware/racks/{{.rack_id}}/membership/add"),
@@ -9322,7 +10447,7 @@ func (c *Client) ExperimentalRackMembershipAddSleds(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9349,14 +10474,15 @@ func (c *Client) ExperimentalRackMembershipAddSleds(
 
 // SledList: List sleds
 //
-// To iterate over all pages, use the `SledListAllPages` method, instead.
+// To iterate over all pages, use the `SledListIter` or `SledListAllPages`
+// methods, instead.
 func (c *Client) SledList(ctx context.Context, params SledListParams) (*SledResultsPage, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "sled_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/sleds"),
@@ -9372,7 +10498,7 @@ func (c *Client) SledList(ctx context.Context, params SledListParams) (*SledResu
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9423,9 +10549,35 @@ func (c *Client) SledListAllPages(ctx context.Context, params SledListParams) ([
 	return allPages, nil
 }
 
+// SledListIter: List sleds
+//
+// This method is a wrapper around the `SledList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SledListIter(
+	ctx context.Context,
+	params SledListParams,
+	opts ...ListOption,
+) iter.Seq2[Sled, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Sled, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SledList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SledListUninitialized: List uninitialized sleds
 //
-// To iterate over all pages, use the `SledListUninitializedAllPages` method, instead.
+// To iterate over all pages, use the `SledListUninitializedIter` or `SledListUninitializedAllPages`
+// methods, instead.
 func (c *Client) SledListUninitialized(
 	ctx context.Context,
 	params SledListUninitializedParams,
@@ -9435,7 +10587,7 @@ func (c *Client) SledListUninitialized(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "sled_list_uninitialized"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/sleds-uninitialized"),
@@ -9450,7 +10602,7 @@ func (c *Client) SledListUninitialized(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9504,6 +10656,31 @@ func (c *Client) SledListUninitializedAllPages(
 	return allPages, nil
 }
 
+// SledListUninitializedIter: List uninitialized sleds
+//
+// This method is a wrapper around the `SledListUninitialized` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SledListUninitializedIter(
+	ctx context.Context,
+	params SledListUninitializedParams,
+	opts ...ListOption,
+) iter.Seq2[UninitializedSled, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]UninitializedSled, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SledListUninitialized(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SledView: Fetch sled
 func (c *Client) SledView(ctx context.Context, params SledViewParams) (*Sled, error) {
 	if err := params.Validate(); err != nil {
@@ -9511,7 +10688,7 @@ func (c *Client) SledView(ctx context.Context, params SledViewParams) (*Sled, er
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "sled_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/sleds/{{.sled_id}}"),
@@ -9525,7 +10702,7 @@ func (c *Client) SledView(ctx context.Context, params SledViewParams) (*Sled, er
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9552,7 +10729,8 @@ func (c *Client) SledView(ctx context.Context, params SledViewParams) (*Sled, er
 
 // SledPhysicalDiskList: List physical disks attached to sleds
 //
-// To iterate over all pages, use the `SledPhysicalDiskListAllPages` method, instead.
+// To iterate over all pages, use the `SledPhysicalDiskListIter` or `SledPhysicalDiskListAllPages`
+// methods, instead.
 func (c *Client) SledPhysicalDiskList(
 	ctx context.Context,
 	params SledPhysicalDiskListParams,
@@ -9562,7 +10740,7 @@ func (c *Client) SledPhysicalDiskList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "sled_physical_disk_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/sleds/{{.sled_id}}/disks"),
@@ -9580,7 +10758,7 @@ func (c *Client) SledPhysicalDiskList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9634,9 +10812,35 @@ func (c *Client) SledPhysicalDiskListAllPages(
 	return allPages, nil
 }
 
+// SledPhysicalDiskListIter: List physical disks attached to sleds
+//
+// This method is a wrapper around the `SledPhysicalDiskList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SledPhysicalDiskListIter(
+	ctx context.Context,
+	params SledPhysicalDiskListParams,
+	opts ...ListOption,
+) iter.Seq2[PhysicalDisk, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]PhysicalDisk, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SledPhysicalDiskList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SledInstanceList: List instances running on given sled
 //
-// To iterate over all pages, use the `SledInstanceListAllPages` method, instead.
+// To iterate over all pages, use the `SledInstanceListIter` or `SledInstanceListAllPages`
+// methods, instead.
 func (c *Client) SledInstanceList(
 	ctx context.Context,
 	params SledInstanceListParams,
@@ -9646,7 +10850,7 @@ func (c *Client) SledInstanceList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "sled_instance_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/sleds/{{.sled_id}}/instances"),
@@ -9664,7 +10868,7 @@ func (c *Client) SledInstanceList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9718,6 +10922,31 @@ func (c *Client) SledInstanceListAllPages(
 	return allPages, nil
 }
 
+// SledInstanceListIter: List instances running on given sled
+//
+// This method is a wrapper around the `SledInstanceList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SledInstanceListIter(
+	ctx context.Context,
+	params SledInstanceListParams,
+	opts ...ListOption,
+) iter.Seq2[SledInstance, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SledInstance, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SledInstanceList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SledSetProvisionPolicy: Set sled provision policy
 func (c *Client) SledSetProvisionPolicy(
 	ctx context.Context,
@@ -9734,7 +10963,7 @@ func (c *Client) SledSetProvisionPolicy(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "sled_set_provision_policy"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/hardware/sleds/{{.sled_id}}/provision-policy"),
@@ -9748,7 +10977,7 @@ func (c *Client) SledSetProvisionPolicy(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9775,7 +11004,9 @@ func (c *Client) SledSetProvisionPolicy(
 
 // NetworkingSwitchPortList: List switch ports
 //
-// To iterate over all pages, use the `NetworkingSwitchPortListAllPages` method, instead.
+// To iterate over all pages, use the `NetworkingSwitchPortListIter` or
+// `NetworkingSwitchPortListAllPages`
+// methods, instead.
 func (c *Client) NetworkingSwitchPortList(
 	ctx context.Context,
 	params NetworkingSwitchPortListParams,
@@ -9785,7 +11016,7 @@ func (c *Client) NetworkingSwitchPortList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/switch-port"),
@@ -9802,7 +11033,7 @@ func (c *Client) NetworkingSwitchPortList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9856,6 +11087,31 @@ func (c *Client) NetworkingSwitchPortListAllPages(
 	return allPages, nil
 }
 
+// NetworkingSwitchPortListIter: List switch ports
+//
+// This method is a wrapper around the `NetworkingSwitchPortList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) NetworkingSwitchPortListIter(
+	ctx context.Context,
+	params NetworkingSwitchPortListParams,
+	opts ...ListOption,
+) iter.Seq2[SwitchPort, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SwitchPort, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.NetworkingSwitchPortList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // NetworkingSwitchPortLldpConfigView: Fetch LLDP configuration for switch port
 func (c *Client) NetworkingSwitchPortLldpConfigView(
 	ctx context.Context,
@@ -9866,7 +11122,7 @@ func (c *Client) NetworkingSwitchPortLldpConfigView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_lldp_config_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/switch-port/{{.port}}/lldp/config"),
@@ -9883,7 +11139,7 @@ func (c *Client) NetworkingSwitchPortLldpConfigView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -9924,7 +11180,7 @@ func (c *Client) NetworkingSwitchPortLldpConfigUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_lldp_config_update"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/hardware/switch-port/{{.port}}/lldp/config"),
@@ -9941,7 +11197,7 @@ func (c *Client) NetworkingSwitchPortLldpConfigUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -9971,7 +11227,7 @@ func (c *Client) NetworkingSwitchPortApplySettings(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_apply_settings"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/hardware/switch-port/{{.port}}/settings"),
@@ -9988,7 +11244,7 @@ func (c *Client) NetworkingSwitchPortApplySettings(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -10012,7 +11268,7 @@ func (c *Client) NetworkingSwitchPortClearSettings(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_clear_settings"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/hardware/switch-port/{{.port}}/settings"),
@@ -10029,7 +11285,7 @@ func (c *Client) NetworkingSwitchPortClearSettings(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -10053,7 +11309,7 @@ func (c *Client) NetworkingSwitchPortStatus(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_status"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/switch-port/{{.port}}/status"),
@@ -10070,7 +11326,7 @@ func (c *Client) NetworkingSwitchPortStatus(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10097,7 +11353,8 @@ func (c *Client) NetworkingSwitchPortStatus(
 
 // SwitchList: List switches
 //
-// To iterate over all pages, use the `SwitchListAllPages` method, instead.
+// To iterate over all pages, use the `SwitchListIter` or `SwitchListAllPages`
+// methods, instead.
 func (c *Client) SwitchList(
 	ctx context.Context,
 	params SwitchListParams,
@@ -10107,7 +11364,7 @@ func (c *Client) SwitchList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "switch_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/switches"),
@@ -10123,7 +11380,7 @@ func (c *Client) SwitchList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10177,6 +11434,31 @@ func (c *Client) SwitchListAllPages(
 	return allPages, nil
 }
 
+// SwitchListIter: List switches
+//
+// This method is a wrapper around the `SwitchList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SwitchListIter(
+	ctx context.Context,
+	params SwitchListParams,
+	opts ...ListOption,
+) iter.Seq2[Switch, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Switch, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SwitchList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SwitchView: Fetch switch
 func (c *Client) SwitchView(ctx context.Context, params SwitchViewParams) (*Switch, error) {
 	if err := params.Validate(); err != nil {
@@ -10184,7 +11466,7 @@ func (c *Client) SwitchView(ctx context.Context, params SwitchViewParams) (*Swit
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "switch_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/hardware/switches/{{.switch_id}}"),
@@ -10198,7 +11480,7 @@ func (c *Client) SwitchView(ctx context.Context, params SwitchViewParams) (*Swit
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10226,7 +11508,9 @@ func (c *Client) SwitchView(ctx context.Context, params SwitchViewParams) (*Swit
 // SiloIdentityProviderList: List identity providers for silo
 // List identity providers for silo by silo name or ID.
 //
-// To iterate over all pages, use the `SiloIdentityProviderListAllPages` method, instead.
+// To iterate over all pages, use the `SiloIdentityProviderListIter` or
+// `SiloIdentityProviderListAllPages`
+// methods, instead.
 func (c *Client) SiloIdentityProviderList(
 	ctx context.Context,
 	params SiloIdentityProviderListParams,
@@ -10236,7 +11520,7 @@ func (c *Client) SiloIdentityProviderList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_identity_provider_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/identity-providers"),
@@ -10253,7 +11537,7 @@ func (c *Client) SiloIdentityProviderList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10308,6 +11592,32 @@ func (c *Client) SiloIdentityProviderListAllPages(
 	return allPages, nil
 }
 
+// SiloIdentityProviderListIter: List identity providers for silo
+// List identity providers for silo by silo name or ID.
+//
+// This method is a wrapper around the `SiloIdentityProviderList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SiloIdentityProviderListIter(
+	ctx context.Context,
+	params SiloIdentityProviderListParams,
+	opts ...ListOption,
+) iter.Seq2[IdentityProvider, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]IdentityProvider, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SiloIdentityProviderList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // LocalIdpUserCreate: Create user
 // Users can only be created in Silos with `provision_type` == `Fixed`. Otherwise, Silo users are
 // just-in-time (JIT)
@@ -10327,7 +11637,7 @@ func (c *Client) LocalIdpUserCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "local_idp_user_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/identity-providers/local/users"),
@@ -10341,7 +11651,7 @@ func (c *Client) LocalIdpUserCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10373,7 +11683,7 @@ func (c *Client) LocalIdpUserDelete(ctx context.Context, params LocalIdpUserDele
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "local_idp_user_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/identity-providers/local/users/{{.user_id}}"),
@@ -10389,7 +11699,7 @@ func (c *Client) LocalIdpUserDelete(ctx context.Context, params LocalIdpUserDele
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -10420,7 +11730,7 @@ func (c *Client) LocalIdpUserSetPassword(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "local_idp_user_set_password"),
 		b,
 		"POST",
 		resolveRelative(
@@ -10439,7 +11749,7 @@ func (c *Client) LocalIdpUserSetPassword(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -10469,7 +11779,7 @@ func (c *Client) SamlIdentityProviderCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "saml_identity_provider_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/identity-providers/saml"),
@@ -10483,7 +11793,7 @@ func (c *Client) SamlIdentityProviderCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10518,7 +11828,7 @@ func (c *Client) SamlIdentityProviderView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "saml_identity_provider_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/identity-providers/saml/{{.provider}}"),
@@ -10534,7 +11844,7 @@ func (c *Client) SamlIdentityProviderView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10561,7 +11871,8 @@ func (c *Client) SamlIdentityProviderView(
 
 // SystemIpPoolList: List IP pools
 //
-// To iterate over all pages, use the `SystemIpPoolListAllPages` method, instead.
+// To iterate over all pages, use the `SystemIpPoolListIter` or `SystemIpPoolListAllPages`
+// methods, instead.
 func (c *Client) SystemIpPoolList(
 	ctx context.Context,
 	params SystemIpPoolListParams,
@@ -10571,7 +11882,7 @@ func (c *Client) SystemIpPoolList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/ip-pools"),
@@ -10587,7 +11898,7 @@ func (c *Client) SystemIpPoolList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10641,6 +11952,31 @@ func (c *Client) SystemIpPoolListAllPages(
 	return allPages, nil
 }
 
+// SystemIpPoolListIter: List IP pools
+//
+// This method is a wrapper around the `SystemIpPoolList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemIpPoolListIter(
+	ctx context.Context,
+	params SystemIpPoolListParams,
+	opts ...ListOption,
+) iter.Seq2[IpPool, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]IpPool, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemIpPoolList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemIpPoolCreate: Create IP pool
 func (c *Client) SystemIpPoolCreate(
 	ctx context.Context,
@@ -10657,7 +11993,7 @@ func (c *Client) SystemIpPoolCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/ip-pools"),
@@ -10669,7 +12005,7 @@ func (c *Client) SystemIpPoolCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10698,7 +12034,7 @@ func (c *Client) SystemIpPoolCreate(
 func (c *Client) SystemIpPoolServiceView(ctx context.Context) (*IpPool, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_service_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/ip-pools-service"),
@@ -10710,7 +12046,7 @@ func (c *Client) SystemIpPoolServiceView(ctx context.Context) (*IpPool, error) {
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10738,7 +12074,9 @@ func (c *Client) SystemIpPoolServiceView(ctx context.Context) (*IpPool, error) {
 // SystemIpPoolServiceRangeList: List IP ranges for the Oxide service pool
 // Ranges are ordered by their first address.
 //
-// To iterate over all pages, use the `SystemIpPoolServiceRangeListAllPages` method, instead.
+// To iterate over all pages, use the `SystemIpPoolServiceRangeListIter` or
+// `SystemIpPoolServiceRangeListAllPages`
+// methods, instead.
 func (c *Client) SystemIpPoolServiceRangeList(
 	ctx context.Context,
 	params SystemIpPoolServiceRangeListParams,
@@ -10748,7 +12086,7 @@ func (c *Client) SystemIpPoolServiceRangeList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_service_range_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/ip-pools-service/ranges"),
@@ -10763,7 +12101,7 @@ func (c *Client) SystemIpPoolServiceRangeList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10818,6 +12156,32 @@ func (c *Client) SystemIpPoolServiceRangeListAllPages(
 	return allPages, nil
 }
 
+// SystemIpPoolServiceRangeListIter: List IP ranges for the Oxide service pool
+// Ranges are ordered by their first address.
+//
+// This method is a wrapper around the `SystemIpPoolServiceRangeList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemIpPoolServiceRangeListIter(
+	ctx context.Context,
+	params SystemIpPoolServiceRangeListParams,
+	opts ...ListOption,
+) iter.Seq2[IpPoolRange, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]IpPoolRange, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemIpPoolServiceRangeList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemIpPoolServiceRangeAdd: Add IP range to Oxide service pool
 // IPv6 ranges are not allowed yet.
 func (c *Client) SystemIpPoolServiceRangeAdd(
@@ -10835,7 +12199,7 @@ func (c *Client) SystemIpPoolServiceRangeAdd(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_service_range_add"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/ip-pools-service/ranges/add"),
@@ -10847,7 +12211,7 @@ func (c *Client) SystemIpPoolServiceRangeAdd(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10888,7 +12252,7 @@ func (c *Client) SystemIpPoolServiceRangeRemove(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_service_range_remove"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/ip-pools-service/ranges/remove"),
@@ -10900,7 +12264,7 @@ func (c *Client) SystemIpPoolServiceRangeRemove(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -10924,7 +12288,7 @@ func (c *Client) SystemIpPoolView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}"),
@@ -10938,7 +12302,7 @@ func (c *Client) SystemIpPoolView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -10979,7 +12343,7 @@ func (c *Client) SystemIpPoolUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}"),
@@ -10993,7 +12357,7 @@ func (c *Client) SystemIpPoolUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11025,7 +12389,7 @@ func (c *Client) SystemIpPoolDelete(ctx context.Context, params SystemIpPoolDele
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}"),
@@ -11039,7 +12403,7 @@ func (c *Client) SystemIpPoolDelete(ctx context.Context, params SystemIpPoolDele
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -11056,7 +12420,8 @@ func (c *Client) SystemIpPoolDelete(ctx context.Context, params SystemIpPoolDele
 // SystemIpPoolRangeList: List ranges for IP pool
 // Ranges are ordered by their first address.
 //
-// To iterate over all pages, use the `SystemIpPoolRangeListAllPages` method, instead.
+// To iterate over all pages, use the `SystemIpPoolRangeListIter` or `SystemIpPoolRangeListAllPages`
+// methods, instead.
 func (c *Client) SystemIpPoolRangeList(
 	ctx context.Context,
 	params SystemIpPoolRangeListParams,
@@ -11066,7 +12431,7 @@ func (c *Client) SystemIpPoolRangeList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_range_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}/ranges"),
@@ -11083,7 +12448,7 @@ func (c *Client) SystemIpPoolRangeList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11138,6 +12503,32 @@ func (c *Client) SystemIpPoolRangeListAllPages(
 	return allPages, nil
 }
 
+// SystemIpPoolRangeListIter: List ranges for IP pool
+// Ranges are ordered by their first address.
+//
+// This method is a wrapper around the `SystemIpPoolRangeList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemIpPoolRangeListIter(
+	ctx context.Context,
+	params SystemIpPoolRangeListParams,
+	opts ...ListOption,
+) iter.Seq2[IpPoolRange, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]IpPoolRange, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemIpPoolRangeList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemIpPoolRangeAdd: Add range to IP pool
 // For multicast pools, all ranges must be either Any-Source Multicast (ASM) or Source-Specific
 // Multicast (SSM),
@@ -11161,7 +12552,7 @@ func (c *Client) SystemIpPoolRangeAdd(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_range_add"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}/ranges/add"),
@@ -11175,7 +12566,7 @@ func (c *Client) SystemIpPoolRangeAdd(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11216,7 +12607,7 @@ func (c *Client) SystemIpPoolRangeRemove(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_range_remove"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}/ranges/remove"),
@@ -11230,7 +12621,7 @@ func (c *Client) SystemIpPoolRangeRemove(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -11246,7 +12637,8 @@ func (c *Client) SystemIpPoolRangeRemove(
 
 // SystemIpPoolSiloList: List IP pool's linked silos
 //
-// To iterate over all pages, use the `SystemIpPoolSiloListAllPages` method, instead.
+// To iterate over all pages, use the `SystemIpPoolSiloListIter` or `SystemIpPoolSiloListAllPages`
+// methods, instead.
 func (c *Client) SystemIpPoolSiloList(
 	ctx context.Context,
 	params SystemIpPoolSiloListParams,
@@ -11256,7 +12648,7 @@ func (c *Client) SystemIpPoolSiloList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_silo_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}/silos"),
@@ -11274,7 +12666,7 @@ func (c *Client) SystemIpPoolSiloList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11328,6 +12720,31 @@ func (c *Client) SystemIpPoolSiloListAllPages(
 	return allPages, nil
 }
 
+// SystemIpPoolSiloListIter: List IP pool's linked silos
+//
+// This method is a wrapper around the `SystemIpPoolSiloList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemIpPoolSiloListIter(
+	ctx context.Context,
+	params SystemIpPoolSiloListParams,
+	opts ...ListOption,
+) iter.Seq2[IpPoolSiloLink, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]IpPoolSiloLink, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemIpPoolSiloList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemIpPoolSiloLink: Link IP pool to silo
 // Users in linked silos can allocate external IPs from this pool for their instances. A silo can
 // have at most one default pool. IPs are allocated from the default pool when users ask for one
@@ -11347,7 +12764,7 @@ func (c *Client) SystemIpPoolSiloLink(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_silo_link"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}/silos"),
@@ -11361,7 +12778,7 @@ func (c *Client) SystemIpPoolSiloLink(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11406,7 +12823,7 @@ func (c *Client) SystemIpPoolSiloUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_silo_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}/silos/{{.silo}}"),
@@ -11421,7 +12838,7 @@ func (c *Client) SystemIpPoolSiloUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11457,7 +12874,7 @@ func (c *Client) SystemIpPoolSiloUnlink(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_silo_unlink"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}/silos/{{.silo}}"),
@@ -11472,7 +12889,7 @@ func (c *Client) SystemIpPoolSiloUnlink(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -11496,7 +12913,7 @@ func (c *Client) SystemIpPoolUtilizationView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_ip_pool_utilization_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/ip-pools/{{.pool}}/utilization"),
@@ -11510,7 +12927,7 @@ func (c *Client) SystemIpPoolUtilizationView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11538,7 +12955,8 @@ func (c *Client) SystemIpPoolUtilizationView(
 // SystemMetric: View metrics
 // View CPU, memory, or storage utilization metrics at the fleet or silo level.
 //
-// To iterate over all pages, use the `SystemMetricAllPages` method, instead.
+// To iterate over all pages, use the `SystemMetricIter` or `SystemMetricAllPages`
+// methods, instead.
 func (c *Client) SystemMetric(
 	ctx context.Context,
 	params SystemMetricParams,
@@ -11548,7 +12966,7 @@ func (c *Client) SystemMetric(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_metric"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/metrics/{{.metric_name}}"),
@@ -11569,7 +12987,7 @@ func (c *Client) SystemMetric(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11624,9 +13042,37 @@ func (c *Client) SystemMetricAllPages(
 	return allPages, nil
 }
 
+// SystemMetricIter: View metrics
+// View CPU, memory, or storage utilization metrics at the fleet or silo level.
+//
+// This method is a wrapper around the `SystemMetric` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemMetricIter(
+	ctx context.Context,
+	params SystemMetricParams,
+	opts ...ListOption,
+) iter.Seq2[Measurement, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Measurement, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemMetric(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // NetworkingAddressLotList: List address lots
 //
-// To iterate over all pages, use the `NetworkingAddressLotListAllPages` method, instead.
+// To iterate over all pages, use the `NetworkingAddressLotListIter` or
+// `NetworkingAddressLotListAllPages`
+// methods, instead.
 func (c *Client) NetworkingAddressLotList(
 	ctx context.Context,
 	params NetworkingAddressLotListParams,
@@ -11636,7 +13082,7 @@ func (c *Client) NetworkingAddressLotList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_address_lot_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/address-lot"),
@@ -11652,7 +13098,7 @@ func (c *Client) NetworkingAddressLotList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11706,6 +13152,31 @@ func (c *Client) NetworkingAddressLotListAllPages(
 	return allPages, nil
 }
 
+// NetworkingAddressLotListIter: List address lots
+//
+// This method is a wrapper around the `NetworkingAddressLotList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) NetworkingAddressLotListIter(
+	ctx context.Context,
+	params NetworkingAddressLotListParams,
+	opts ...ListOption,
+) iter.Seq2[AddressLot, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AddressLot, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.NetworkingAddressLotList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // NetworkingAddressLotCreate: Create address lot
 func (c *Client) NetworkingAddressLotCreate(
 	ctx context.Context,
@@ -11722,7 +13193,7 @@ func (c *Client) NetworkingAddressLotCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_address_lot_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/networking/address-lot"),
@@ -11734,7 +13205,7 @@ func (c *Client) NetworkingAddressLotCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11769,7 +13240,7 @@ func (c *Client) NetworkingAddressLotView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_address_lot_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/address-lot/{{.address_lot}}"),
@@ -11783,7 +13254,7 @@ func (c *Client) NetworkingAddressLotView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11818,7 +13289,7 @@ func (c *Client) NetworkingAddressLotDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_address_lot_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/networking/address-lot/{{.address_lot}}"),
@@ -11832,7 +13303,7 @@ func (c *Client) NetworkingAddressLotDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -11848,7 +13319,9 @@ func (c *Client) NetworkingAddressLotDelete(
 
 // NetworkingAddressLotBlockList: List blocks in address lot
 //
-// To iterate over all pages, use the `NetworkingAddressLotBlockListAllPages` method, instead.
+// To iterate over all pages, use the `NetworkingAddressLotBlockListIter` or
+// `NetworkingAddressLotBlockListAllPages`
+// methods, instead.
 func (c *Client) NetworkingAddressLotBlockList(
 	ctx context.Context,
 	params NetworkingAddressLotBlockListParams,
@@ -11858,7 +13331,7 @@ func (c *Client) NetworkingAddressLotBlockList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_address_lot_block_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/address-lot/{{.address_lot}}/blocks"),
@@ -11876,7 +13349,7 @@ func (c *Client) NetworkingAddressLotBlockList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11930,11 +13403,36 @@ func (c *Client) NetworkingAddressLotBlockListAllPages(
 	return allPages, nil
 }
 
+// NetworkingAddressLotBlockListIter: List blocks in address lot
+//
+// This method is a wrapper around the `NetworkingAddressLotBlockList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) NetworkingAddressLotBlockListIter(
+	ctx context.Context,
+	params NetworkingAddressLotBlockListParams,
+	opts ...ListOption,
+) iter.Seq2[AddressLotBlock, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]AddressLotBlock, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.NetworkingAddressLotBlockList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // NetworkingAllowListView: Get user-facing services IP allowlist
 func (c *Client) NetworkingAllowListView(ctx context.Context) (*AllowList, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_allow_list_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/allow-list"),
@@ -11946,7 +13444,7 @@ func (c *Client) NetworkingAllowListView(ctx context.Context) (*AllowList, error
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -11987,7 +13485,7 @@ func (c *Client) NetworkingAllowListUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_allow_list_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/networking/allow-list"),
@@ -11999,7 +13497,7 @@ func (c *Client) NetworkingAllowListUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12040,7 +13538,7 @@ func (c *Client) NetworkingBfdDisable(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bfd_disable"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/networking/bfd-disable"),
@@ -12052,7 +13550,7 @@ func (c *Client) NetworkingBfdDisable(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -12079,7 +13577,7 @@ func (c *Client) NetworkingBfdEnable(ctx context.Context, params NetworkingBfdEn
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bfd_enable"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/networking/bfd-enable"),
@@ -12091,7 +13589,7 @@ func (c *Client) NetworkingBfdEnable(ctx context.Context, params NetworkingBfdEn
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -12109,7 +13607,7 @@ func (c *Client) NetworkingBfdEnable(ctx context.Context, params NetworkingBfdEn
 func (c *Client) NetworkingBfdStatus(ctx context.Context) (*[]BfdStatus, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bfd_status"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/bfd-status"),
@@ -12121,7 +13619,7 @@ func (c *Client) NetworkingBfdStatus(ctx context.Context) (*[]BfdStatus, error)
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12148,7 +13646,9 @@ func (c *Client) NetworkingBfdStatus(ctx context.Context) (*[]BfdStatus, error)
 
 // NetworkingBgpConfigList: List BGP configurations
 //
-// To iterate over all pages, use the `NetworkingBgpConfigListAllPages` method, instead.
+// To iterate over all pages, use the `NetworkingBgpConfigListIter` or
+// `NetworkingBgpConfigListAllPages`
+// methods, instead.
 func (c *Client) NetworkingBgpConfigList(
 	ctx context.Context,
 	params NetworkingBgpConfigListParams,
@@ -12158,7 +13658,7 @@ func (c *Client) NetworkingBgpConfigList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_config_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/bgp"),
@@ -12174,7 +13674,7 @@ func (c *Client) NetworkingBgpConfigList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12228,6 +13728,31 @@ func (c *Client) NetworkingBgpConfigListAllPages(
 	return allPages, nil
 }
 
+// NetworkingBgpConfigListIter: List BGP configurations
+//
+// This method is a wrapper around the `NetworkingBgpConfigList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) NetworkingBgpConfigListIter(
+	ctx context.Context,
+	params NetworkingBgpConfigListParams,
+	opts ...ListOption,
+) iter.Seq2[BgpConfig, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]BgpConfig, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.NetworkingBgpConfigList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // NetworkingBgpConfigCreate: Create BGP configuration
 func (c *Client) NetworkingBgpConfigCreate(
 	ctx context.Context,
@@ -12244,7 +13769,7 @@ func (c *Client) NetworkingBgpConfigCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_config_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/networking/bgp"),
@@ -12256,7 +13781,7 @@ func (c *Client) NetworkingBgpConfigCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12291,7 +13816,7 @@ func (c *Client) NetworkingBgpConfigDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_config_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/networking/bgp"),
@@ -12305,7 +13830,7 @@ func (c *Client) NetworkingBgpConfigDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -12321,7 +13846,9 @@ func (c *Client) NetworkingBgpConfigDelete(
 
 // NetworkingBgpAnnounceSetList: List BGP announce sets
 //
-// To iterate over all pages, use the `NetworkingBgpAnnounceSetListAllPages` method, instead.
+// To iterate over all pages, use the `NetworkingBgpAnnounceSetListIter` or
+// `NetworkingBgpAnnounceSetListAllPages`
+// methods, instead.
 func (c *Client) NetworkingBgpAnnounceSetList(
 	ctx context.Context,
 	params NetworkingBgpAnnounceSetListParams,
@@ -12331,7 +13858,7 @@ func (c *Client) NetworkingBgpAnnounceSetList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_announce_set_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/bgp-announce-set"),
@@ -12347,7 +13874,7 @@ func (c *Client) NetworkingBgpAnnounceSetList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12390,7 +13917,7 @@ func (c *Client) NetworkingBgpAnnounceSetUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_announce_set_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/networking/bgp-announce-set"),
@@ -12402,7 +13929,7 @@ func (c *Client) NetworkingBgpAnnounceSetUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12437,7 +13964,7 @@ func (c *Client) NetworkingBgpAnnounceSetDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_announce_set_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/networking/bgp-announce-set/{{.announce_set}}"),
@@ -12451,7 +13978,7 @@ func (c *Client) NetworkingBgpAnnounceSetDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -12475,7 +14002,7 @@ func (c *Client) NetworkingBgpAnnouncementList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_announcement_list"),
 		nil,
 		"GET",
 		resolveRelative(
@@ -12492,7 +14019,7 @@ func (c *Client) NetworkingBgpAnnouncementList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12521,7 +14048,7 @@ func (c *Client) NetworkingBgpAnnouncementList(
 func (c *Client) NetworkingBgpExported(ctx context.Context) (*[]BgpExported, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_exported"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/bgp-exported"),
@@ -12533,7 +14060,7 @@ func (c *Client) NetworkingBgpExported(ctx context.Context) (*[]BgpExported, err
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12568,7 +14095,7 @@ func (c *Client) NetworkingBgpImported(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_imported"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/bgp-imported"),
@@ -12582,7 +14109,7 @@ func (c *Client) NetworkingBgpImported(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12617,7 +14144,7 @@ func (c *Client) NetworkingBgpMessageHistory(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_message_history"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/bgp-message-history"),
@@ -12631,7 +14158,7 @@ func (c *Client) NetworkingBgpMessageHistory(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12660,7 +14187,7 @@ func (c *Client) NetworkingBgpMessageHistory(
 func (c *Client) NetworkingBgpStatus(ctx context.Context) (*[]BgpPeerStatus, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_bgp_status"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/bgp-status"),
@@ -12672,7 +14199,7 @@ func (c *Client) NetworkingBgpStatus(ctx context.Context) (*[]BgpPeerStatus, err
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12701,7 +14228,7 @@ func (c *Client) NetworkingBgpStatus(ctx context.Context) (*[]BgpPeerStatus, err
 func (c *Client) NetworkingInboundIcmpView(ctx context.Context) (*ServiceIcmpConfig, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_inbound_icmp_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/inbound-icmp"),
@@ -12713,7 +14240,7 @@ func (c *Client) NetworkingInboundIcmpView(ctx context.Context) (*ServiceIcmpCon
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12754,7 +14281,7 @@ func (c *Client) NetworkingInboundIcmpUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_inbound_icmp_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/networking/inbound-icmp"),
@@ -12766,7 +14293,7 @@ func (c *Client) NetworkingInboundIcmpUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -12782,7 +14309,9 @@ func (c *Client) NetworkingInboundIcmpUpdate(
 
 // NetworkingLoopbackAddressList: List loopback addresses
 //
-// To iterate over all pages, use the `NetworkingLoopbackAddressListAllPages` method, instead.
+// To iterate over all pages, use the `NetworkingLoopbackAddressListIter` or
+// `NetworkingLoopbackAddressListAllPages`
+// methods, instead.
 func (c *Client) NetworkingLoopbackAddressList(
 	ctx context.Context,
 	params NetworkingLoopbackAddressListParams,
@@ -12792,7 +14321,7 @@ func (c *Client) NetworkingLoopbackAddressList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_loopback_address_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/loopback-address"),
@@ -12808,7 +14337,7 @@ func (c *Client) NetworkingLoopbackAddressList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12862,6 +14391,31 @@ func (c *Client) NetworkingLoopbackAddressListAllPages(
 	return allPages, nil
 }
 
+// NetworkingLoopbackAddressListIter: List loopback addresses
+//
+// This method is a wrapper around the `NetworkingLoopbackAddressList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) NetworkingLoopbackAddressListIter(
+	ctx context.Context,
+	params NetworkingLoopbackAddressListParams,
+	opts ...ListOption,
+) iter.Seq2[LoopbackAddress, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]LoopbackAddress, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.NetworkingLoopbackAddressList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // NetworkingLoopbackAddressCreate: Create loopback address
 func (c *Client) NetworkingLoopbackAddressCreate(
 	ctx context.Context,
@@ -12878,7 +14432,7 @@ func (c *Client) NetworkingLoopbackAddressCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_loopback_address_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/networking/loopback-address"),
@@ -12890,7 +14444,7 @@ func (c *Client) NetworkingLoopbackAddressCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -12925,7 +14479,7 @@ func (c *Client) NetworkingLoopbackAddressDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_loopback_address_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(
@@ -12945,7 +14499,7 @@ func (c *Client) NetworkingLoopbackAddressDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -12965,7 +14519,7 @@ func (c *Client) SystemNetworkingSettingsView(
 ) (*SystemNetworkingSettings, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_networking_settings_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/settings"),
@@ -12977,7 +14531,7 @@ func (c *Client) SystemNetworkingSettingsView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13018,7 +14572,7 @@ func (c *Client) SystemNetworkingSettingsUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_networking_settings_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/networking/settings"),
@@ -13030,7 +14584,7 @@ func (c *Client) SystemNetworkingSettingsUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13057,7 +14611,9 @@ func (c *Client) SystemNetworkingSettingsUpdate(
 
 // NetworkingSwitchPortSettingsList: List switch port settings
 //
-// To iterate over all pages, use the `NetworkingSwitchPortSettingsListAllPages` method, instead.
+// To iterate over all pages, use the `NetworkingSwitchPortSettingsListIter` or
+// `NetworkingSwitchPortSettingsListAllPages`
+// methods, instead.
 func (c *Client) NetworkingSwitchPortSettingsList(
 	ctx context.Context,
 	params NetworkingSwitchPortSettingsListParams,
@@ -13067,7 +14623,7 @@ func (c *Client) NetworkingSwitchPortSettingsList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_settings_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/switch-port-settings"),
@@ -13084,7 +14640,7 @@ func (c *Client) NetworkingSwitchPortSettingsList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13138,6 +14694,31 @@ func (c *Client) NetworkingSwitchPortSettingsListAllPages(
 	return allPages, nil
 }
 
+// NetworkingSwitchPortSettingsListIter: List switch port settings
+//
+// This method is a wrapper around the `NetworkingSwitchPortSettingsList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) NetworkingSwitchPortSettingsListIter(
+	ctx context.Context,
+	params NetworkingSwitchPortSettingsListParams,
+	opts ...ListOption,
+) iter.Seq2[SwitchPortSettingsIdentity, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SwitchPortSettingsIdentity, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.NetworkingSwitchPortSettingsList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // NetworkingSwitchPortSettingsCreate: Create switch port settings
 func (c *Client) NetworkingSwitchPortSettingsCreate(
 	ctx context.Context,
@@ -13154,7 +14735,7 @@ func (c *Client) NetworkingSwitchPortSettingsCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_settings_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/networking/switch-port-settings"),
@@ -13166,7 +14747,7 @@ func (c *Client) NetworkingSwitchPortSettingsCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13201,7 +14782,7 @@ func (c *Client) NetworkingSwitchPortSettingsDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_settings_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/networking/switch-port-settings"),
@@ -13215,7 +14796,7 @@ func (c *Client) NetworkingSwitchPortSettingsDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -13239,7 +14820,7 @@ func (c *Client) NetworkingSwitchPortSettingsView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "networking_switch_port_settings_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/networking/switch-port-settings/{{.port}}"),
@@ -13253,7 +14834,7 @@ func (c *Client) NetworkingSwitchPortSettingsView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13282,7 +14863,7 @@ func (c *Client) NetworkingSwitchPortSettingsView(
 func (c *Client) SystemPolicyView(ctx context.Context) (*FleetRolePolicy, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_policy_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/policy"),
@@ -13294,7 +14875,7 @@ func (c *Client) SystemPolicyView(ctx context.Context) (*FleetRolePolicy, error)
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13335,7 +14916,7 @@ func (c *Client) SystemPolicyUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_policy_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/policy"),
@@ -13347,7 +14928,7 @@ func (c *Client) SystemPolicyUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13383,7 +14964,7 @@ func (c *Client) ScimTokenList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "scim_token_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/scim/tokens"),
@@ -13397,7 +14978,7 @@ func (c *Client) ScimTokenList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13435,7 +15016,7 @@ func (c *Client) ScimTokenCreate(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "scim_token_create"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/system/scim/tokens"),
@@ -13449,7 +15030,7 @@ func (c *Client) ScimTokenCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13485,7 +15066,7 @@ func (c *Client) ScimTokenView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "scim_token_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/scim/tokens/{{.token_id}}"),
@@ -13501,7 +15082,7 @@ func (c *Client) ScimTokenView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13534,7 +15115,7 @@ func (c *Client) ScimTokenDelete(ctx context.Context, params ScimTokenDeletePara
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "scim_token_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/scim/tokens/{{.token_id}}"),
@@ -13550,7 +15131,7 @@ func (c *Client) ScimTokenDelete(ctx context.Context, params ScimTokenDeletePara
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -13566,7 +15147,8 @@ func (c *Client) ScimTokenDelete(ctx context.Context, params ScimTokenDeletePara
 
 // SystemQuotasList: List resource quotas for all silos
 //
-// To iterate over all pages, use the `SystemQuotasListAllPages` method, instead.
+// To iterate over all pages, use the `SystemQuotasListIter` or `SystemQuotasListAllPages`
+// methods, instead.
 func (c *Client) SystemQuotasList(
 	ctx context.Context,
 	params SystemQuotasListParams,
@@ -13576,7 +15158,7 @@ func (c *Client) SystemQuotasList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_quotas_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/silo-quotas"),
@@ -13592,7 +15174,7 @@ func (c *Client) SystemQuotasList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13646,17 +15228,43 @@ func (c *Client) SystemQuotasListAllPages(
 	return allPages, nil
 }
 
+// SystemQuotasListIter: List resource quotas for all silos
+//
+// This method is a wrapper around the `SystemQuotasList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemQuotasListIter(
+	ctx context.Context,
+	params SystemQuotasListParams,
+	opts ...ListOption,
+) iter.Seq2[SiloQuotas, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SiloQuotas, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemQuotasList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SiloList: List silos
 // Lists silos that are discoverable based on the current permissions.
 //
-// To iterate over all pages, use the `SiloListAllPages` method, instead.
+// To iterate over all pages, use the `SiloListIter` or `SiloListAllPages`
+// methods, instead.
 func (c *Client) SiloList(ctx context.Context, params SiloListParams) (*SiloResultsPage, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/silos"),
@@ -13672,7 +15280,7 @@ func (c *Client) SiloList(ctx context.Context, params SiloListParams) (*SiloResu
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13724,6 +15332,32 @@ func (c *Client) SiloListAllPages(ctx context.Context, params SiloListParams) ([
 	return allPages, nil
 }
 
+// SiloListIter: List silos
+// Lists silos that are discoverable based on the current permissions.
+//
+// This method is a wrapper around the `SiloList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SiloListIter(
+	ctx context.Context,
+	params SiloListParams,
+	opts ...ListOption,
+) iter.Seq2[Silo, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Silo, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SiloList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SiloCreate: Create silo
 func (c *Client) SiloCreate(ctx context.Context, params SiloCreateParams) (*Silo, error) {
 	if err := params.Validate(); err != nil {
@@ -13737,7 +15371,7 @@ func (c *Client) SiloCreate(ctx context.Context, params SiloCreateParams) (*Silo
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/silos"),
@@ -13749,7 +15383,7 @@ func (c *Client) SiloCreate(ctx context.Context, params SiloCreateParams) (*Silo
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13782,7 +15416,7 @@ func (c *Client) SiloView(ctx context.Context, params SiloViewParams) (*Silo, er
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/silos/{{.silo}}"),
@@ -13796,7 +15430,7 @@ func (c *Client) SiloView(ctx context.Context, params SiloViewParams) (*Silo, er
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13829,7 +15463,7 @@ func (c *Client) SiloDelete(ctx context.Context, params SiloDeleteParams) error
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/silos/{{.silo}}"),
@@ -13843,7 +15477,7 @@ func (c *Client) SiloDelete(ctx context.Context, params SiloDeleteParams) error
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -13862,7 +15496,8 @@ func (c *Client) SiloDelete(ctx context.Context, params SiloDeleteParams) error
 // pool. IPs
 // are allocated from the default pool when users ask for one without specifying a pool.
 //
-// To iterate over all pages, use the `SiloIpPoolListAllPages` method, instead.
+// To iterate over all pages, use the `SiloIpPoolListIter` or `SiloIpPoolListAllPages`
+// methods, instead.
 func (c *Client) SiloIpPoolList(
 	ctx context.Context,
 	params SiloIpPoolListParams,
@@ -13872,7 +15507,7 @@ func (c *Client) SiloIpPoolList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_ip_pool_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/silos/{{.silo}}/ip-pools"),
@@ -13890,7 +15525,7 @@ func (c *Client) SiloIpPoolList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -13947,6 +15582,34 @@ func (c *Client) SiloIpPoolListAllPages(
 	return allPages, nil
 }
 
+// SiloIpPoolListIter: List IP pools linked to silo
+// Linked IP pools are available to users in the specified silo. A silo can have at most one default
+// pool. IPs
+// are allocated from the default pool when users ask for one without specifying a pool.
+//
+// This method is a wrapper around the `SiloIpPoolList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SiloIpPoolListIter(
+	ctx context.Context,
+	params SiloIpPoolListParams,
+	opts ...ListOption,
+) iter.Seq2[SiloIpPool, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SiloIpPool, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SiloIpPoolList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SiloPolicyView: Fetch silo IAM policy
 func (c *Client) SiloPolicyView(
 	ctx context.Context,
@@ -13957,7 +15620,7 @@ func (c *Client) SiloPolicyView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_policy_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/silos/{{.silo}}/policy"),
@@ -13971,7 +15634,7 @@ func (c *Client) SiloPolicyView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14012,7 +15675,7 @@ func (c *Client) SiloPolicyUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_policy_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/silos/{{.silo}}/policy"),
@@ -14026,7 +15689,7 @@ func (c *Client) SiloPolicyUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14061,7 +15724,7 @@ func (c *Client) SiloQuotasView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_quotas_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/silos/{{.silo}}/quotas"),
@@ -14075,7 +15738,7 @@ func (c *Client) SiloQuotasView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14117,7 +15780,7 @@ func (c *Client) SiloQuotasUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_quotas_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/silos/{{.silo}}/quotas"),
@@ -14131,7 +15794,7 @@ func (c *Client) SiloQuotasUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14158,7 +15821,8 @@ func (c *Client) SiloQuotasUpdate(
 
 // SiloSubnetPoolList: List subnet pools linked to a silo
 //
-// To iterate over all pages, use the `SiloSubnetPoolListAllPages` method, instead.
+// To iterate over all pages, use the `SiloSubnetPoolListIter` or `SiloSubnetPoolListAllPages`
+// methods, instead.
 func (c *Client) SiloSubnetPoolList(
 	ctx context.Context,
 	params SiloSubnetPoolListParams,
@@ -14168,7 +15832,7 @@ func (c *Client) SiloSubnetPoolList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_subnet_pool_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/silos/{{.silo}}/subnet-pools"),
@@ -14186,7 +15850,7 @@ func (c *Client) SiloSubnetPoolList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14240,9 +15904,35 @@ func (c *Client) SiloSubnetPoolListAllPages(
 	return allPages, nil
 }
 
+// SiloSubnetPoolListIter: List subnet pools linked to a silo
+//
+// This method is a wrapper around the `SiloSubnetPoolList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SiloSubnetPoolListIter(
+	ctx context.Context,
+	params SiloSubnetPoolListParams,
+	opts ...ListOption,
+) iter.Seq2[SiloSubnetPool, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SiloSubnetPool, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SiloSubnetPoolList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemSubnetPoolList: List subnet pools
 //
-// To iterate over all pages, use the `SystemSubnetPoolListAllPages` method, instead.
+// To iterate over all pages, use the `SystemSubnetPoolListIter` or `SystemSubnetPoolListAllPages`
+// methods, instead.
 func (c *Client) SystemSubnetPoolList(
 	ctx context.Context,
 	params SystemSubnetPoolListParams,
@@ -14252,7 +15942,7 @@ func (c *Client) SystemSubnetPoolList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/subnet-pools"),
@@ -14268,7 +15958,7 @@ func (c *Client) SystemSubnetPoolList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14322,6 +16012,31 @@ func (c *Client) SystemSubnetPoolListAllPages(
 	return allPages, nil
 }
 
+// SystemSubnetPoolListIter: List subnet pools
+//
+// This method is a wrapper around the `SystemSubnetPoolList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemSubnetPoolListIter(
+	ctx context.Context,
+	params SystemSubnetPoolListParams,
+	opts ...ListOption,
+) iter.Seq2[SubnetPool, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SubnetPool, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemSubnetPoolList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemSubnetPoolCreate: Create subnet pool
 func (c *Client) SystemSubnetPoolCreate(
 	ctx context.Context,
@@ -14338,7 +16053,7 @@ func (c *Client) SystemSubnetPoolCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/subnet-pools"),
@@ -14350,7 +16065,7 @@ func (c *Client) SystemSubnetPoolCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14385,7 +16100,7 @@ func (c *Client) SystemSubnetPoolView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}"),
@@ -14399,7 +16114,7 @@ func (c *Client) SystemSubnetPoolView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14440,7 +16155,7 @@ func (c *Client) SystemSubnetPoolUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}"),
@@ -14454,7 +16169,7 @@ func (c *Client) SystemSubnetPoolUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14489,7 +16204,7 @@ func (c *Client) SystemSubnetPoolDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}"),
@@ -14503,7 +16218,7 @@ func (c *Client) SystemSubnetPoolDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -14519,7 +16234,9 @@ func (c *Client) SystemSubnetPoolDelete(
 
 // SystemSubnetPoolMemberList: List members in subnet pool
 //
-// To iterate over all pages, use the `SystemSubnetPoolMemberListAllPages` method, instead.
+// To iterate over all pages, use the `SystemSubnetPoolMemberListIter` or
+// `SystemSubnetPoolMemberListAllPages`
+// methods, instead.
 func (c *Client) SystemSubnetPoolMemberList(
 	ctx context.Context,
 	params SystemSubnetPoolMemberListParams,
@@ -14529,7 +16246,7 @@ func (c *Client) SystemSubnetPoolMemberList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_member_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}/members"),
@@ -14546,7 +16263,7 @@ func (c *Client) SystemSubnetPoolMemberList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14600,6 +16317,31 @@ func (c *Client) SystemSubnetPoolMemberListAllPages(
 	return allPages, nil
 }
 
+// SystemSubnetPoolMemberListIter: List members in subnet pool
+//
+// This method is a wrapper around the `SystemSubnetPoolMemberList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemSubnetPoolMemberListIter(
+	ctx context.Context,
+	params SystemSubnetPoolMemberListParams,
+	opts ...ListOption,
+) iter.Seq2[SubnetPoolMember, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SubnetPoolMember, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemSubnetPoolMemberList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemSubnetPoolMemberAdd: Add member to subnet pool
 func (c *Client) SystemSubnetPoolMemberAdd(
 	ctx context.Context,
@@ -14616,7 +16358,7 @@ func (c *Client) SystemSubnetPoolMemberAdd(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_member_add"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}/members/add"),
@@ -14630,7 +16372,7 @@ func (c *Client) SystemSubnetPoolMemberAdd(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14671,7 +16413,7 @@ func (c *Client) SystemSubnetPoolMemberRemove(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_member_remove"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}/members/remove"),
@@ -14685,7 +16427,7 @@ func (c *Client) SystemSubnetPoolMemberRemove(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -14701,7 +16443,9 @@ func (c *Client) SystemSubnetPoolMemberRemove(
 
 // SystemSubnetPoolSiloList: List silos linked to subnet pool
 //
-// To iterate over all pages, use the `SystemSubnetPoolSiloListAllPages` method, instead.
+// To iterate over all pages, use the `SystemSubnetPoolSiloListIter` or
+// `SystemSubnetPoolSiloListAllPages`
+// methods, instead.
 func (c *Client) SystemSubnetPoolSiloList(
 	ctx context.Context,
 	params SystemSubnetPoolSiloListParams,
@@ -14711,7 +16455,7 @@ func (c *Client) SystemSubnetPoolSiloList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_silo_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}/silos"),
@@ -14729,7 +16473,7 @@ func (c *Client) SystemSubnetPoolSiloList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14783,6 +16527,31 @@ func (c *Client) SystemSubnetPoolSiloListAllPages(
 	return allPages, nil
 }
 
+// SystemSubnetPoolSiloListIter: List silos linked to subnet pool
+//
+// This method is a wrapper around the `SystemSubnetPoolSiloList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemSubnetPoolSiloListIter(
+	ctx context.Context,
+	params SystemSubnetPoolSiloListParams,
+	opts ...ListOption,
+) iter.Seq2[SubnetPoolSiloLink, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SubnetPoolSiloLink, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemSubnetPoolSiloList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemSubnetPoolSiloLink: Link subnet pool to silo
 func (c *Client) SystemSubnetPoolSiloLink(
 	ctx context.Context,
@@ -14799,7 +16568,7 @@ func (c *Client) SystemSubnetPoolSiloLink(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_silo_link"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}/silos"),
@@ -14813,7 +16582,7 @@ func (c *Client) SystemSubnetPoolSiloLink(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14854,7 +16623,7 @@ func (c *Client) SystemSubnetPoolSiloUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_silo_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}/silos/{{.silo}}"),
@@ -14869,7 +16638,7 @@ func (c *Client) SystemSubnetPoolSiloUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14904,7 +16673,7 @@ func (c *Client) SystemSubnetPoolSiloUnlink(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_silo_unlink"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}/silos/{{.silo}}"),
@@ -14919,7 +16688,7 @@ func (c *Client) SystemSubnetPoolSiloUnlink(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -14943,7 +16712,7 @@ func (c *Client) SystemSubnetPoolUtilizationView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_subnet_pool_utilization_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/subnet-pools/{{.pool}}/utilization"),
@@ -14957,7 +16726,7 @@ func (c *Client) SystemSubnetPoolUtilizationView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -14999,7 +16768,7 @@ func (c *Client) SystemTimeseriesQuery(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_timeseries_query"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/timeseries/query"),
@@ -15011,7 +16780,7 @@ func (c *Client) SystemTimeseriesQuery(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15038,7 +16807,9 @@ func (c *Client) SystemTimeseriesQuery(
 
 // SystemTimeseriesSchemaList: List timeseries schemas
 //
-// To iterate over all pages, use the `SystemTimeseriesSchemaListAllPages` method, instead.
+// To iterate over all pages, use the `SystemTimeseriesSchemaListIter` or
+// `SystemTimeseriesSchemaListAllPages`
+// methods, instead.
 func (c *Client) SystemTimeseriesSchemaList(
 	ctx context.Context,
 	params SystemTimeseriesSchemaListParams,
@@ -15048,7 +16819,7 @@ func (c *Client) SystemTimeseriesSchemaList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_timeseries_schema_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/timeseries/schemas"),
@@ -15063,7 +16834,7 @@ func (c *Client) SystemTimeseriesSchemaList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15117,6 +16888,31 @@ func (c *Client) SystemTimeseriesSchemaListAllPages(
 	return allPages, nil
 }
 
+// SystemTimeseriesSchemaListIter: List timeseries schemas
+//
+// This method is a wrapper around the `SystemTimeseriesSchemaList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemTimeseriesSchemaListIter(
+	ctx context.Context,
+	params SystemTimeseriesSchemaListParams,
+	opts ...ListOption,
+) iter.Seq2[TimeseriesSchema, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]TimeseriesSchema, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemTimeseriesSchemaList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemUpdateRecoveryFinish: Clear system recovery status
 // Instructs the system that a system recovery operation ("mupdate") was completed using the
 // software in the
@@ -15149,7 +16945,7 @@ func (c *Client) SystemUpdateRecoveryFinish(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_recovery_finish"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/update/recovery-finish"),
@@ -15161,7 +16957,7 @@ func (c *Client) SystemUpdateRecoveryFinish(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -15179,7 +16975,9 @@ func (c *Client) SystemUpdateRecoveryFinish(
 // Returns a paginated list of all TUF repositories ordered by system version (newest first by
 // default).
 //
-// To iterate over all pages, use the `SystemUpdateRepositoryListAllPages` method, instead.
+// To iterate over all pages, use the `SystemUpdateRepositoryListIter` or
+// `SystemUpdateRepositoryListAllPages`
+// methods, instead.
 func (c *Client) SystemUpdateRepositoryList(
 	ctx context.Context,
 	params SystemUpdateRepositoryListParams,
@@ -15189,7 +16987,7 @@ func (c *Client) SystemUpdateRepositoryList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_repository_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/update/repositories"),
@@ -15205,7 +17003,7 @@ func (c *Client) SystemUpdateRepositoryList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15261,6 +17059,33 @@ func (c *Client) SystemUpdateRepositoryListAllPages(
 	return allPages, nil
 }
 
+// SystemUpdateRepositoryListIter: List all TUF repositories
+// Returns a paginated list of all TUF repositories ordered by system version (newest first by
+// default).
+//
+// This method is a wrapper around the `SystemUpdateRepositoryList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemUpdateRepositoryListIter(
+	ctx context.Context,
+	params SystemUpdateRepositoryListParams,
+	opts ...ListOption,
+) iter.Seq2[TufRepo, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]TufRepo, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemUpdateRepositoryList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemUpdateRepositoryUpload: Upload system release repository
 // System release repositories are verified by the updates trust store.
 func (c *Client) SystemUpdateRepositoryUpload(
@@ -15274,7 +17099,7 @@ func (c *Client) SystemUpdateRepositoryUpload(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_repository_upload"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/update/repositories"),
@@ -15288,7 +17113,7 @@ func (c *Client) SystemUpdateRepositoryUpload(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15323,7 +17148,7 @@ func (c *Client) SystemUpdateRepositoryView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_repository_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/update/repositories/{{.system_version}}"),
@@ -15337,7 +17162,7 @@ func (c *Client) SystemUpdateRepositoryView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15367,7 +17192,7 @@ func (c *Client) SystemUpdateRepositoryView(
 func (c *Client) SystemUpdateStatus(ctx context.Context) (*UpdateStatus, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_status"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/update/status"),
@@ -15379,7 +17204,7 @@ func (c *Client) SystemUpdateStatus(ctx context.Context) (*UpdateStatus, error)
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15421,7 +17246,7 @@ func (c *Client) TargetReleaseUpdate(ctx context.Context, params TargetReleaseUp
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "target_release_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/system/update/target-release"),
@@ -15433,7 +17258,7 @@ func (c *Client) TargetReleaseUpdate(ctx context.Context, params TargetReleaseUp
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -15453,7 +17278,9 @@ func (c *Client) TargetReleaseUpdate(ctx context.Context, params TargetReleaseUp
 // metadata to be signed by keys trusted
 // by the trust store.
 //
-// To iterate over all pages, use the `SystemUpdateTrustRootListAllPages` method, instead.
+// To iterate over all pages, use the `SystemUpdateTrustRootListIter` or
+// `SystemUpdateTrustRootListAllPages`
+// methods, instead.
 func (c *Client) SystemUpdateTrustRootList(
 	ctx context.Context,
 	params SystemUpdateTrustRootListParams,
@@ -15463,7 +17290,7 @@ func (c *Client) SystemUpdateTrustRootList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_trust_root_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/update/trust-roots"),
@@ -15479,7 +17306,7 @@ func (c *Client) SystemUpdateTrustRootList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15537,6 +17364,35 @@ func (c *Client) SystemUpdateTrustRootListAllPages(
 	return allPages, nil
 }
 
+// SystemUpdateTrustRootListIter: List root roles in the updates trust store
+// A root role is a JSON document describing the cryptographic keys that are trusted to sign system
+// release repositories, as described by The Update Framework. Uploading a repository requires its
+// metadata to be signed by keys trusted
+// by the trust store.
+//
+// This method is a wrapper around the `SystemUpdateTrustRootList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SystemUpdateTrustRootListIter(
+	ctx context.Context,
+	params SystemUpdateTrustRootListParams,
+	opts ...ListOption,
+) iter.Seq2[UpdatesTrustRoot, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]UpdatesTrustRoot, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SystemUpdateTrustRootList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SystemUpdateTrustRootCreate: Add trusted root role to updates trust store
 func (c *Client) SystemUpdateTrustRootCreate(
 	ctx context.Context,
@@ -15553,7 +17409,7 @@ func (c *Client) SystemUpdateTrustRootCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_trust_root_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/system/update/trust-roots"),
@@ -15565,7 +17421,7 @@ func (c *Client) SystemUpdateTrustRootCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15600,7 +17456,7 @@ func (c *Client) SystemUpdateTrustRootView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_trust_root_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/update/trust-roots/{{.trust_root_id}}"),
@@ -15614,7 +17470,7 @@ func (c *Client) SystemUpdateTrustRootView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15652,7 +17508,7 @@ func (c *Client) SystemUpdateTrustRootDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "system_update_trust_root_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/system/update/trust-roots/{{.trust_root_id}}"),
@@ -15666,7 +17522,7 @@ func (c *Client) SystemUpdateTrustRootDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -15682,7 +17538,8 @@ func (c *Client) SystemUpdateTrustRootDelete(
 
 // SiloUserList: List built-in (system) users in silo
 //
-// To iterate over all pages, use the `SiloUserListAllPages` method, instead.
+// To iterate over all pages, use the `SiloUserListIter` or `SiloUserListAllPages`
+// methods, instead.
 func (c *Client) SiloUserList(
 	ctx context.Context,
 	params SiloUserListParams,
@@ -15692,7 +17549,7 @@ func (c *Client) SiloUserList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_user_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/users"),
@@ -15709,7 +17566,7 @@ func (c *Client) SiloUserList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15763,9 +17620,35 @@ func (c *Client) SiloUserListAllPages(
 	return allPages, nil
 }
 
+// SiloUserListIter: List built-in (system) users in silo
+//
+// This method is a wrapper around the `SiloUserList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SiloUserListIter(
+	ctx context.Context,
+	params SiloUserListParams,
+	opts ...ListOption,
+) iter.Seq2[User, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]User, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SiloUserList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // UserBuiltinList: List built-in users
 //
-// To iterate over all pages, use the `UserBuiltinListAllPages` method, instead.
+// To iterate over all pages, use the `UserBuiltinListIter` or `UserBuiltinListAllPages`
+// methods, instead.
 func (c *Client) UserBuiltinList(
 	ctx context.Context,
 	params UserBuiltinListParams,
@@ -15775,7 +17658,7 @@ func (c *Client) UserBuiltinList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "user_builtin_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/users-builtin"),
@@ -15791,7 +17674,7 @@ func (c *Client) UserBuiltinList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15845,6 +17728,31 @@ func (c *Client) UserBuiltinListAllPages(
 	return allPages, nil
 }
 
+// UserBuiltinListIter: List built-in users
+//
+// This method is a wrapper around the `UserBuiltinList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) UserBuiltinListIter(
+	ctx context.Context,
+	params UserBuiltinListParams,
+	opts ...ListOption,
+) iter.Seq2[UserBuiltin, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]UserBuiltin, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.UserBuiltinList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // UserBuiltinView: Fetch built-in user
 func (c *Client) UserBuiltinView(
 	ctx context.Context,
@@ -15855,7 +17763,7 @@ func (c *Client) UserBuiltinView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "user_builtin_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/users-builtin/{{.user}}"),
@@ -15869,7 +17777,7 @@ func (c *Client) UserBuiltinView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15901,7 +17809,7 @@ func (c *Client) SiloUserView(ctx context.Context, params SiloUserViewParams) (*
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_user_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/users/{{.user_id}}"),
@@ -15917,7 +17825,7 @@ func (c *Client) SiloUserView(ctx context.Context, params SiloUserViewParams) (*
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -15944,7 +17852,8 @@ func (c *Client) SiloUserView(ctx context.Context, params SiloUserViewParams) (*
 
 // SiloUtilizationList: List current utilization state for all silos
 //
-// To iterate over all pages, use the `SiloUtilizationListAllPages` method, instead.
+// To iterate over all pages, use the `SiloUtilizationListIter` or `SiloUtilizationListAllPages`
+// methods, instead.
 func (c *Client) SiloUtilizationList(
 	ctx context.Context,
 	params SiloUtilizationListParams,
@@ -15954,7 +17863,7 @@ func (c *Client) SiloUtilizationList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_utilization_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/utilization/silos"),
@@ -15970,7 +17879,7 @@ func (c *Client) SiloUtilizationList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16024,6 +17933,31 @@ func (c *Client) SiloUtilizationListAllPages(
 	return allPages, nil
 }
 
+// SiloUtilizationListIter: List current utilization state for all silos
+//
+// This method is a wrapper around the `SiloUtilizationList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) SiloUtilizationListIter(
+	ctx context.Context,
+	params SiloUtilizationListParams,
+	opts ...ListOption,
+) iter.Seq2[SiloUtilization, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]SiloUtilization, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.SiloUtilizationList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // SiloUtilizationView: Fetch current utilization for given silo
 func (c *Client) SiloUtilizationView(
 	ctx context.Context,
@@ -16034,7 +17968,7 @@ func (c *Client) SiloUtilizationView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "silo_utilization_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/system/utilization/silos/{{.silo}}"),
@@ -16048,7 +17982,7 @@ func (c *Client) SiloUtilizationView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16094,7 +18028,7 @@ func (c *Client) ExperimentalTimeseriesQuery(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "timeseries_query"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/timeseries/query"),
@@ -16108,7 +18042,7 @@ func (c *Client) ExperimentalTimeseriesQuery(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16135,14 +18069,15 @@ func (c *Client) ExperimentalTimeseriesQuery(
 
 // UserList: List users
 //
-// To iterate over all pages, use the `UserListAllPages` method, instead.
+// To iterate over all pages, use the `UserListIter` or `UserListAllPages`
+// methods, instead.
 func (c *Client) UserList(ctx context.Context, params UserListParams) (*UserResultsPage, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "user_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/users"),
@@ -16159,7 +18094,7 @@ func (c *Client) UserList(ctx context.Context, params UserListParams) (*UserResu
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16210,6 +18145,31 @@ func (c *Client) UserListAllPages(ctx context.Context, params UserListParams) ([
 	return allPages, nil
 }
 
+// UserListIter: List users
+//
+// This method is a wrapper around the `UserList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) UserListIter(
+	ctx context.Context,
+	params UserListParams,
+	opts ...ListOption,
+) iter.Seq2[User, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]User, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.UserList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // UserView: Fetch user
 func (c *Client) UserView(ctx context.Context, params UserViewParams) (*User, error) {
 	if err := params.Validate(); err != nil {
@@ -16217,7 +18177,7 @@ func (c *Client) UserView(ctx context.Context, params UserViewParams) (*User, er
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "user_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/users/{{.user_id}}"),
@@ -16231,7 +18191,7 @@ func (c *Client) UserView(ctx context.Context, params UserViewParams) (*User, er
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16258,7 +18218,8 @@ func (c *Client) UserView(ctx context.Context, params UserViewParams) (*User, er
 
 // UserTokenList: List user's access tokens
 //
-// To iterate over all pages, use the `UserTokenListAllPages` method, instead.
+// To iterate over all pages, use the `UserTokenListIter` or `UserTokenListAllPages`
+// methods, instead.
 func (c *Client) UserTokenList(
 	ctx context.Context,
 	params UserTokenListParams,
@@ -16268,7 +18229,7 @@ func (c *Client) UserTokenList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "user_token_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/users/{{.user_id}}/access-tokens"),
@@ -16286,7 +18247,7 @@ func (c *Client) UserTokenList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16340,6 +18301,31 @@ func (c *Client) UserTokenListAllPages(
 	return allPages, nil
 }
 
+// UserTokenListIter: List user's access tokens
+//
+// This method is a wrapper around the `UserTokenList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) UserTokenListIter(
+	ctx context.Context,
+	params UserTokenListParams,
+	opts ...ListOption,
+) iter.Seq2[DeviceAccessToken, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]DeviceAccessToken, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.UserTokenList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // UserLogout: Log user out
 // Silo admins can use this endpoint to log the specified user out by deleting all of their tokens
 // AND sessions. This
@@ -16350,7 +18336,7 @@ func (c *Client) UserLogout(ctx context.Context, params UserLogoutParams) error
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "user_logout"),
 		nil,
 		"POST",
 		resolveRelative(c.host, "/v1/users/{{.user_id}}/logout"),
@@ -16364,7 +18350,7 @@ func (c *Client) UserLogout(ctx context.Context, params UserLogoutParams) error
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -16380,7 +18366,8 @@ func (c *Client) UserLogout(ctx context.Context, params UserLogoutParams) error
 
 // UserSessionList: List user's console sessions
 //
-// To iterate over all pages, use the `UserSessionListAllPages` method, instead.
+// To iterate over all pages, use the `UserSessionListIter` or `UserSessionListAllPages`
+// methods, instead.
 func (c *Client) UserSessionList(
 	ctx context.Context,
 	params UserSessionListParams,
@@ -16390,7 +18377,7 @@ func (c *Client) UserSessionList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "user_session_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/users/{{.user_id}}/sessions"),
@@ -16408,7 +18395,7 @@ func (c *Client) UserSessionList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16462,11 +18449,36 @@ func (c *Client) UserSessionListAllPages(
 	return allPages, nil
 }
 
+// UserSessionListIter: List user's console sessions
+//
+// This method is a wrapper around the `UserSessionList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) UserSessionListIter(
+	ctx context.Context,
+	params UserSessionListParams,
+	opts ...ListOption,
+) iter.Seq2[ConsoleSession, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]ConsoleSession, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.UserSessionList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // UtilizationView: Fetch resource utilization for user's current silo
 func (c *Client) UtilizationView(ctx context.Context) (*Utilization, error) {
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "utilization_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/utilization"),
@@ -16478,7 +18490,7 @@ func (c *Client) UtilizationView(ctx context.Context) (*Utilization, error) {
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16513,7 +18525,7 @@ func (c *Client) VpcFirewallRulesView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_firewall_rules_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpc-firewall-rules"),
@@ -16528,7 +18540,7 @@ func (c *Client) VpcFirewallRulesView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16581,7 +18593,7 @@ func (c *Client) VpcFirewallRulesUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_firewall_rules_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/vpc-firewall-rules"),
@@ -16596,7 +18608,7 @@ func (c *Client) VpcFirewallRulesUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16624,7 +18636,8 @@ func (c *Client) VpcFirewallRulesUpdate(
 // VpcRouterRouteList: List routes
 // List the routes associated with a router in a particular VPC.
 //
-// To iterate over all pages, use the `VpcRouterRouteListAllPages` method, instead.
+// To iterate over all pages, use the `VpcRouterRouteListIter` or `VpcRouterRouteListAllPages`
+// methods, instead.
 func (c *Client) VpcRouterRouteList(
 	ctx context.Context,
 	params VpcRouterRouteListParams,
@@ -16634,7 +18647,7 @@ func (c *Client) VpcRouterRouteList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_route_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpc-router-routes"),
@@ -16653,7 +18666,7 @@ func (c *Client) VpcRouterRouteList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16708,6 +18721,32 @@ func (c *Client) VpcRouterRouteListAllPages(
 	return allPages, nil
 }
 
+// VpcRouterRouteListIter: List routes
+// List the routes associated with a router in a particular VPC.
+//
+// This method is a wrapper around the `VpcRouterRouteList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) VpcRouterRouteListIter(
+	ctx context.Context,
+	params VpcRouterRouteListParams,
+	opts ...ListOption,
+) iter.Seq2[RouterRoute, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]RouterRoute, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.VpcRouterRouteList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // VpcRouterRouteCreate: Create route
 func (c *Client) VpcRouterRouteCreate(
 	ctx context.Context,
@@ -16724,7 +18763,7 @@ func (c *Client) VpcRouterRouteCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_route_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/vpc-router-routes"),
@@ -16740,7 +18779,7 @@ func (c *Client) VpcRouterRouteCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16775,7 +18814,7 @@ func (c *Client) VpcRouterRouteView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_route_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpc-router-routes/{{.route}}"),
@@ -16793,7 +18832,7 @@ func (c *Client) VpcRouterRouteView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16834,7 +18873,7 @@ func (c *Client) VpcRouterRouteUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_route_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/vpc-router-routes/{{.route}}"),
@@ -16852,7 +18891,7 @@ func (c *Client) VpcRouterRouteUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -16887,7 +18926,7 @@ func (c *Client) VpcRouterRouteDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_route_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/vpc-router-routes/{{.route}}"),
@@ -16905,7 +18944,7 @@ func (c *Client) VpcRouterRouteDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -16921,7 +18960,8 @@ func (c *Client) VpcRouterRouteDelete(
 
 // VpcRouterList: List routers
 //
-// To iterate over all pages, use the `VpcRouterListAllPages` method, instead.
+// To iterate over all pages, use the `VpcRouterListIter` or `VpcRouterListAllPages`
+// methods, instead.
 func (c *Client) VpcRouterList(
 	ctx context.Context,
 	params VpcRouterListParams,
@@ -16931,7 +18971,7 @@ func (c *Client) VpcRouterList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpc-routers"),
@@ -16949,7 +18989,7 @@ func (c *Client) VpcRouterList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17003,6 +19043,31 @@ func (c *Client) VpcRouterListAllPages(
 	return allPages, nil
 }
 
+// VpcRouterListIter: List routers
+//
+// This method is a wrapper around the `VpcRouterList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) VpcRouterListIter(
+	ctx context.Context,
+	params VpcRouterListParams,
+	opts ...ListOption,
+) iter.Seq2[VpcRouter, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]VpcRouter, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.VpcRouterList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // VpcRouterCreate: Create VPC router
 func (c *Client) VpcRouterCreate(
 	ctx context.Context,
@@ -17019,7 +19084,7 @@ func (c *Client) VpcRouterCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/vpc-routers"),
@@ -17034,7 +19099,7 @@ func (c *Client) VpcRouterCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17069,7 +19134,7 @@ func (c *Client) VpcRouterView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpc-routers/{{.router}}"),
@@ -17086,7 +19151,7 @@ func (c *Client) VpcRouterView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17127,7 +19192,7 @@ func (c *Client) VpcRouterUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/vpc-routers/{{.router}}"),
@@ -17144,7 +19209,7 @@ func (c *Client) VpcRouterUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17176,7 +19241,7 @@ func (c *Client) VpcRouterDelete(ctx context.Context, params VpcRouterDeletePara
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_router_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/vpc-routers/{{.router}}"),
@@ -17193,7 +19258,7 @@ func (c *Client) VpcRouterDelete(ctx context.Context, params VpcRouterDeletePara
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -17209,7 +19274,8 @@ func (c *Client) VpcRouterDelete(ctx context.Context, params VpcRouterDeletePara
 
 // VpcSubnetList: List subnets
 //
-// To iterate over all pages, use the `VpcSubnetListAllPages` method, instead.
+// To iterate over all pages, use the `VpcSubnetListIter` or `VpcSubnetListAllPages`
+// methods, instead.
 func (c *Client) VpcSubnetList(
 	ctx context.Context,
 	params VpcSubnetListParams,
@@ -17219,7 +19285,7 @@ func (c *Client) VpcSubnetList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_subnet_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpc-subnets"),
@@ -17237,7 +19303,7 @@ func (c *Client) VpcSubnetList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17291,6 +19357,31 @@ func (c *Client) VpcSubnetListAllPages(
 	return allPages, nil
 }
 
+// VpcSubnetListIter: List subnets
+//
+// This method is a wrapper around the `VpcSubnetList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) VpcSubnetListIter(
+	ctx context.Context,
+	params VpcSubnetListParams,
+	opts ...ListOption,
+) iter.Seq2[VpcSubnet, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]VpcSubnet, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.VpcSubnetList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // VpcSubnetCreate: Create subnet
 func (c *Client) VpcSubnetCreate(
 	ctx context.Context,
@@ -17307,7 +19398,7 @@ func (c *Client) VpcSubnetCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_subnet_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/vpc-subnets"),
@@ -17322,7 +19413,7 @@ func (c *Client) VpcSubnetCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17357,7 +19448,7 @@ func (c *Client) VpcSubnetView(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_subnet_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpc-subnets/{{.subnet}}"),
@@ -17374,7 +19465,7 @@ func (c *Client) VpcSubnetView(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17415,7 +19506,7 @@ func (c *Client) VpcSubnetUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_subnet_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/vpc-subnets/{{.subnet}}"),
@@ -17432,7 +19523,7 @@ func (c *Client) VpcSubnetUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17464,7 +19555,7 @@ func (c *Client) VpcSubnetDelete(ctx context.Context, params VpcSubnetDeletePara
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_subnet_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/vpc-subnets/{{.subnet}}"),
@@ -17481,7 +19572,7 @@ func (c *Client) VpcSubnetDelete(ctx context.Context, params VpcSubnetDeletePara
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -17497,7 +19588,9 @@ func (c *Client) VpcSubnetDelete(ctx context.Context, params VpcSubnetDeletePara
 
 // VpcSubnetListNetworkInterfaces: List network interfaces
 //
-// To iterate over all pages, use the `VpcSubnetListNetworkInterfacesAllPages` method, instead.
+// To iterate over all pages, use the `VpcSubnetListNetworkInterfacesIter` or
+// `VpcSubnetListNetworkInterfacesAllPages`
+// methods, instead.
 func (c *Client) VpcSubnetListNetworkInterfaces(
 	ctx context.Context,
 	params VpcSubnetListNetworkInterfacesParams,
@@ -17507,7 +19600,7 @@ func (c *Client) VpcSubnetListNetworkInterfaces(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_subnet_list_network_interfaces"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpc-subnets/{{.subnet}}/network-interfaces"),
@@ -17527,7 +19620,7 @@ func (c *Client) VpcSubnetListNetworkInterfaces(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17581,16 +19674,42 @@ func (c *Client) VpcSubnetListNetworkInterfacesAllPages(
 	return allPages, nil
 }
 
+// VpcSubnetListNetworkInterfacesIter: List network interfaces
+//
+// This method is a wrapper around the `VpcSubnetListNetworkInterfaces` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) VpcSubnetListNetworkInterfacesIter(
+	ctx context.Context,
+	params VpcSubnetListNetworkInterfacesParams,
+	opts ...ListOption,
+) iter.Seq2[InstanceNetworkInterface, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]InstanceNetworkInterface, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.VpcSubnetListNetworkInterfaces(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // VpcList: List VPCs
 //
-// To iterate over all pages, use the `VpcListAllPages` method, instead.
+// To iterate over all pages, use the `VpcListIter` or `VpcListAllPages`
+// methods, instead.
 func (c *Client) VpcList(ctx context.Context, params VpcListParams) (*VpcResultsPage, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpcs"),
@@ -17607,7 +19726,7 @@ func (c *Client) VpcList(ctx context.Context, params VpcListParams) (*VpcResults
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17658,6 +19777,31 @@ func (c *Client) VpcListAllPages(ctx context.Context, params VpcListParams) ([]V
 	return allPages, nil
 }
 
+// VpcListIter: List VPCs
+//
+// This method is a wrapper around the `VpcList` method.
+// This method returns an iterator that fetches the pages lazily.
+func (c *Client) VpcListIter(
+	ctx context.Context,
+	params VpcListParams,
+	opts ...ListOption,
+) iter.Seq2[Vpc, error] {
+	return listIter(
+		params.PageToken,
+		params.Limit,
+		opts,
+		func(pageToken string, limit *int) ([]Vpc, string, error) {
+			params.PageToken = pageToken
+			params.Limit = limit
+			page, err := c.VpcList(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Items, page.NextPage, nil
+		},
+	)
+}
+
 // VpcCreate: Create VPC
 func (c *Client) VpcCreate(ctx context.Context, params VpcCreateParams) (*Vpc, error) {
 	if err := params.Validate(); err != nil {
@@ -17671,7 +19815,7 @@ func (c *Client) VpcCreate(ctx context.Context, params VpcCreateParams) (*Vpc, e
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/vpcs"),
@@ -17685,7 +19829,7 @@ func (c *Client) VpcCreate(ctx context.Context, params VpcCreateParams) (*Vpc, e
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17717,7 +19861,7 @@ func (c *Client) VpcView(ctx context.Context, params VpcViewParams) (*Vpc, error
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_view"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/vpcs/{{.vpc}}"),
@@ -17733,7 +19877,7 @@ func (c *Client) VpcView(ctx context.Context, params VpcViewParams) (*Vpc, error
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17771,7 +19915,7 @@ func (c *Client) VpcUpdate(ctx context.Context, params VpcUpdateParams) (*Vpc, e
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/vpcs/{{.vpc}}"),
@@ -17787,7 +19931,7 @@ func (c *Client) VpcUpdate(ctx context.Context, params VpcUpdateParams) (*Vpc, e
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17819,7 +19963,7 @@ func (c *Client) VpcDelete(ctx context.Context, params VpcDeleteParams) error {
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "vpc_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/vpcs/{{.vpc}}"),
@@ -17835,7 +19979,7 @@ func (c *Client) VpcDelete(ctx context.Context, params VpcDeleteParams) error {
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -17865,7 +20009,7 @@ func (c *Client) WebhookReceiverCreate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "webhook_receiver_create"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/webhook-receivers"),
@@ -17877,7 +20021,7 @@ func (c *Client) WebhookReceiverCreate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -17921,7 +20065,7 @@ func (c *Client) WebhookReceiverUpdate(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "webhook_receiver_update"),
 		b,
 		"PUT",
 		resolveRelative(c.host, "/v1/webhook-receivers/{{.receiver}}"),
@@ -17935,7 +20079,7 @@ func (c *Client) WebhookReceiverUpdate(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -17959,7 +20103,7 @@ func (c *Client) WebhookSecretsList(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "webhook_secrets_list"),
 		nil,
 		"GET",
 		resolveRelative(c.host, "/v1/webhook-secrets"),
@@ -17973,7 +20117,7 @@ func (c *Client) WebhookSecretsList(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -18014,7 +20158,7 @@ func (c *Client) WebhookSecretsAdd(
 
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "webhook_secrets_add"),
 		b,
 		"POST",
 		resolveRelative(c.host, "/v1/webhook-secrets"),
@@ -18028,7 +20172,7 @@ func (c *Client) WebhookSecretsAdd(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %v", err)
 	}
@@ -18063,7 +20207,7 @@ func (c *Client) WebhookSecretsDelete(
 	}
 	// Create the request
 	req, err := c.buildRequest(
-		ctx,
+		withOperationID(ctx, "webhook_secrets_delete"),
 		nil,
 		"DELETE",
 		resolveRelative(c.host, "/v1/webhook-secrets/{{.secret_id}}"),
@@ -18077,7 +20221,7 @@ func (c *Client) WebhookSecretsDelete(
 	}
 
 	// Send the request.
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("error sending request: %v", err)
 	}
@@ -0,0 +1,305 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/pelletier/go-toml"
+)
+
+// ErrProfileNotFound is returned when a profile doesn't exist in the credentials file.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile holds the credentials for an Oxide API host, as stored by the Oxide CLI in
+// credentials.toml.
+type Profile struct {
+	// Name is the name of the profile.
+	Name string
+
+	// Host is the base URL of the Oxide API (e.g., https://oxide.sys.example.com).
+	Host string
+
+	// Token is the API token.
+	Token string
+
+	// User is the ID of the user the token belongs to. Optional.
+	User string
+
+	// Time is when the token was obtained. Optional.
+	Time *time.Time
+
+	// CAFile is the path of a PEM file with the certificate authorities used to verify the host's
+	// TLS certificate. Relative paths are relative to the configuration directory. Optional.
+	CAFile string
+}
+
+// Profiles reads and writes the profiles stored in the credentials.toml and config.toml files of
+// the Oxide CLI, so that credentials provisioned with the SDK work with the CLI and vice versa.
+//
+// Files are written atomically with 0600 permissions. Unknown keys in both files are preserved.
+type Profiles struct {
+	configDir string
+}
+
+// NewProfiles returns a [Profiles] that manages the files in configDir. When configDir is empty,
+// the default directory of the Oxide CLI ($HOME/.config/oxide) is used.
+func NewProfiles(configDir string) (*Profiles, error) {
+	if configDir == "" {
+		var err error
+		configDir, err = defaultConfigDirPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Profiles{configDir: configDir}, nil
+}
+
+// List returns all profiles sorted by name. It returns an empty list when the credentials file
+// doesn't exist.
+func (p *Profiles) List() ([]Profile, error) {
+	tree, err := p.loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	profilesTree, _ := tree.Get("profile").(*toml.Tree)
+	if profilesTree == nil {
+		return []Profile{}, nil
+	}
+
+	names := profilesTree.Keys()
+	slices.Sort(names)
+
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		profile, err := profileFromTree(tree, name)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *profile)
+	}
+	return profiles, nil
+}
+
+// Get returns the profile with the given name, or [ErrProfileNotFound].
+func (p *Profiles) Get(name string) (*Profile, error) {
+	tree, err := p.loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return profileFromTree(tree, name)
+}
+
+// Set adds a profile, or replaces the fields of the profile with the same name. The unknown keys
+// of an existing profile are kept.
+func (p *Profiles) Set(profile Profile) error {
+	errs := make([]error, 0)
+	if profile.Name == "" {
+		errs = append(errs, errors.New("profile name is required"))
+	}
+	if profile.Host == "" {
+		errs = append(errs, errors.New("host is required"))
+	}
+	if profile.Token == "" {
+		errs = append(errs, errors.New("token is required"))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid profile:\n%w", err)
+	}
+
+	tree, err := p.loadCredentials()
+	if err != nil {
+		return err
+	}
+
+	// Update the fields in place so that the unknown keys of an existing profile are kept.
+	path := []string{"profile", profile.Name}
+	var timestamp string
+	if profile.Time != nil {
+		timestamp = profile.Time.UTC().Format(time.RFC3339Nano)
+	}
+	for _, field := range []struct{ key, value string }{
+		{"host", profile.Host},
+		{"token", profile.Token},
+		{"user", profile.User},
+		{"time", timestamp},
+		{"ca_file", profile.CAFile},
+	} {
+		keyPath := append(slices.Clone(path), field.key)
+		if field.value != "" {
+			tree.SetPath(keyPath, field.value)
+			continue
+		}
+		if tree.HasPath(keyPath) {
+			if err := tree.DeletePath(keyPath); err != nil {
+				return fmt.Errorf("failed to update profile %q: %w", profile.Name, err)
+			}
+		}
+	}
+
+	return writeTOMLFile(p.credentialsPath(), tree)
+}
+
+// Delete removes the profile with the given name, or returns [ErrProfileNotFound]. If the profile
+// is the default profile, the default is unset.
+func (p *Profiles) Delete(name string) error {
+	tree, err := p.loadCredentials()
+	if err != nil {
+		return err
+	}
+
+	path := []string{"profile", name}
+	if !tree.HasPath(path) {
+		return ErrProfileNotFound
+	}
+	if err := tree.DeletePath(path); err != nil {
+		return fmt.Errorf("failed to delete profile %q: %w", name, err)
+	}
+	if err := writeTOMLFile(p.credentialsPath(), tree); err != nil {
+		return err
+	}
+
+	defaultName, err := p.Default()
+	if err != nil || defaultName != name {
+		return err
+	}
+	config, err := loadTOMLFile(p.configPath())
+	if err != nil {
+		return err
+	}
+	if err := config.Delete("default-profile"); err != nil {
+		return fmt.Errorf("failed to unset default profile: %w", err)
+	}
+	return writeTOMLFile(p.configPath(), config)
+}
+
+// Default returns the name of the default profile. It returns an empty string when no default
+// profile is set.
+func (p *Profiles) Default() (string, error) {
+	config, err := loadTOMLFile(p.configPath())
+	if err != nil {
+		return "", err
+	}
+
+	name, _ := config.Get("default-profile").(string)
+	return name, nil
+}
+
+// SetDefault sets the default profile, which must exist, in config.toml.
+func (p *Profiles) SetDefault(name string) error {
+	if _, err := p.Get(name); err != nil {
+		return err
+	}
+
+	config, err := loadTOMLFile(p.configPath())
+	if err != nil {
+		return err
+	}
+	config.Set("default-profile", name)
+	return writeTOMLFile(p.configPath(), config)
+}
+
+// credentialsPath returns the path of the credentials file.
+func (p *Profiles) credentialsPath() string {
+	return filepath.Join(p.configDir, credentialsFile)
+}
+
+// configPath returns the path of the config file.
+func (p *Profiles) configPath() string {
+	return filepath.Join(p.configDir, configFile)
+}
+
+// loadCredentials loads the credentials file.
+func (p *Profiles) loadCredentials() (*toml.Tree, error) {
+	return loadTOMLFile(p.credentialsPath())
+}
+
+// profileFromTree reads the named profile from a parsed credentials file.
+func profileFromTree(tree *toml.Tree, name string) (*Profile, error) {
+	profileTree, ok := tree.GetPath([]string{"profile", name}).(*toml.Tree)
+	if !ok {
+		return nil, ErrProfileNotFound
+	}
+
+	profile := &Profile{Name: name}
+	profile.Host, _ = profileTree.Get("host").(string)
+	profile.Token, _ = profileTree.Get("token").(string)
+	profile.User, _ = profileTree.Get("user").(string)
+	profile.CAFile, _ = profileTree.Get("ca_file").(string)
+
+	// The Oxide CLI stores the time as an RFC 3339 string, but accept TOML datetimes too.
+	switch t := profileTree.Get("time").(type) {
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time for profile %q: %w", name, err)
+		}
+		profile.Time = &parsed
+	case time.Time:
+		profile.Time = &t
+	}
+
+	return profile, nil
+}
+
+// loadTOMLFile parses the TOML file at path. A missing file is treated as an empty one.
+func loadTOMLFile(path string) (*toml.Tree, error) {
+	tree, err := toml.LoadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return toml.TreeFromMap(map[string]any{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q: %w", path, err)
+	}
+	return tree, nil
+}
+
+// writeTOMLFile atomically replaces the file at path with the contents of tree. The file is
+// written with 0600 permissions since it may contain credentials.
+func writeTOMLFile(path string, tree *toml.Tree) error {
+	contents, err := tree.ToTomlString()
+	if err != nil {
+		return fmt.Errorf("failed to encode %q: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %q: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions on %q: %w", tmp.Name(), err)
+	}
+	if _, err := tmp.WriteString(contents); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %q: %w", tmp.Name(), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync %q: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %q: %w", tmp.Name(), err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %q: %w", path, err)
+	}
+	return nil
+}
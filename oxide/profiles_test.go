@@ -0,0 +1,167 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Profiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "oxide")
+	profiles, err := NewProfiles(dir)
+	require.NoError(t, err)
+
+	// Nothing exists yet.
+	list, err := profiles.List()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	defaultName, err := profiles.Default()
+	require.NoError(t, err)
+	assert.Empty(t, defaultName)
+	_, err = profiles.Get("prod")
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+
+	ts := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+	require.NoError(t, profiles.Set(Profile{
+		Name:  "prod",
+		Host:  "https://prod.example.com",
+		Token: "prod-token",
+		User:  "001de000-05e4-4000-8000-000000004007",
+		Time:  &ts,
+	}))
+	require.NoError(t, profiles.Set(Profile{
+		Name:   "dev",
+		Host:   "https://dev.example.com",
+		Token:  "dev-token",
+		CAFile: "dev-ca.pem",
+	}))
+
+	info, err := os.Stat(filepath.Join(dir, credentialsFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	list, err = profiles.List()
+	require.NoError(t, err)
+	assert.Equal(t, []Profile{
+		{Name: "dev", Host: "https://dev.example.com", Token: "dev-token", CAFile: "dev-ca.pem"},
+		{
+			Name:  "prod",
+			Host:  "https://prod.example.com",
+			Token: "prod-token",
+			User:  "001de000-05e4-4000-8000-000000004007",
+			Time:  &ts,
+		},
+	}, list)
+
+	// Updating a profile replaces it.
+	require.NoError(t, profiles.Set(Profile{
+		Name:  "prod",
+		Host:  "https://prod.example.com",
+		Token: "new-token",
+	}))
+	profile, err := profiles.Get("prod")
+	require.NoError(t, err)
+	assert.Equal(t, &Profile{
+		Name:  "prod",
+		Host:  "https://prod.example.com",
+		Token: "new-token",
+	}, profile)
+
+	// The client reads the profiles written here.
+	require.NoError(t, profiles.SetDefault("prod"))
+	info, err = os.Stat(filepath.Join(dir, configFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	client, err := NewClient(WithConfigDir(dir), WithDefaultProfile())
+	require.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com/", client.host)
+	assert.Equal(t, "new-token", client.token)
+
+	// Deleting the default profile unsets the default.
+	require.NoError(t, profiles.Delete("prod"))
+	defaultName, err = profiles.Default()
+	require.NoError(t, err)
+	assert.Empty(t, defaultName)
+	assert.ErrorIs(t, profiles.Delete("prod"), ErrProfileNotFound)
+
+	list, err = profiles.List()
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
+func Test_Profiles_preservesUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, credentialsFile), []byte(`
+[profile.existing]
+host = "https://existing.example.com"
+token = "existing-token"
+user = "existing-user"
+time = "2024-01-02T03:04:05.123456Z"
+region = "keep-me-too"
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFile), []byte(`
+default-profile = "existing"
+some-setting = "keep-me"
+`), 0o644))
+
+	profiles, err := NewProfiles(dir)
+	require.NoError(t, err)
+
+	existing, err := profiles.Get("existing")
+	require.NoError(t, err)
+	require.NotNil(t, existing.Time)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), *existing.Time)
+
+	require.NoError(t, profiles.Set(Profile{
+		Name:  "other",
+		Host:  "https://other.example.com",
+		Token: "other-token",
+	}))
+	require.NoError(t, profiles.SetDefault("other"))
+
+	_, err = profiles.Get("existing")
+	require.NoError(t, err)
+
+	config, err := os.ReadFile(filepath.Join(dir, configFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(config), `some-setting = "keep-me"`)
+	assert.Contains(t, string(config), `default-profile = "other"`)
+
+	// Replacing a profile keeps its unknown keys.
+	require.NoError(t, profiles.Set(Profile{
+		Name:  "existing",
+		Host:  "https://existing.example.com",
+		Token: "new-token",
+	}))
+	existing, err = profiles.Get("existing")
+	require.NoError(t, err)
+	assert.Equal(t, &Profile{
+		Name:  "existing",
+		Host:  "https://existing.example.com",
+		Token: "new-token",
+	}, existing)
+	credentials, err := os.ReadFile(filepath.Join(dir, credentialsFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(credentials), `region = "keep-me-too"`)
+}
+
+func Test_Profiles_errors(t *testing.T) {
+	profiles, err := NewProfiles(t.TempDir())
+	require.NoError(t, err)
+
+	assert.EqualError(
+		t,
+		profiles.Set(Profile{}),
+		"invalid profile:\nprofile name is required\nhost is required\ntoken is required",
+	)
+	assert.ErrorIs(t, profiles.SetDefault("missing"), ErrProfileNotFound)
+}
@@ -0,0 +1,361 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/oxidecomputer/oxide.go/internal/stage"
+)
+
+// defaultCascadeConcurrency is the number of actions of a stage that run concurrently.
+const defaultCascadeConcurrency = 4
+
+// DeleteProjectCascadeOptions configures DeleteProjectCascade.
+type DeleteProjectCascadeOptions struct {
+	// DryRun only plans the deletion: nothing is stopped or deleted, and the plan is written to
+	// Output.
+	DryRun bool
+
+	// Output is where the plan is written in dry-run mode. Defaults to os.Stdout.
+	Output io.Writer
+
+	// Concurrency is the number of actions of a stage that run concurrently. Defaults to 4.
+	Concurrency int
+
+	// Wait configures the polling of instances while they stop.
+	Wait []WaitOption
+}
+
+// CascadeAction is an action of a CascadePlan, such as deleting a disk.
+type CascadeAction struct {
+	// Verb is "stop" or "delete".
+	Verb string
+
+	// Kind is the kind of the resource, e.g. "instance" or "VPC subnet".
+	Kind string
+
+	// Name is the name of the resource.
+	Name string
+
+	// Vpc is the name of the VPC of the resource, for the resources of a VPC.
+	Vpc string
+
+	run func(ctx context.Context) error
+}
+
+// String describes the action, e.g. "delete VPC subnet default (VPC default)".
+func (a CascadeAction) String() string {
+	s := fmt.Sprintf("%s %s %s", a.Verb, a.Kind, a.Name)
+	if a.Vpc != "" {
+		s += fmt.Sprintf(" (VPC %s)", a.Vpc)
+	}
+	return s
+}
+
+// CascadePlan is the plan of the deletion of a project and of its resources. The actions of a
+// stage run concurrently, once all the actions of the previous stages have completed.
+type CascadePlan struct {
+	// Project is the project to delete.
+	Project NameOrId
+
+	// Stages are the stages of the deletion, in order. The last one deletes the project.
+	Stages [][]CascadeAction
+}
+
+// String describes the plan, one action per line.
+func (p *CascadePlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Plan to delete project %s:\n", p.Project)
+	for i, stage := range p.Stages {
+		fmt.Fprintf(&b, "stage %d:\n", i+1)
+		for _, action := range stage {
+			fmt.Fprintf(&b, "  %s\n", action)
+		}
+	}
+	return b.String()
+}
+
+// DeleteProjectCascade deletes a project along with all the resources that prevent its deletion:
+// instances, disks, snapshots, images, floating IPs, and VPCs with their subnets, custom routers
+// and internet gateways. The resources are discovered with the list endpoints.
+//
+// Running instances are stopped first, and the resources are then deleted in dependency order,
+// a stage at a time. Resources that are already gone are skipped, so an interrupted deletion can
+// be resumed by calling DeleteProjectCascade again. The deletion stops after the first stage that
+// fails, and the returned error joins the errors of the actions of that stage.
+//
+// The returned plan lists the actions, whether the deletion ran or not.
+func (c *Client) DeleteProjectCascade(
+	ctx context.Context,
+	project NameOrId,
+	opts *DeleteProjectCascadeOptions,
+) (*CascadePlan, error) {
+	if opts == nil {
+		opts = &DeleteProjectCascadeOptions{}
+	}
+	if project == "" {
+		return nil, errors.New("project must be set")
+	}
+
+	plan, err := c.planProjectDeletion(ctx, project, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		out := opts.Output
+		if out == nil {
+			out = os.Stdout
+		}
+		_, err := io.WriteString(out, plan.String())
+		return plan, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultCascadeConcurrency
+	}
+	for i, actions := range plan.Stages {
+		steps := make([]stage.Step, len(actions))
+		for j, action := range actions {
+			steps[j] = stage.Step{Name: action.String(), Run: action.run}
+		}
+		if err := stage.Run(ctx, steps, concurrency); err != nil {
+			return plan, fmt.Errorf(
+				"stage %d of the deletion of project %s failed: %w",
+				i+1,
+				project,
+				err,
+			)
+		}
+	}
+	return plan, nil
+}
+
+// planProjectDeletion discovers the resources of a project and plans their deletion.
+func (c *Client) planProjectDeletion(
+	ctx context.Context,
+	project NameOrId,
+	opts *DeleteProjectCascadeOptions,
+) (*CascadePlan, error) {
+	instances, err := c.InstanceListAllPages(ctx, InstanceListParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error listing instances: %w", err)
+	}
+	disks, err := c.DiskListAllPages(ctx, DiskListParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error listing disks: %w", err)
+	}
+	snapshots, err := c.SnapshotListAllPages(ctx, SnapshotListParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error listing snapshots: %w", err)
+	}
+	images, err := c.ImageListAllPages(ctx, ImageListParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error listing images: %w", err)
+	}
+	floatingIPs, err := c.FloatingIpListAllPages(ctx, FloatingIpListParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error listing floating IPs: %w", err)
+	}
+	vpcs, err := c.VpcListAllPages(ctx, VpcListParams{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("error listing VPCs: %w", err)
+	}
+
+	var stop, deleteInstances, deleteDetached, deleteSnapshots, deleteDisks []CascadeAction
+	for _, instance := range instances {
+		params := InstanceStopParams{Project: project, Instance: NameOrId(instance.Name)}
+		switch instance.RunState {
+		case InstanceStateStopped, InstanceStateFailed, InstanceStateDestroyed:
+		default:
+			stop = append(stop, CascadeAction{
+				Verb: "stop",
+				Kind: "instance",
+				Name: string(instance.Name),
+				run: func(ctx context.Context) error {
+					return c.StopInstanceAndWait(ctx, params, opts.Wait...)
+				},
+			})
+		}
+		deleteInstances = append(deleteInstances, cascadeDelete(
+			"instance", string(instance.Name), "",
+			func(ctx context.Context) error {
+				return c.InstanceDelete(ctx, InstanceDeleteParams{
+					Project:  project,
+					Instance: params.Instance,
+				})
+			},
+		))
+	}
+	for _, ip := range floatingIPs {
+		deleteDetached = append(deleteDetached, cascadeDelete(
+			"floating IP", string(ip.Name), "",
+			func(ctx context.Context) error {
+				return c.FloatingIpDelete(ctx, FloatingIpDeleteParams{
+					Project:    project,
+					FloatingIp: NameOrId(ip.Name),
+				})
+			},
+		))
+	}
+	for _, image := range images {
+		deleteDetached = append(deleteDetached, cascadeDelete(
+			"image", string(image.Name), "",
+			func(ctx context.Context) error {
+				return c.ImageDelete(ctx, ImageDeleteParams{
+					Project: project,
+					Image:   NameOrId(image.Name),
+				})
+			},
+		))
+	}
+	for _, snapshot := range snapshots {
+		deleteSnapshots = append(deleteSnapshots, cascadeDelete(
+			"snapshot", string(snapshot.Name), "",
+			func(ctx context.Context) error {
+				return c.SnapshotDelete(ctx, SnapshotDeleteParams{
+					Project:  project,
+					Snapshot: NameOrId(snapshot.Name),
+				})
+			},
+		))
+	}
+	for _, disk := range disks {
+		deleteDisks = append(deleteDisks, cascadeDelete(
+			"disk", string(disk.Name), "",
+			func(ctx context.Context) error {
+				return c.DiskDelete(ctx, DiskDeleteParams{
+					Project: project,
+					Disk:    NameOrId(disk.Name),
+				})
+			},
+		))
+	}
+
+	var deleteSubnets, deleteRouters, deleteVPCs []CascadeAction
+	for _, vpc := range vpcs {
+		vpcName := NameOrId(vpc.Name)
+
+		subnets, err := c.VpcSubnetListAllPages(ctx, VpcSubnetListParams{
+			Project: project,
+			Vpc:     vpcName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing subnets of VPC %s: %w", vpc.Name, err)
+		}
+		for _, subnet := range subnets {
+			deleteSubnets = append(deleteSubnets, cascadeDelete(
+				"VPC subnet", string(subnet.Name), string(vpc.Name),
+				func(ctx context.Context) error {
+					return c.VpcSubnetDelete(ctx, VpcSubnetDeleteParams{
+						Project: project,
+						Vpc:     vpcName,
+						Subnet:  NameOrId(subnet.Name),
+					})
+				},
+			))
+		}
+
+		gateways, err := c.InternetGatewayListAllPages(ctx, InternetGatewayListParams{
+			Project: project,
+			Vpc:     vpcName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing internet gateways of VPC %s: %w", vpc.Name, err)
+		}
+		for _, gateway := range gateways {
+			deleteSubnets = append(deleteSubnets, cascadeDelete(
+				"internet gateway", string(gateway.Name), string(vpc.Name),
+				func(ctx context.Context) error {
+					return c.InternetGatewayDelete(ctx, InternetGatewayDeleteParams{
+						Project: project,
+						Vpc:     vpcName,
+						Gateway: NameOrId(gateway.Name),
+						Cascade: NewPointer(true),
+					})
+				},
+			))
+		}
+
+		routers, err := c.VpcRouterListAllPages(ctx, VpcRouterListParams{
+			Project: project,
+			Vpc:     vpcName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing routers of VPC %s: %w", vpc.Name, err)
+		}
+		for _, router := range routers {
+			// The system router is deleted along with its VPC.
+			if router.Kind != VpcRouterKindCustom {
+				continue
+			}
+			deleteRouters = append(deleteRouters, cascadeDelete(
+				"VPC router", string(router.Name), string(vpc.Name),
+				func(ctx context.Context) error {
+					return c.VpcRouterDelete(ctx, VpcRouterDeleteParams{
+						Project: project,
+						Vpc:     vpcName,
+						Router:  NameOrId(router.Name),
+					})
+				},
+			))
+		}
+
+		deleteVPCs = append(deleteVPCs, cascadeDelete(
+			"VPC", string(vpc.Name), "",
+			func(ctx context.Context) error {
+				return c.VpcDelete(ctx, VpcDeleteParams{Project: project, Vpc: vpcName})
+			},
+		))
+	}
+
+	deleteProject := cascadeDelete("project", string(project), "", func(ctx context.Context) error {
+		return c.ProjectDelete(ctx, ProjectDeleteParams{Project: project})
+	})
+
+	plan := &CascadePlan{Project: project}
+	for _, stage := range [][]CascadeAction{
+		stop,
+		deleteInstances,
+		deleteDetached,
+		deleteSnapshots,
+		deleteDisks,
+		deleteSubnets,
+		deleteRouters,
+		deleteVPCs,
+		{deleteProject},
+	} {
+		if len(stage) > 0 {
+			plan.Stages = append(plan.Stages, stage)
+		}
+	}
+	return plan, nil
+}
+
+// cascadeDelete returns an action that deletes a resource with del. A resource that is already
+// gone counts as deleted.
+func cascadeDelete(
+	kind, name, vpc string,
+	del func(ctx context.Context) error,
+) CascadeAction {
+	return CascadeAction{
+		Verb: "delete",
+		Kind: kind,
+		Name: name,
+		Vpc:  vpc,
+		run: func(ctx context.Context) error {
+			if err := del(ctx); err != nil && !errors.Is(err, ErrObjectNotFound) {
+				return err
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,273 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProject serves the resources of the project "ci", and enforces the dependencies between
+// them: a resource can't be deleted while the resources that depend on it exist.
+type fakeProject struct {
+	mu sync.Mutex
+	// items holds the resources by collection, e.g. "disks", or "vpc-subnets/default" for the
+	// resources of a VPC.
+	items map[string][]map[string]any
+	// calls holds the stop and delete calls.
+	calls []string
+	// fail makes the deletion of the named resources fail.
+	fail map[string]bool
+}
+
+func newFakeProject(t *testing.T) (*Client, *fakeProject) {
+	t.Helper()
+
+	named := func(names ...string) []map[string]any {
+		var items []map[string]any
+		for _, name := range names {
+			items = append(items, map[string]any{"name": name})
+		}
+		return items
+	}
+	p := &fakeProject{
+		items: map[string][]map[string]any{
+			"instances": {
+				{"name": "web", "run_state": "running"},
+				{"name": "db", "run_state": "stopped"},
+			},
+			"disks":                     named("web-boot", "db-boot", "db-data"),
+			"snapshots":                 named("db-data-snap"),
+			"images":                    named("base-image"),
+			"floating-ips":              named("web-ip"),
+			"vpcs":                      named("default", "backend"),
+			"vpc-subnets/default":       named("default"),
+			"vpc-subnets/backend":       named("db-subnet", "cache-subnet"),
+			"internet-gateways/default": named("default"),
+			"vpc-routers/default":       {{"name": "system", "kind": "system"}},
+			"vpc-routers/backend": {
+				{"name": "system", "kind": "system"},
+				{"name": "egress", "kind": "custom"},
+			},
+		},
+		fail: make(map[string]bool),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("project") != "ci" && !strings.HasPrefix(r.URL.Path, "/v1/projects/") {
+			t.Errorf("request without project: %s", r.URL)
+		}
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+		collection := parts[0]
+		if vpc := r.URL.Query().Get("vpc"); vpc != "" {
+			collection += "/" + vpc
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && len(parts) == 1:
+			json.NewEncoder(w).Encode(map[string]any{"items": p.items[collection]})
+		case r.Method == http.MethodGet && len(parts) == 2:
+			p.view(w, collection, parts[1])
+		case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "stop":
+			p.calls = append(p.calls, "stop "+parts[1])
+			p.view(w, collection, parts[1])
+			p.find(collection, parts[1])["run_state"] = "stopped"
+		case r.Method == http.MethodDelete && len(parts) == 2:
+			p.delete(w, collection, parts[1])
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+	require.NoError(t, err)
+	return client, p
+}
+
+func (p *fakeProject) find(collection, name string) map[string]any {
+	for _, item := range p.items[collection] {
+		if item["name"] == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func (p *fakeProject) view(w http.ResponseWriter, collection, name string) {
+	item := p.find(collection, name)
+	if item == nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"request_id":"1","error_code":"ObjectNotFound","message":"not found"}`))
+		return
+	}
+	json.NewEncoder(w).Encode(item)
+}
+
+func (p *fakeProject) delete(w http.ResponseWriter, collection, name string) {
+	p.calls = append(p.calls, "delete "+collection+" "+name)
+
+	// dependents lists the collections whose resources prevent the deletion of a resource.
+	dependents := map[string][]string{
+		"floating-ips":      {"instances"},
+		"disks":             {"instances"},
+		"snapshots":         {"images"},
+		"vpc-subnets":       {"instances"},
+		"internet-gateways": {"instances"},
+		"vpc-routers":       {"vpc-subnets/" + strings.TrimPrefix(collection, "vpc-routers/")},
+		"vpcs": {
+			"instances",
+			"vpc-subnets/" + name,
+			"internet-gateways/" + name,
+		},
+		"projects": {
+			"instances", "disks", "snapshots", "images", "floating-ips", "vpcs",
+		},
+	}
+	conflict := p.fail[name]
+	for _, dependent := range dependents[strings.Split(collection, "/")[0]] {
+		conflict = conflict || len(p.items[dependent]) > 0
+	}
+	if collection == "instances" && p.find(collection, name)["run_state"] != "stopped" {
+		conflict = true
+	}
+	if collection == "vpcs" {
+		for _, router := range p.items["vpc-routers/"+name] {
+			conflict = conflict || router["kind"] == "custom"
+		}
+	}
+	if conflict {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(
+			w,
+			`{"request_id":"1","error_code":"InvalidRequest","message":"%s %s is in use"}`,
+			collection,
+			name,
+		)
+		return
+	}
+
+	if collection == "projects" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	i := slices.IndexFunc(p.items[collection], func(item map[string]any) bool {
+		return item["name"] == name
+	})
+	if i < 0 {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"request_id":"1","error_code":"ObjectNotFound","message":"not found"}`))
+		return
+	}
+	p.items[collection] = slices.Delete(p.items[collection], i, i+1)
+	if collection == "vpcs" {
+		delete(p.items, "vpc-routers/"+name)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func Test_DeleteProjectCascade(t *testing.T) {
+	ctx := context.Background()
+	wait := []WaitOption{WithWaitBackoff(time.Millisecond, time.Millisecond)}
+
+	t.Run("prints the plan in dry-run mode", func(t *testing.T) {
+		client, project := newFakeProject(t)
+
+		var out strings.Builder
+		plan, err := client.DeleteProjectCascade(ctx, "ci", &DeleteProjectCascadeOptions{
+			DryRun: true,
+			Output: &out,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `Plan to delete project ci:
+stage 1:
+  stop instance web
+stage 2:
+  delete instance web
+  delete instance db
+stage 3:
+  delete floating IP web-ip
+  delete image base-image
+stage 4:
+  delete snapshot db-data-snap
+stage 5:
+  delete disk web-boot
+  delete disk db-boot
+  delete disk db-data
+stage 6:
+  delete VPC subnet default (VPC default)
+  delete internet gateway default (VPC default)
+  delete VPC subnet db-subnet (VPC backend)
+  delete VPC subnet cache-subnet (VPC backend)
+stage 7:
+  delete VPC router egress (VPC backend)
+stage 8:
+  delete VPC default
+  delete VPC backend
+stage 9:
+  delete project ci
+`, out.String())
+		assert.Equal(t, out.String(), plan.String())
+		assert.Empty(t, project.calls)
+	})
+
+	t.Run("deletes the project", func(t *testing.T) {
+		client, project := newFakeProject(t)
+
+		_, err := client.DeleteProjectCascade(ctx, "ci", &DeleteProjectCascadeOptions{
+			Concurrency: 2,
+			Wait:        wait,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "stop web", project.calls[0])
+		assert.Equal(t, "delete projects ci", project.calls[len(project.calls)-1])
+		assert.Len(t, project.calls, 17)
+		for collection, items := range project.items {
+			assert.Empty(t, items, collection)
+		}
+	})
+
+	t.Run("stops after a stage fails", func(t *testing.T) {
+		client, project := newFakeProject(t)
+		project.fail["db-data"] = true
+		project.fail["web-boot"] = true
+
+		_, err := client.DeleteProjectCascade(ctx, "ci", &DeleteProjectCascadeOptions{
+			Wait: wait,
+		})
+		assert.ErrorContains(t, err, "stage 5 of the deletion of project ci failed")
+		assert.ErrorContains(t, err, "delete disk db-data: ")
+		assert.ErrorContains(t, err, "delete disk web-boot: ")
+		assert.NotContains(t, project.calls, "delete vpcs default")
+
+		// Deleting again resumes where the deletion stopped.
+		project.fail = map[string]bool{}
+		plan, err := client.DeleteProjectCascade(ctx, "ci", &DeleteProjectCascadeOptions{
+			Wait: wait,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "delete disk db-data", plan.Stages[0][1].String())
+	})
+
+	t.Run("requires a project", func(t *testing.T) {
+		client, _ := newFakeProject(t)
+
+		_, err := client.DeleteProjectCascade(ctx, "", nil)
+		assert.EqualError(t, err, "project must be set")
+	})
+}
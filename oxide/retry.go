@@ -0,0 +1,217 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// defaultRetryMaxAttempts is the number of attempts made when RetryPolicy.MaxAttempts is unset.
+	defaultRetryMaxAttempts = 4
+
+	// defaultRetryMinBackoff is the base delay used when RetryPolicy.MinBackoff is unset.
+	defaultRetryMinBackoff = 500 * time.Millisecond
+
+	// defaultRetryMaxBackoff is the delay cap used when RetryPolicy.MaxBackoff is unset.
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy configures how [Client] retries API calls that fail with transient errors, such as
+// a 503 Service Unavailable response, a connection reset, or a timeout. Zero values use sensible
+// defaults.
+//
+// Only idempotent methods (GET, HEAD, PUT, DELETE) are retried unless RetryPOST is set. Requests
+// whose body can't be rewound are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. Defaults to 4.
+	MaxAttempts int
+
+	// MinBackoff is the base delay before the first retry. The delay doubles with every attempt
+	// and is randomly jittered. Defaults to 500ms.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts. Defaults to 30s. A Retry-After header sent by
+	// the server takes precedence when it asks for a longer delay.
+	MaxBackoff time.Duration
+
+	// RetryPOST opts POST requests into retries. POST operations aren't idempotent, so only enable
+	// this when creating a duplicate resource is acceptable or detected by the caller (e.g., via
+	// ErrObjectAlreadyExists).
+	RetryPOST bool
+}
+
+// WithRetryPolicy enables automatic retries of transient API failures using the given policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		if policy.MaxAttempts < 0 {
+			return errors.New("retry policy max attempts must not be negative")
+		}
+		if policy.MinBackoff < 0 || policy.MaxBackoff < 0 {
+			return errors.New("retry policy backoff must not be negative")
+		}
+		if policy.MaxBackoff != 0 && policy.MinBackoff > policy.MaxBackoff {
+			return fmt.Errorf(
+				"retry policy min backoff %s exceeds max backoff %s",
+				policy.MinBackoff,
+				policy.MaxBackoff,
+			)
+		}
+		cfg.retryPolicy = &policy
+		return nil
+	})
+}
+
+// do sends req with client, retrying transient failures according to the policy. The returned
+// response is always the one from the last attempt.
+func (p *RetryPolicy) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	if !p.canRetry(req) {
+		return client.Do(req)
+	}
+
+	maxAttempts := p.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = defaultRetryMaxAttempts
+	}
+
+	ctx := req.Context()
+	attemptReq := req
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(attemptReq)
+
+		retryable, retryAfter := isRetryable(ctx, resp, err)
+		if !retryable || attempt >= maxAttempts {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(max(p.backoff(attempt), retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		attemptReq, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// canRetry reports whether req may be sent more than once.
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	case http.MethodPost:
+		if !p.RetryPOST {
+			return false
+		}
+	default:
+		return false
+	}
+
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// backoff returns the jittered delay to wait after the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	minBackoff := p.MinBackoff
+	if minBackoff == 0 {
+		minBackoff = defaultRetryMinBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+
+	d := minBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, maxBackoff)
+
+	// Use "equal jitter" so that retries are spread out but never happen immediately.
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// isRetryable classifies the outcome of a single attempt. When the response is retryable it also
+// returns the delay requested by the server via the Retry-After header, if any.
+func isRetryable(ctx context.Context, resp *http.Response, err error) (bool, time.Duration) {
+	if ctx.Err() != nil {
+		return false, 0
+	}
+	if err != nil {
+		return isTransientError(err), 0
+	}
+	if resp.StatusCode < http.StatusInternalServerError {
+		return false, 0
+	}
+
+	httpErr := peekHTTPError(resp)
+	if !errors.Is(httpErr, ErrServiceUnavailable) && !errors.Is(httpErr, ErrHTTP503) {
+		return false, 0
+	}
+	return true, parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+}
+
+// isTransientError reports whether err is a network failure that's worth retrying.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number of seconds or an
+// HTTP date. It returns 0 when the value is empty or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// rewindRequest returns a copy of req with a fresh body, ready to be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return r, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("error rewinding request body: %v", err)
+	}
+	r.Body = body
+	return r, nil
+}
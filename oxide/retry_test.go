@@ -0,0 +1,265 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serviceUnavailableBody = `{
+  "request_id": "37a8ed33-b7ad-43b0-b2ce-1171d03f5324",
+  "error_code": "ServiceNotAvailable",
+  "message": "Service Unavailable"
+}`
+
+// flakyServer returns a server that fails the first failures requests with the given status and
+// body, then responds with a project. It records the bodies of all requests it receives.
+func flakyServer(
+	t *testing.T,
+	failures int32,
+	status int,
+	body string,
+) (*httptest.Server, *atomic.Int32, *[]string) {
+	t.Helper()
+
+	var calls atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(b))
+
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte(`{"name":"my-project"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls, &bodies
+}
+
+func newRetryTestClient(t *testing.T, host string, policy RetryPolicy) *Client {
+	t.Helper()
+
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	client, err := NewClient(
+		WithHost(host),
+		WithToken("test-token"),
+		WithRetryPolicy(policy),
+	)
+	require.NoError(t, err)
+	return client
+}
+
+func Test_RetryPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries idempotent requests until success", func(t *testing.T) {
+		server, calls, _ := flakyServer(t, 2, http.StatusServiceUnavailable, serviceUnavailableBody)
+		client := newRetryTestClient(t, server.URL, RetryPolicy{})
+
+		project, err := client.ProjectView(ctx, ProjectViewParams{Project: "my-project"})
+		require.NoError(t, err)
+		assert.Equal(t, Name("my-project"), project.Name)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("returns the last error when attempts are exhausted", func(t *testing.T) {
+		server, calls, _ := flakyServer(t, 5, http.StatusServiceUnavailable, serviceUnavailableBody)
+		client := newRetryTestClient(t, server.URL, RetryPolicy{MaxAttempts: 2})
+
+		_, err := client.ProjectView(ctx, ProjectViewParams{Project: "my-project"})
+		assert.ErrorIs(t, err, ErrServiceUnavailable)
+		assert.ErrorIs(t, err, ErrHTTP503)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("does not retry other server errors", func(t *testing.T) {
+		server, calls, _ := flakyServer(
+			t,
+			1,
+			http.StatusInternalServerError,
+			`{"error_code":"Internal","message":"oops","request_id":"1"}`,
+		)
+		client := newRetryTestClient(t, server.URL, RetryPolicy{})
+
+		_, err := client.ProjectView(ctx, ProjectViewParams{Project: "my-project"})
+		assert.ErrorIs(t, err, ErrInternalError)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("does not retry POST by default", func(t *testing.T) {
+		server, calls, _ := flakyServer(t, 1, http.StatusServiceUnavailable, serviceUnavailableBody)
+		client := newRetryTestClient(t, server.URL, RetryPolicy{})
+
+		_, err := client.ProjectCreate(ctx, ProjectCreateParams{
+			Body: &ProjectCreate{Name: "my-project", Description: "test"},
+		})
+		assert.ErrorIs(t, err, ErrServiceUnavailable)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("resends the body of opted-in POST requests", func(t *testing.T) {
+		server, calls, bodies := flakyServer(
+			t,
+			1,
+			http.StatusServiceUnavailable,
+			serviceUnavailableBody,
+		)
+		client := newRetryTestClient(t, server.URL, RetryPolicy{RetryPOST: true})
+
+		_, err := client.ProjectCreate(ctx, ProjectCreateParams{
+			Body: &ProjectCreate{Name: "my-project", Description: "test"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+		require.Len(t, *bodies, 2)
+		assert.Equal(t, (*bodies)[0], (*bodies)[1])
+		assert.Contains(t, (*bodies)[1], `"name":"my-project"`)
+	})
+
+	t.Run("rewinds seekable bodies", func(t *testing.T) {
+		server, calls, bodies := flakyServer(
+			t,
+			1,
+			http.StatusServiceUnavailable,
+			serviceUnavailableBody,
+		)
+		client := newRetryTestClient(t, server.URL, RetryPolicy{})
+
+		resp, err := client.MakeRequest(ctx, Request{
+			Method: http.MethodPut,
+			Path:   "/v1/projects/my-project",
+			Body:   io.NewSectionReader(strings.NewReader("xxpayload"), 2, 7),
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, []string{"payload", "payload"}, *bodies)
+	})
+
+	t.Run("does not retry bodies that can't be rewound", func(t *testing.T) {
+		server, calls, _ := flakyServer(t, 1, http.StatusServiceUnavailable, serviceUnavailableBody)
+		client := newRetryTestClient(t, server.URL, RetryPolicy{})
+
+		resp, err := client.MakeRequest(ctx, Request{
+			Method: http.MethodPut,
+			Path:   "/v1/projects/my-project",
+			Body:   io.MultiReader(bytes.NewBufferString("payload")),
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		server, calls, _ := flakyServer(t, 5, http.StatusServiceUnavailable, serviceUnavailableBody)
+		client, err := NewClient(
+			WithHost(server.URL),
+			WithToken("test-token"),
+			WithRetryPolicy(RetryPolicy{MinBackoff: time.Hour, MaxBackoff: time.Hour}),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = client.ProjectView(ctx, ProjectViewParams{Project: "my-project"})
+		assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func Test_WithRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        RetryPolicy
+		expectedError string
+	}{
+		{
+			name:   "accepts zero policy",
+			policy: RetryPolicy{},
+		},
+		{
+			name:          "rejects negative attempts",
+			policy:        RetryPolicy{MaxAttempts: -1},
+			expectedError: "retry policy max attempts must not be negative",
+		},
+		{
+			name:          "rejects min backoff greater than max backoff",
+			policy:        RetryPolicy{MinBackoff: time.Minute, MaxBackoff: time.Second},
+			expectedError: "retry policy min backoff 1m0s exceeds max backoff 1s",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewClient(
+				WithHost("http://localhost"),
+				WithToken("foo"),
+				WithRetryPolicy(tc.policy),
+			)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: 0},
+		{value: "3", expected: 3 * time.Second},
+		{value: "-3", expected: 0},
+		{value: "Thu, 01 Jan 2026 00:00:10 GMT", expected: 10 * time.Second},
+		{value: "Wed, 31 Dec 2025 23:59:00 GMT", expected: 0},
+		{value: "soon", expected: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseRetryAfter(tc.value, now))
+		})
+	}
+}
+
+func Test_RetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, ceiling := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		8: time.Second,
+	} {
+		for range 20 {
+			d := policy.backoff(attempt)
+			assert.GreaterOrEqual(t, d, ceiling/2)
+			assert.LessOrEqual(t, d, ceiling)
+		}
+	}
+}
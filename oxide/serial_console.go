@@ -0,0 +1,158 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/coder/websocket"
+)
+
+// InstanceSerialConsoleConnect connects to the serial console of an instance. The returned
+// connection reads the output of the serial console and writes to its input. When
+// params.MostRecent is set, the connection first replays that many bytes of scrollback.
+//
+// The serial console is streamed over a websocket, which the generated
+// InstanceSerialConsoleStream method can't upgrade to. The connection is authenticated like
+// every other request, but it bypasses the client middleware, logging and retries.
+//
+// Reads return io.EOF once the server closes the connection normally. Canceling ctx closes the
+// connection, and pending and later reads and writes return the context error. Close the
+// connection to release its resources.
+func (c *Client) InstanceSerialConsoleConnect(
+	ctx context.Context,
+	params InstanceSerialConsoleStreamParams,
+) (io.ReadWriteCloser, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := c.buildRequest(
+		withOperationID(ctx, "instance_serial_console_stream"),
+		nil,
+		"GET",
+		resolveRelative(c.host, "/v1/instances/{{.instance}}/serial-console/stream"),
+		map[string]string{
+			"instance": string(params.Instance),
+		},
+		map[string]string{
+			"most_recent": PointerUint64ToStr(params.MostRecent),
+			"project":     string(params.Project),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %v", err)
+	}
+	// The websocket handshake sets its own headers.
+	req.Header.Del("Content-Type")
+
+	conn, resp, err := websocket.Dial(ctx, req.URL.String(), &websocket.DialOptions{
+		HTTPClient: c.client,
+		HTTPHeader: req.Header,
+	})
+	if err != nil {
+		if resp != nil && resp.Body != nil {
+			if httpErr := NewHTTPError(resp); httpErr != nil {
+				return nil, httpErr
+			}
+		}
+		return nil, fmt.Errorf("error connecting to serial console: %w", err)
+	}
+	// The serial console is a stream of unbounded length.
+	conn.SetReadLimit(-1)
+
+	return &serialConsoleConn{ctx: ctx, conn: conn}, nil
+}
+
+// serialConsoleConn adapts a serial console websocket to an io.ReadWriteCloser. The output of the
+// serial console is sent in binary messages. Other messages, such as migration notices, are
+// skipped.
+type serialConsoleConn struct {
+	ctx  context.Context
+	conn *websocket.Conn
+
+	// mu serializes reads, and msg is the message being read, if any.
+	mu  sync.Mutex
+	msg io.Reader
+}
+
+// Read reads the output of the serial console.
+func (s *serialConsoleConn) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		if s.msg == nil {
+			typ, msg, err := s.conn.Reader(s.ctx)
+			if err != nil {
+				return 0, s.closeError(err)
+			}
+			if typ != websocket.MessageBinary {
+				if _, err := io.Copy(io.Discard, msg); err != nil {
+					return 0, s.closeError(err)
+				}
+				continue
+			}
+			s.msg = msg
+		}
+
+		n, err := s.msg.Read(p)
+		if errors.Is(err, io.EOF) {
+			s.msg = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		if err != nil {
+			return n, s.closeError(err)
+		}
+		return n, nil
+	}
+}
+
+// Write writes p to the input of the serial console, in a single message.
+func (s *serialConsoleConn) Write(p []byte) (int, error) {
+	if err := s.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if err := s.conn.Write(s.ctx, websocket.MessageBinary, p); err != nil {
+		return 0, s.closeError(err)
+	}
+	return len(p), nil
+}
+
+// Close closes the connection with a normal closure. Once ctx is done, the connection is closed
+// without waiting for the server to acknowledge it.
+func (s *serialConsoleConn) Close() error {
+	if s.ctx.Err() != nil {
+		s.conn.CloseNow()
+		return nil
+	}
+	err := s.conn.Close(websocket.StatusNormalClosure, "")
+	if err != nil && websocket.CloseStatus(err) == websocket.StatusNormalClosure {
+		return nil
+	}
+	return err
+}
+
+// closeError translates an error of the websocket: a normal closure is the end of the stream,
+// and the context error takes precedence when ctx is done.
+func (s *serialConsoleConn) closeError(err error) error {
+	if ctxErr := s.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	switch websocket.CloseStatus(err) {
+	case websocket.StatusNormalClosure, websocket.StatusGoingAway:
+		return io.EOF
+	case -1:
+		return err
+	default:
+		return fmt.Errorf("serial console closed: %w", err)
+	}
+}
@@ -0,0 +1,138 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSerialConsoleServer returns a client for a server that accepts serial console websockets
+// and hands them to handle, along with the handshake request.
+func newSerialConsoleServer(
+	t *testing.T,
+	handle func(r *http.Request, conn *websocket.Conn),
+) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/instances/my-instance/serial-console/stream" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(
+				[]byte(`{"request_id":"1","error_code":"ObjectNotFound","message":"not found"}`),
+			)
+			return
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.CloseNow()
+		handle(r, conn)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+	require.NoError(t, err)
+	return client
+}
+
+func Test_InstanceSerialConsoleConnect(t *testing.T) {
+	ctx := context.Background()
+	params := InstanceSerialConsoleStreamParams{
+		Instance:   "my-instance",
+		Project:    "my-project",
+		MostRecent: NewPointer(uint64(4096)),
+	}
+
+	t.Run("streams the serial console", func(t *testing.T) {
+		var req *http.Request
+		client := newSerialConsoleServer(t, func(r *http.Request, conn *websocket.Conn) {
+			req = r
+			conn.Write(ctx, websocket.MessageBinary, []byte("login: "))
+			conn.Write(ctx, websocket.MessageText, []byte(`{"type":"migration"}`))
+
+			// Echo the input back.
+			_, input, err := conn.Read(ctx)
+			require.NoError(t, err)
+			conn.Write(ctx, websocket.MessageBinary, input)
+			conn.Close(websocket.StatusNormalClosure, "")
+		})
+
+		console, err := client.InstanceSerialConsoleConnect(ctx, params)
+		require.NoError(t, err)
+		defer console.Close()
+
+		assert.Equal(t, "Bearer foo", req.Header.Get("Authorization"))
+		assert.Equal(t, client.APIVersion(), req.Header.Get("API-Version"))
+		assert.Equal(t, "4096", req.URL.Query().Get("most_recent"))
+		assert.Equal(t, "my-project", req.URL.Query().Get("project"))
+
+		buf := make([]byte, 7)
+		_, err = io.ReadFull(console, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "login: ", string(buf))
+
+		_, err = console.Write([]byte("root\n"))
+		require.NoError(t, err)
+		output, err := io.ReadAll(console)
+		require.NoError(t, err)
+		assert.Equal(t, "root\n", string(output))
+	})
+
+	t.Run("reports abnormal closures", func(t *testing.T) {
+		client := newSerialConsoleServer(t, func(r *http.Request, conn *websocket.Conn) {
+			conn.Close(websocket.StatusInternalError, "instance stopped")
+		})
+
+		console, err := client.InstanceSerialConsoleConnect(ctx, params)
+		require.NoError(t, err)
+		defer console.Close()
+
+		_, err = console.Read(make([]byte, 1))
+		assert.ErrorContains(t, err, "serial console closed")
+		assert.ErrorContains(t, err, "instance stopped")
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		done := make(chan struct{})
+		client := newSerialConsoleServer(t, func(r *http.Request, conn *websocket.Conn) {
+			<-done
+		})
+		defer close(done)
+
+		ctx, cancel := context.WithCancel(ctx)
+		console, err := client.InstanceSerialConsoleConnect(ctx, params)
+		require.NoError(t, err)
+		defer console.Close()
+
+		errs := make(chan error)
+		go func() {
+			_, err := console.Read(make([]byte, 1))
+			errs <- err
+		}()
+		cancel()
+		assert.ErrorIs(t, <-errs, context.Canceled)
+
+		_, err = console.Write([]byte("x"))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("returns API errors", func(t *testing.T) {
+		client := newSerialConsoleServer(t, nil)
+
+		params := params
+		params.Instance = "other-instance"
+		_, err := client.InstanceSerialConsoleConnect(ctx, params)
+		assert.ErrorIs(t, err, ErrObjectNotFound)
+	})
+}
@@ -0,0 +1,385 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// supportBundlePath is the path of the content of a support bundle.
+	supportBundlePath = "/experimental/v1/system/support-bundles/{{.bundle_id}}/download"
+
+	// supportBundleFilePath is the path of a file of a support bundle.
+	supportBundleFilePath = "/experimental/v1/system/support-bundles/{{.bundle_id}}/download/{{.file}}"
+
+	// defaultDownloadResumes is the number of times a download is resumed after an interruption
+	// when DownloadOptions.MaxResumes is unset.
+	defaultDownloadResumes = 3
+)
+
+// DownloadOptions configures the downloads of support bundles and their files.
+type DownloadOptions struct {
+	// MaxResumes is the number of times an interrupted download is resumed from where it stopped
+	// with a Range request. Defaults to 3, and a negative value disables resuming.
+	MaxResumes int
+}
+
+func (o *DownloadOptions) maxResumes() int {
+	switch {
+	case o == nil || o.MaxResumes == 0:
+		return defaultDownloadResumes
+	case o.MaxResumes < 0:
+		return 0
+	default:
+		return o.MaxResumes
+	}
+}
+
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalSupportBundleSize returns the size of the content of a support bundle, in bytes,
+// with a HEAD request.
+func (c *Client) ExperimentalSupportBundleSize(
+	ctx context.Context,
+	params SupportBundleHeadParams,
+) (int64, error) {
+	if err := params.Validate(); err != nil {
+		return 0, err
+	}
+	return c.downloadSize(
+		withOperationID(ctx, "support_bundle_head"),
+		supportBundlePath,
+		map[string]string{"bundle_id": params.BundleId},
+	)
+}
+
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalSupportBundleFileSize returns the size of a file of a support bundle, in bytes, with
+// a HEAD request.
+func (c *Client) ExperimentalSupportBundleFileSize(
+	ctx context.Context,
+	params SupportBundleHeadFileParams,
+) (int64, error) {
+	if err := params.Validate(); err != nil {
+		return 0, err
+	}
+	return c.downloadSize(
+		withOperationID(ctx, "support_bundle_head_file"),
+		supportBundleFilePath,
+		map[string]string{"bundle_id": params.BundleId, "file": params.File},
+	)
+}
+
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalSupportBundleDownloadReader returns a reader over the content of a support bundle.
+//
+// The size of the content is read with a HEAD request first. An interrupted download is resumed
+// with a Range request, and reading fails with io.ErrUnexpectedEOF if the content is shorter than
+// announced. A single range, e.g. "bytes=1048576-" to resume a previous download, can be
+// requested with params.Range. The caller must close the reader.
+func (c *Client) ExperimentalSupportBundleDownloadReader(
+	ctx context.Context,
+	params SupportBundleDownloadParams,
+	opts *DownloadOptions,
+) (io.ReadCloser, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return c.openDownload(
+		ctx,
+		"support_bundle_head",
+		"support_bundle_download",
+		supportBundlePath,
+		map[string]string{"bundle_id": params.BundleId},
+		params.Range,
+		opts,
+	)
+}
+
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalSupportBundleDownloadToWriter writes the content of a support bundle to w, and
+// returns the number of bytes written. See ExperimentalSupportBundleDownloadReader.
+func (c *Client) ExperimentalSupportBundleDownloadToWriter(
+	ctx context.Context,
+	params SupportBundleDownloadParams,
+	w io.Writer,
+	opts *DownloadOptions,
+) (int64, error) {
+	r, err := c.ExperimentalSupportBundleDownloadReader(ctx, params, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(w, r)
+}
+
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalSupportBundleDownloadFileReader returns a reader over a file of a support bundle.
+// See ExperimentalSupportBundleDownloadReader.
+func (c *Client) ExperimentalSupportBundleDownloadFileReader(
+	ctx context.Context,
+	params SupportBundleDownloadFileParams,
+	opts *DownloadOptions,
+) (io.ReadCloser, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return c.openDownload(
+		ctx,
+		"support_bundle_head_file",
+		"support_bundle_download_file",
+		supportBundleFilePath,
+		map[string]string{"bundle_id": params.BundleId, "file": params.File},
+		params.Range,
+		opts,
+	)
+}
+
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalSupportBundleDownloadFileToWriter writes a file of a support bundle to w, and
+// returns the number of bytes written. See ExperimentalSupportBundleDownloadReader.
+func (c *Client) ExperimentalSupportBundleDownloadFileToWriter(
+	ctx context.Context,
+	params SupportBundleDownloadFileParams,
+	w io.Writer,
+	opts *DownloadOptions,
+) (int64, error) {
+	r, err := c.ExperimentalSupportBundleDownloadFileReader(ctx, params, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(w, r)
+}
+
+// downloadSize returns the size of the content at path with a HEAD request.
+func (c *Client) downloadSize(
+	ctx context.Context,
+	path string,
+	params map[string]string,
+) (int64, error) {
+	// buildRequest escapes the path parameters in place, so they are copied.
+	req, err := c.buildRequest(
+		ctx,
+		nil,
+		"HEAD",
+		resolveRelative(c.host, path),
+		maps.Clone(params),
+		nil,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error building request: %v", err)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+	if err := NewHTTPError(resp); err != nil {
+		return 0, err
+	}
+
+	if resp.ContentLength < 0 {
+		return 0, errors.New("response doesn't have a Content-Length header")
+	}
+	return resp.ContentLength, nil
+}
+
+// openDownload returns a reader over the content at path, limited to byteRange if it's set. The
+// size of the content is read with the headOp operation, and the content with the getOp operation.
+func (c *Client) openDownload(
+	ctx context.Context,
+	headOp, getOp string,
+	path string,
+	params map[string]string,
+	byteRange string,
+	opts *DownloadOptions,
+) (io.ReadCloser, error) {
+	size, err := c.downloadSize(withOperationID(ctx, headOp), path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := int64(0), size
+	if byteRange != "" {
+		if start, end, err = parseByteRange(byteRange, size); err != nil {
+			return nil, err
+		}
+	}
+
+	d := &download{
+		client:     c,
+		ctx:        withOperationID(ctx, getOp),
+		path:       path,
+		params:     params,
+		size:       size,
+		offset:     start,
+		end:        end,
+		maxResumes: opts.maxResumes(),
+	}
+	// An empty range, e.g. "bytes=100-" of 100 bytes, can't be requested: the server rejects it
+	// with 416 Range Not Satisfiable. There is nothing to read anyway.
+	if start == end {
+		return d, nil
+	}
+	if err := d.open(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// download reads the bytes [offset, end) of the content at path, and resumes reading with a
+// Range request when the response body is interrupted.
+type download struct {
+	client     *Client
+	ctx        context.Context
+	path       string
+	params     map[string]string
+	size       int64
+	offset     int64
+	end        int64
+	maxResumes int
+	resumes    int
+	body       io.ReadCloser
+}
+
+// open requests the bytes [offset, end) of the content.
+func (d *download) open() error {
+	req, err := d.client.buildRequest(
+		d.ctx,
+		nil,
+		"GET",
+		resolveRelative(d.client.host, d.path),
+		maps.Clone(d.params),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error building request: %v", err)
+	}
+	partial := d.offset > 0 || d.end < d.size
+	if partial {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", d.offset, d.end-1))
+	}
+
+	resp, err := d.client.do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %v", err)
+	}
+	if err := NewHTTPError(resp); err != nil {
+		resp.Body.Close()
+		return err
+	}
+
+	want := d.end - d.offset
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+	case partial:
+		// The server ignored the range, so skip the bytes before it.
+		want = d.size
+		if _, err := io.CopyN(io.Discard, resp.Body, d.offset); err != nil {
+			resp.Body.Close()
+			return fmt.Errorf("error skipping to offset %d: %w", d.offset, err)
+		}
+	}
+	if resp.ContentLength >= 0 && resp.ContentLength != want {
+		resp.Body.Close()
+		return fmt.Errorf("response has %d bytes, want %d", resp.ContentLength, want)
+	}
+
+	d.body = resp.Body
+	return nil
+}
+
+// Read reads the content, resuming the download if the response body is interrupted.
+func (d *download) Read(p []byte) (int, error) {
+	for {
+		if d.offset >= d.end {
+			return 0, io.EOF
+		}
+		if d.body == nil {
+			if err := d.open(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := d.body.Read(p[:min(int64(len(p)), d.end-d.offset)])
+		d.offset += int64(n)
+		if err == nil || (errors.Is(err, io.EOF) && d.offset >= d.end) {
+			return n, nil
+		}
+
+		d.body.Close()
+		d.body = nil
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if d.resumes >= d.maxResumes || d.ctx.Err() != nil {
+			return n, fmt.Errorf("download interrupted at %d of %d bytes: %w", d.offset, d.end, err)
+		}
+		d.resumes++
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// Close closes the response body being read, if any.
+func (d *download) Close() error {
+	if d.body == nil {
+		return nil
+	}
+	err := d.body.Close()
+	d.body = nil
+	return err
+}
+
+// parseByteRange parses a single HTTP byte range, such as "bytes=0-499" or "bytes=500-", of a
+// content of size bytes, and returns the bytes [start, end) it covers.
+func parseByteRange(s string, size int64) (int64, int64, error) {
+	spec, ok := strings.CutPrefix(s, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, fmt.Errorf("invalid range %q: must be a single byte range", s)
+	}
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+
+	if first == "" {
+		// A suffix range selects the last bytes of the content.
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("invalid range %q", s)
+		}
+		return max(0, size-n), size, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start > size {
+		return 0, 0, fmt.Errorf("invalid range %q for %d bytes", s, size)
+	}
+	end := size
+	if last != "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < start {
+			return 0, 0, fmt.Errorf("invalid range %q", s)
+		}
+		end = min(n+1, size)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,567 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SupportBundleFSOptions configures a SupportBundleFS.
+type SupportBundleFSOptions struct {
+	// CacheDir is the directory where the files of the bundle are cached once fetched, under a
+	// subdirectory named after the bundle. The cache is kept when the file system is closed, so
+	// it can be reused. Defaults to a temporary directory that is removed by Close.
+	CacheDir string
+
+	// Download configures the downloads of the files of the bundle.
+	Download *DownloadOptions
+}
+
+// SupportBundleFS is a read-only fs.FS over the files of a support bundle, so that the bundle can
+// be browsed with standard tooling, such as fs.WalkDir, fs.Glob and http.FileServer, without
+// downloading the whole bundle.
+//
+// The tree of the bundle is read from its index when the file system is created. A file is
+// downloaded the first time its content is read, and is then read from a local cache. Stat
+// reports the size of a file that isn't cached yet with a HEAD request. Files have no
+// modification time.
+//
+// A SupportBundleFS is safe for concurrent use. Close it to remove the temporary cache.
+type SupportBundleFS struct {
+	client   *Client
+	ctx      context.Context
+	bundleID string
+	download *DownloadOptions
+	nodes    map[string]*supportBundleNode
+
+	// cacheDir is where the files are cached, and removeCache whether Close removes it.
+	cacheDir    string
+	removeCache bool
+
+	// mu guards the cache entries and the sizes of the files.
+	mu      sync.Mutex
+	entries map[string]*supportBundleCacheEntry
+	sizes   map[string]int64
+}
+
+var (
+	_ fs.ReadDirFS  = (*SupportBundleFS)(nil)
+	_ fs.ReadFileFS = (*SupportBundleFS)(nil)
+	_ fs.StatFS     = (*SupportBundleFS)(nil)
+)
+
+// supportBundleNode is a file or directory of a support bundle.
+type supportBundleNode struct {
+	name     string
+	dir      bool
+	children []string
+}
+
+// supportBundleCacheEntry is the cached copy of a file. mu serializes its download.
+type supportBundleCacheEntry struct {
+	mu     sync.Mutex
+	path   string
+	cached bool
+}
+
+// EXPERIMENTAL: This operation is not yet stable and may change or be removed without notice.
+//
+// ExperimentalSupportBundleFS returns a file system over the files of a support bundle. The files
+// are fetched with ctx, so canceling it fails the reads of files that aren't cached yet.
+func (c *Client) ExperimentalSupportBundleFS(
+	ctx context.Context,
+	bundleID string,
+	opts *SupportBundleFSOptions,
+) (*SupportBundleFS, error) {
+	if opts == nil {
+		opts = &SupportBundleFSOptions{}
+	}
+
+	index, err := c.supportBundleIndex(ctx, SupportBundleIndexParams{BundleId: bundleID})
+	if err != nil {
+		return nil, err
+	}
+
+	fsys := &SupportBundleFS{
+		client:   c,
+		ctx:      ctx,
+		bundleID: bundleID,
+		download: opts.Download,
+		nodes:    supportBundleTree(index),
+		entries:  make(map[string]*supportBundleCacheEntry),
+		sizes:    make(map[string]int64),
+	}
+	if opts.CacheDir != "" {
+		fsys.cacheDir = filepath.Join(opts.CacheDir, bundleID)
+	} else {
+		if fsys.cacheDir, err = os.MkdirTemp("", "support-bundle-"); err != nil {
+			return nil, fmt.Errorf("error creating cache directory: %w", err)
+		}
+		fsys.removeCache = true
+	}
+	return fsys, nil
+}
+
+// supportBundleIndex returns the paths of the files of a support bundle. The index lists one path
+// per line, and the paths of directories end with a slash.
+func (c *Client) supportBundleIndex(
+	ctx context.Context,
+	params SupportBundleIndexParams,
+) ([]string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := c.buildRequest(
+		withOperationID(ctx, "support_bundle_index"),
+		nil,
+		"GET",
+		resolveRelative(c.host, "/experimental/v1/system/support-bundles/{{.bundle_id}}/index"),
+		map[string]string{"bundle_id": params.BundleId},
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %v", err)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+	if err := NewHTTPError(resp); err != nil {
+		return nil, err
+	}
+
+	var index []string
+	lines := bufio.NewScanner(resp.Body)
+	for lines.Scan() {
+		if line := strings.TrimSpace(lines.Text()); line != "" {
+			index = append(index, line)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("error reading support bundle index: %w", err)
+	}
+	return index, nil
+}
+
+// supportBundleTree returns the tree of the paths of an index, keyed by path. Paths that aren't
+// valid fs.FS paths are skipped.
+func supportBundleTree(index []string) map[string]*supportBundleNode {
+	nodes := map[string]*supportBundleNode{".": {name: ".", dir: true}}
+
+	var add func(name string, dir bool)
+	add = func(name string, dir bool) {
+		if node, ok := nodes[name]; ok {
+			node.dir = node.dir || dir
+			return
+		}
+		nodes[name] = &supportBundleNode{name: path.Base(name), dir: dir}
+		parent := path.Dir(name)
+		add(parent, true)
+		nodes[parent].children = append(nodes[parent].children, name)
+	}
+	for _, entry := range index {
+		name := strings.TrimPrefix(strings.TrimPrefix(entry, "./"), "/")
+		dir := strings.HasSuffix(name, "/")
+		name = strings.TrimSuffix(name, "/")
+		if name == "" || !fs.ValidPath(name) {
+			continue
+		}
+		add(name, dir)
+	}
+
+	for _, node := range nodes {
+		slices.Sort(node.children)
+	}
+	return nodes
+}
+
+// Open opens the named file or directory. The content of a file is fetched on its first read.
+func (fsys *SupportBundleFS) Open(name string) (fs.File, error) {
+	node, err := fsys.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if node.dir {
+		return &supportBundleDir{fsys: fsys, name: name, node: node}, nil
+	}
+	return &supportBundleFile{fsys: fsys, name: name}, nil
+}
+
+// Stat returns a fs.FileInfo describing the named file or directory.
+func (fsys *SupportBundleFS) Stat(name string) (fs.FileInfo, error) {
+	node, err := fsys.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return fsys.stat(name, node)
+}
+
+// ReadDir reads the named directory and returns its entries sorted by name.
+func (fsys *SupportBundleFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	node, err := fsys.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !node.dir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return fsys.dirEntries(node), nil
+}
+
+// ReadFile reads the named file and returns its content.
+func (fsys *SupportBundleFS) ReadFile(name string) ([]byte, error) {
+	node, err := fsys.lookup("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	if node.dir {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errors.New("is a directory")}
+	}
+	local, err := fsys.fetch(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+	return os.ReadFile(local)
+}
+
+// Close removes the temporary cache, if any. Files opened from fsys must not be used afterwards.
+func (fsys *SupportBundleFS) Close() error {
+	if !fsys.removeCache {
+		return nil
+	}
+	return os.RemoveAll(fsys.cacheDir)
+}
+
+// lookup returns the node of the named file or directory.
+func (fsys *SupportBundleFS) lookup(op, name string) (*supportBundleNode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	node, ok := fsys.nodes[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return node, nil
+}
+
+// stat describes a node. The size of a file is read from the cache if it's there, or with a HEAD
+// request otherwise.
+func (fsys *SupportBundleFS) stat(name string, node *supportBundleNode) (fs.FileInfo, error) {
+	if node.dir {
+		return &supportBundleFileInfo{name: node.name, mode: fs.ModeDir | 0o555}, nil
+	}
+	size, err := fsys.size(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return &supportBundleFileInfo{name: node.name, size: size, mode: 0o444}, nil
+}
+
+// size returns the size of the named file.
+func (fsys *SupportBundleFS) size(name string) (int64, error) {
+	fsys.mu.Lock()
+	size, ok := fsys.sizes[name]
+	fsys.mu.Unlock()
+	if ok {
+		return size, nil
+	}
+
+	size, err := fsys.client.ExperimentalSupportBundleFileSize(
+		fsys.ctx,
+		SupportBundleHeadFileParams{BundleId: fsys.bundleID, File: name},
+	)
+	if err != nil {
+		return 0, err
+	}
+	fsys.mu.Lock()
+	fsys.sizes[name] = size
+	fsys.mu.Unlock()
+	return size, nil
+}
+
+// dirEntries returns the entries of a directory.
+func (fsys *SupportBundleFS) dirEntries(node *supportBundleNode) []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(node.children))
+	for _, name := range node.children {
+		entries = append(entries, &supportBundleDirEntry{
+			fsys: fsys,
+			name: name,
+			node: fsys.nodes[name],
+		})
+	}
+	return entries
+}
+
+// fetch returns the path of the cached copy of the named file, and downloads it first if it
+// isn't cached yet.
+func (fsys *SupportBundleFS) fetch(name string) (string, error) {
+	fsys.mu.Lock()
+	entry, ok := fsys.entries[name]
+	if !ok {
+		entry = &supportBundleCacheEntry{
+			path: filepath.Join(fsys.cacheDir, filepath.FromSlash(name)),
+		}
+		fsys.entries[name] = entry
+	}
+	fsys.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.cached {
+		return entry.path, nil
+	}
+
+	// A file cached by a previous file system over the same cache directory is reused.
+	info, err := os.Stat(entry.path)
+	if err != nil {
+		if info, err = fsys.downloadFile(name, entry.path); err != nil {
+			return "", err
+		}
+	}
+
+	entry.cached = true
+	fsys.mu.Lock()
+	fsys.sizes[name] = info.Size()
+	fsys.mu.Unlock()
+	return entry.path, nil
+}
+
+// downloadFile downloads the named file to local. The file is written to a temporary file first, so
+// that an interrupted download doesn't leave a partial file in the cache.
+func (fsys *SupportBundleFS) downloadFile(name, local string) (fs.FileInfo, error) {
+	if err := os.MkdirAll(filepath.Dir(local), 0o755); err != nil {
+		return nil, fmt.Errorf("error creating cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(local), ".download-*")
+	if err != nil {
+		return nil, fmt.Errorf("error creating cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := fsys.client.ExperimentalSupportBundleDownloadFileToWriter(
+		fsys.ctx,
+		SupportBundleDownloadFileParams{BundleId: fsys.bundleID, File: name},
+		tmp,
+		fsys.download,
+	); err != nil {
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("error writing cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), local); err != nil {
+		return nil, fmt.Errorf("error writing cache file: %w", err)
+	}
+	return os.Stat(local)
+}
+
+// supportBundleFile is an open file of a support bundle. Its content is opened from the cache on
+// the first read or seek.
+type supportBundleFile struct {
+	fsys *SupportBundleFS
+	name string
+
+	mu     sync.Mutex
+	f      *os.File
+	closed bool
+}
+
+var (
+	_ io.ReaderAt = (*supportBundleFile)(nil)
+	_ io.Seeker   = (*supportBundleFile)(nil)
+)
+
+// open returns the cached copy of the file, and fetches it first if needed.
+func (f *supportBundleFile) open(op string) (*os.File, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil, &fs.PathError{Op: op, Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.f != nil {
+		return f.f, nil
+	}
+
+	local, err := f.fsys.fetch(f.name)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: f.name, Err: err}
+	}
+	if f.f, err = os.Open(local); err != nil {
+		return nil, &fs.PathError{Op: op, Path: f.name, Err: err}
+	}
+	return f.f, nil
+}
+
+// Stat describes the file, without fetching it.
+func (f *supportBundleFile) Stat() (fs.FileInfo, error) {
+	f.mu.Lock()
+	closed := f.closed
+	f.mu.Unlock()
+	if closed {
+		return nil, &fs.PathError{Op: "stat", Path: f.name, Err: fs.ErrClosed}
+	}
+	return f.fsys.stat(f.name, f.fsys.nodes[f.name])
+}
+
+// Read reads the content of the file.
+func (f *supportBundleFile) Read(p []byte) (int, error) {
+	file, err := f.open("read")
+	if err != nil {
+		return 0, err
+	}
+	return file.Read(p)
+}
+
+// ReadAt reads the content of the file at off.
+func (f *supportBundleFile) ReadAt(p []byte, off int64) (int, error) {
+	file, err := f.open("read")
+	if err != nil {
+		return 0, err
+	}
+	return file.ReadAt(p, off)
+}
+
+// Seek sets the offset of the next read.
+func (f *supportBundleFile) Seek(offset int64, whence int) (int64, error) {
+	file, err := f.open("seek")
+	if err != nil {
+		return 0, err
+	}
+	return file.Seek(offset, whence)
+}
+
+// Close closes the file.
+func (f *supportBundleFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	if f.f != nil {
+		return f.f.Close()
+	}
+	return nil
+}
+
+// supportBundleDir is an open directory of a support bundle.
+type supportBundleDir struct {
+	fsys *SupportBundleFS
+	name string
+	node *supportBundleNode
+
+	// offset is the number of entries read by ReadDir.
+	offset int
+}
+
+var _ fs.ReadDirFile = (*supportBundleDir)(nil)
+
+// Stat describes the directory.
+func (d *supportBundleDir) Stat() (fs.FileInfo, error) {
+	return d.fsys.stat(d.name, d.node)
+}
+
+// Read fails, since directories don't have content.
+func (d *supportBundleDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+// ReadDir reads the next n entries of the directory, or all the remaining entries if n <= 0.
+func (d *supportBundleDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries := d.fsys.dirEntries(d.node)[d.offset:]
+	if n > 0 {
+		if len(entries) == 0 {
+			return nil, io.EOF
+		}
+		entries = entries[:min(n, len(entries))]
+	}
+	d.offset += len(entries)
+	return entries, nil
+}
+
+// Close closes the directory.
+func (d *supportBundleDir) Close() error {
+	return nil
+}
+
+// supportBundleDirEntry is an entry of a directory of a support bundle.
+type supportBundleDirEntry struct {
+	fsys *SupportBundleFS
+	name string
+	node *supportBundleNode
+}
+
+func (e *supportBundleDirEntry) Name() string {
+	return e.node.name
+}
+
+func (e *supportBundleDirEntry) IsDir() bool {
+	return e.node.dir
+}
+
+func (e *supportBundleDirEntry) Type() fs.FileMode {
+	if e.node.dir {
+		return fs.ModeDir
+	}
+	return 0
+}
+
+// Info describes the entry. For a file that isn't cached, it sends a HEAD request.
+func (e *supportBundleDirEntry) Info() (fs.FileInfo, error) {
+	return e.fsys.stat(e.name, e.node)
+}
+
+func (e *supportBundleDirEntry) String() string {
+	return fs.FormatDirEntry(e)
+}
+
+// supportBundleFileInfo describes a file or directory of a support bundle.
+type supportBundleFileInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func (i *supportBundleFileInfo) Name() string {
+	return i.name
+}
+
+func (i *supportBundleFileInfo) Size() int64 {
+	return i.size
+}
+
+func (i *supportBundleFileInfo) Mode() fs.FileMode {
+	return i.mode
+}
+
+func (i *supportBundleFileInfo) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (i *supportBundleFileInfo) IsDir() bool {
+	return i.mode.IsDir()
+}
+
+func (i *supportBundleFileInfo) Sys() any {
+	return nil
+}
+
+func (i *supportBundleFileInfo) String() string {
+	return fs.FormatFileInfo(i)
+}
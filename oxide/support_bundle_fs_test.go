@@ -0,0 +1,232 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBundleFiles are the files of the support bundle served by newSupportBundleFSServer.
+var testBundleFiles = map[string]string{
+	"bundle_id.txt":                  "my-bundle",
+	"meta/trace.json":                `{"spans":[]}`,
+	"sled-1/oxide-sled-agent.log":    strings.Repeat("sled agent log line\n", 1000),
+	"sled-1/zones/oxz_switch/dladm":  "LINK CLASS MTU STATE\n",
+	"sled-2/oxide-sled-agent.log":    "",
+	"sp_task_dumps/sled_0/dump-0.gz": "\x1f\x8b",
+}
+
+// newSupportBundleFSServer returns a client for a server that serves the index and the files of
+// a support bundle, and the number of GET requests for each file.
+func newSupportBundleFSServer(t *testing.T) (*Client, func(name string) int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	gets := make(map[string]int)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /experimental/v1/system/support-bundles/my-bundle/index",
+		func(w http.ResponseWriter, r *http.Request) {
+			// Directories are listed with a trailing slash, and only some of them are listed.
+			index := "meta/\nsled-1/\nsled-1/zones/\n"
+			for name := range testBundleFiles {
+				index += name + "\n"
+			}
+			w.Write([]byte(index))
+		},
+	)
+	mux.HandleFunc(
+		"/experimental/v1/system/support-bundles/my-bundle/download/{file...}",
+		func(w http.ResponseWriter, r *http.Request) {
+			name := r.PathValue("file")
+			content, ok := testBundleFiles[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == http.MethodGet {
+				mu.Lock()
+				gets[name]++
+				mu.Unlock()
+			}
+			http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+		},
+	)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+	require.NoError(t, err)
+	return client, func(name string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return gets[name]
+	}
+}
+
+func Test_SupportBundleFS(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes the fs.FS conformance tests", func(t *testing.T) {
+		client, _ := newSupportBundleFSServer(t)
+		fsys, err := client.ExperimentalSupportBundleFS(ctx, "my-bundle", nil)
+		require.NoError(t, err)
+		defer fsys.Close()
+
+		var names []string
+		for name := range testBundleFiles {
+			names = append(names, name)
+		}
+		require.NoError(t, fstest.TestFS(fsys, names...))
+	})
+
+	t.Run("walks the bundle without downloading it", func(t *testing.T) {
+		client, gets := newSupportBundleFSServer(t)
+		fsys, err := client.ExperimentalSupportBundleFS(ctx, "my-bundle", nil)
+		require.NoError(t, err)
+		defer fsys.Close()
+
+		var walked []string
+		err = fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			require.NoError(t, err)
+			walked = append(walked, name)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			".",
+			"bundle_id.txt",
+			"meta",
+			"meta/trace.json",
+			"sled-1",
+			"sled-1/oxide-sled-agent.log",
+			"sled-1/zones",
+			"sled-1/zones/oxz_switch",
+			"sled-1/zones/oxz_switch/dladm",
+			"sled-2",
+			"sled-2/oxide-sled-agent.log",
+			"sp_task_dumps",
+			"sp_task_dumps/sled_0",
+			"sp_task_dumps/sled_0/dump-0.gz",
+		}, walked)
+
+		logs, err := fs.Glob(fsys, "sled-*/*.log")
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			[]string{"sled-1/oxide-sled-agent.log", "sled-2/oxide-sled-agent.log"},
+			logs,
+		)
+
+		info, err := fs.Stat(fsys, "sled-1/oxide-sled-agent.log")
+		require.NoError(t, err)
+		assert.Equal(t, int64(20000), info.Size())
+		assert.Equal(t, 0, gets("sled-1/oxide-sled-agent.log"))
+	})
+
+	t.Run("fetches files once", func(t *testing.T) {
+		client, gets := newSupportBundleFSServer(t)
+		fsys, err := client.ExperimentalSupportBundleFS(ctx, "my-bundle", nil)
+		require.NoError(t, err)
+
+		for range 3 {
+			data, err := fs.ReadFile(fsys, "meta/trace.json")
+			require.NoError(t, err)
+			assert.Equal(t, testBundleFiles["meta/trace.json"], string(data))
+		}
+		assert.Equal(t, 1, gets("meta/trace.json"))
+
+		require.NoError(t, fsys.Close())
+		_, err = os.Stat(fsys.cacheDir)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("reuses a cache directory", func(t *testing.T) {
+		client, gets := newSupportBundleFSServer(t)
+		cacheDir := t.TempDir()
+
+		for range 2 {
+			fsys, err := client.ExperimentalSupportBundleFS(
+				ctx,
+				"my-bundle",
+				&SupportBundleFSOptions{CacheDir: cacheDir},
+			)
+			require.NoError(t, err)
+			data, err := fs.ReadFile(fsys, "bundle_id.txt")
+			require.NoError(t, err)
+			assert.Equal(t, "my-bundle", string(data))
+			require.NoError(t, fsys.Close())
+		}
+		assert.Equal(t, 1, gets("bundle_id.txt"))
+		assert.FileExists(t, filepath.Join(cacheDir, "my-bundle", "bundle_id.txt"))
+	})
+
+	t.Run("serves files over HTTP", func(t *testing.T) {
+		client, _ := newSupportBundleFSServer(t)
+		fsys, err := client.ExperimentalSupportBundleFS(ctx, "my-bundle", nil)
+		require.NoError(t, err)
+		defer fsys.Close()
+
+		server := httptest.NewServer(http.FileServerFS(fsys))
+		defer server.Close()
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/sled-1/oxide-sled-agent.log", nil)
+		require.NoError(t, err)
+		req.Header.Set("Range", "bytes=20-39")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "sled agent log line\n", string(body))
+	})
+
+	t.Run("reports missing files", func(t *testing.T) {
+		client, _ := newSupportBundleFSServer(t)
+		fsys, err := client.ExperimentalSupportBundleFS(ctx, "my-bundle", nil)
+		require.NoError(t, err)
+		defer fsys.Close()
+
+		_, err = fsys.Open("sled-3/oxide-sled-agent.log")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		_, err = fsys.Open("../bundle_id.txt")
+		assert.ErrorIs(t, err, fs.ErrInvalid)
+		_, err = fsys.ReadFile("sled-1")
+		assert.ErrorContains(t, err, "is a directory")
+	})
+}
+
+func Test_supportBundleTree(t *testing.T) {
+	nodes := supportBundleTree([]string{"a/b/c.txt", "./d/", "/e.txt", "../f", "a/"})
+
+	var names []string
+	for name, node := range nodes {
+		names = append(names, name)
+		if name == "e.txt" || name == "a/b/c.txt" {
+			assert.False(t, node.dir, name)
+		} else {
+			assert.True(t, node.dir, name)
+		}
+	}
+	assert.ElementsMatch(t, []string{".", "a", "a/b", "a/b/c.txt", "d", "e.txt"}, names)
+	assert.Equal(t, []string{"a", "d", "e.txt"}, nodes["."].children)
+	assert.Equal(t, "c.txt", nodes["a/b/c.txt"].name)
+}
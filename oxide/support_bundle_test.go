@@ -0,0 +1,270 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// supportBundleServer serves the content of a support bundle and of its files, and can cut the
+// responses short to emulate interrupted downloads.
+type supportBundleServer struct {
+	content []byte
+
+	mu sync.Mutex
+	// interrupt is the number of the next GET responses that are cut short after cut bytes.
+	interrupt int
+	cut       int
+	// ignoreRange makes the server answer Range requests with the whole content.
+	ignoreRange bool
+	// ranges holds the Range header of every GET request.
+	ranges []string
+}
+
+func newSupportBundleServer(t *testing.T, content []byte) (*Client, *supportBundleServer) {
+	t.Helper()
+
+	s := &supportBundleServer{content: content}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/experimental/v1/system/support-bundles/my-bundle/download"
+		if r.URL.Path != prefix && !strings.HasPrefix(r.URL.Path, prefix+"/") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(
+				[]byte(`{"request_id":"1","error_code":"ObjectNotFound","message":"not found"}`),
+			)
+			return
+		}
+
+		s.mu.Lock()
+		interrupt := s.interrupt > 0 && r.Method == http.MethodGet
+		if interrupt {
+			s.interrupt--
+		}
+		if r.Method == http.MethodGet {
+			s.ranges = append(s.ranges, r.Header.Get("Range"))
+		}
+		ignoreRange := s.ignoreRange
+		s.mu.Unlock()
+
+		if ignoreRange {
+			r.Header.Del("Range")
+		}
+		if !interrupt {
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(s.content))
+			return
+		}
+
+		// Announce the whole content but send only part of it, so the server closes the
+		// connection mid-body.
+		start := 0
+		if rng := r.Header.Get("Range"); rng != "" {
+			first, _, _ := strings.Cut(strings.TrimPrefix(rng, "bytes="), "-")
+			start, _ = strconv.Atoi(first)
+			w.Header().Set(
+				"Content-Range",
+				"bytes "+first+"-"+strconv.Itoa(len(s.content)-1)+"/"+strconv.Itoa(len(s.content)),
+			)
+			w.Header().Set("Content-Length", strconv.Itoa(len(s.content)-start))
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.Header().Set("Content-Length", strconv.Itoa(len(s.content)))
+		}
+		w.Write(s.content[start:min(start+s.cut, len(s.content))])
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+	require.NoError(t, err)
+	return client, s
+}
+
+func testSupportBundle() []byte {
+	content := make([]byte, 256*1024)
+	for i := range content {
+		content[i] = byte(i * 7)
+	}
+	return content
+}
+
+func Test_ExperimentalSupportBundleSize(t *testing.T) {
+	ctx := context.Background()
+	content := testSupportBundle()
+	client, _ := newSupportBundleServer(t, content)
+
+	size, err := client.ExperimentalSupportBundleSize(
+		ctx,
+		SupportBundleHeadParams{BundleId: "my-bundle"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), size)
+
+	size, err = client.ExperimentalSupportBundleFileSize(
+		ctx,
+		SupportBundleHeadFileParams{BundleId: "my-bundle", File: "bundle_id.txt"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), size)
+
+	_, err = client.ExperimentalSupportBundleSize(
+		ctx,
+		SupportBundleHeadParams{BundleId: "other-bundle"},
+	)
+	// The responses to HEAD requests don't have a body to read the error from.
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.HTTPResponse.StatusCode)
+}
+
+func Test_ExperimentalSupportBundleDownload(t *testing.T) {
+	ctx := context.Background()
+	content := testSupportBundle()
+	params := SupportBundleDownloadParams{BundleId: "my-bundle"}
+
+	t.Run("downloads the content", func(t *testing.T) {
+		client, server := newSupportBundleServer(t, content)
+
+		var buf bytes.Buffer
+		n, err := client.ExperimentalSupportBundleDownloadToWriter(ctx, params, &buf, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(content)), n)
+		assert.Equal(t, content, buf.Bytes())
+		assert.Equal(t, []string{""}, server.ranges)
+	})
+
+	t.Run("downloads a file", func(t *testing.T) {
+		client, _ := newSupportBundleServer(t, content)
+
+		var buf bytes.Buffer
+		_, err := client.ExperimentalSupportBundleDownloadFileToWriter(
+			ctx,
+			SupportBundleDownloadFileParams{BundleId: "my-bundle", File: "sled-1/sled-agent.log"},
+			&buf,
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, content, buf.Bytes())
+	})
+
+	t.Run("downloads a range", func(t *testing.T) {
+		client, server := newSupportBundleServer(t, content)
+
+		params := params
+		params.Range = "bytes=1000-1999"
+		r, err := client.ExperimentalSupportBundleDownloadReader(ctx, params, nil)
+		require.NoError(t, err)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, content[1000:2000], data)
+		assert.Equal(t, []string{"bytes=1000-1999"}, server.ranges)
+	})
+
+	t.Run("downloads empty ranges without a request", func(t *testing.T) {
+		client, server := newSupportBundleServer(t, content)
+
+		for _, byteRange := range []string{fmt.Sprintf("bytes=%d-", len(content)), "bytes=-0"} {
+			params := params
+			params.Range = byteRange
+			r, err := client.ExperimentalSupportBundleDownloadReader(ctx, params, nil)
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Empty(t, data)
+			require.NoError(t, r.Close())
+		}
+		assert.Empty(t, server.ranges)
+	})
+
+	t.Run("resumes interrupted downloads", func(t *testing.T) {
+		client, server := newSupportBundleServer(t, content)
+		server.interrupt, server.cut = 2, 64*1024
+
+		var buf bytes.Buffer
+		n, err := client.ExperimentalSupportBundleDownloadToWriter(ctx, params, &buf, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(content)), n)
+		assert.Equal(t, content, buf.Bytes())
+		assert.Equal(t, []string{"", "bytes=65536-262143", "bytes=131072-262143"}, server.ranges)
+	})
+
+	t.Run("resumes from servers that ignore ranges", func(t *testing.T) {
+		client, server := newSupportBundleServer(t, content)
+		server.interrupt, server.cut, server.ignoreRange = 1, 64*1024, true
+
+		var buf bytes.Buffer
+		_, err := client.ExperimentalSupportBundleDownloadToWriter(ctx, params, &buf, nil)
+		require.NoError(t, err)
+		assert.Equal(t, content, buf.Bytes())
+	})
+
+	t.Run("fails after too many interruptions", func(t *testing.T) {
+		client, server := newSupportBundleServer(t, content)
+		server.interrupt, server.cut = 2, 1024
+
+		var buf bytes.Buffer
+		n, err := client.ExperimentalSupportBundleDownloadToWriter(
+			ctx,
+			params,
+			&buf,
+			&DownloadOptions{MaxResumes: 1},
+		)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.ErrorContains(t, err, "download interrupted at 2048 of 262144 bytes")
+		assert.Equal(t, int64(2048), n)
+	})
+
+	t.Run("rejects invalid ranges", func(t *testing.T) {
+		client, _ := newSupportBundleServer(t, content)
+
+		params := params
+		params.Range = "bytes=0-9,20-29"
+		_, err := client.ExperimentalSupportBundleDownloadReader(ctx, params, nil)
+		assert.ErrorContains(t, err, "must be a single byte range")
+	})
+}
+
+func Test_parseByteRange(t *testing.T) {
+	tests := []struct {
+		input      string
+		start, end int64
+		wantErr    bool
+	}{
+		{input: "bytes=0-", start: 0, end: 100},
+		{input: "bytes=10-19", start: 10, end: 20},
+		{input: "bytes=90-200", start: 90, end: 100},
+		{input: "bytes=-10", start: 90, end: 100},
+		{input: "bytes=-200", start: 0, end: 100},
+		{input: "bytes=100-", start: 100, end: 100},
+		{input: "bytes=101-", wantErr: true},
+		{input: "bytes=20-10", wantErr: true},
+		{input: "items=0-10", wantErr: true},
+		{input: "bytes=10", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			start, end, err := parseByteRange(tt.input, 100)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.end, end)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// TokenSource supplies API tokens to [Client]. Implementations can fetch short-lived tokens from
+// a secrets manager, run a credential helper, or refresh a token obtained via device
+// authorization.
+type TokenSource interface {
+	// Token returns a valid API token. It's called whenever [Client] doesn't have a cached token.
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is an adapter to allow the use of ordinary functions as a [TokenSource].
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// WithTokenSource sets a [TokenSource] that [Client] calls to obtain the API token. The token is
+// cached until a request fails with [ErrUnauthenticated], at which point the next request fetches
+// a new one. This is mutually exclusive with [WithToken], [WithProfile], and
+// [WithDefaultProfile].
+func WithTokenSource(ts TokenSource) ClientOption {
+	return clientOptionFunc(func(cfg *clientConfig) error {
+		if ts == nil {
+			return errors.New("token source must not be nil")
+		}
+		cfg.tokenSource = ts
+		return nil
+	})
+}
+
+// cachedTokenSource caches the token returned by a [TokenSource] until it's invalidated.
+type cachedTokenSource struct {
+	source TokenSource
+
+	mu    sync.Mutex
+	token string
+}
+
+// Token returns the cached token, fetching a new one from the underlying source if needed.
+func (s *cachedTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" {
+		return s.token, nil
+	}
+
+	token, err := s.source.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error getting token from token source: %w", err)
+	}
+	if token == "" {
+		return "", errors.New("token source returned an empty token")
+	}
+	s.token = token
+	return token, nil
+}
+
+// invalidate drops the cached token if it's still the given one. Comparing tokens avoids dropping a
+// fresh token because of a concurrent request that used a stale one.
+func (s *cachedTokenSource) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// invalidateOnUnauthenticated drops the token used by req from the cache when resp reports that
+// it's no longer valid.
+func (s *cachedTokenSource) invalidateOnUnauthenticated(req *http.Request, resp *http.Response) {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return
+	}
+	if errors.Is(peekHTTPError(resp), ErrUnauthenticated) {
+		s.invalidate(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+	}
+}
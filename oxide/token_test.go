@@ -0,0 +1,122 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithTokenSource(t *testing.T) {
+	var valid atomic.Value
+	valid.Store("token-1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+valid.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"request_id":"1","error_code":"Unauthorized","message":"bad"}`))
+			return
+		}
+		w.Write([]byte(`{"name":"my-project"}`))
+	}))
+	defer server.Close()
+
+	var calls atomic.Int32
+	client, err := NewClient(
+		WithHost(server.URL),
+		WithTokenSource(TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return fmt.Sprintf("token-%d", calls.Add(1)), nil
+		})),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	params := ProjectViewParams{Project: "my-project"}
+
+	// The first token is fetched once and then cached.
+	for range 3 {
+		_, err = client.ProjectView(ctx, params)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Once the server rejects the cached token, it's invalidated and the next call refreshes it.
+	valid.Store("token-2")
+	_, err = client.ProjectView(ctx, params)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+	_, err = client.ProjectView(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func Test_WithTokenSource_errors(t *testing.T) {
+	errSecrets := errors.New("secrets manager unavailable")
+	failing := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return "", errSecrets
+	})
+	empty := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return "", nil
+	})
+
+	t.Run("fails when combined with a token", func(t *testing.T) {
+		_, err := NewClient(WithHost("http://localhost"), WithToken("foo"), WithTokenSource(empty))
+		assert.EqualError(
+			t,
+			err,
+			"cannot authenticate with both a token source and a token or profile",
+		)
+	})
+
+	t.Run("fails with a nil token source", func(t *testing.T) {
+		_, err := NewClient(WithHost("http://localhost"), WithTokenSource(nil))
+		assert.ErrorContains(t, err, "token source must not be nil")
+	})
+
+	t.Run("surfaces token source errors", func(t *testing.T) {
+		client, err := NewClient(WithHost("http://localhost"), WithTokenSource(failing))
+		require.NoError(t, err)
+
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+		assert.ErrorContains(t, err, errSecrets.Error())
+	})
+
+	t.Run("rejects empty tokens", func(t *testing.T) {
+		client, err := NewClient(WithHost("http://localhost"), WithTokenSource(empty))
+		require.NoError(t, err)
+
+		_, err = client.ProjectView(context.Background(), ProjectViewParams{Project: "p"})
+		assert.ErrorContains(t, err, "token source returned an empty token")
+	})
+}
+
+func Test_cachedTokenSource_invalidate(t *testing.T) {
+	var calls int
+	ts := &cachedTokenSource{source: TokenSourceFunc(func(ctx context.Context) (string, error) {
+		calls++
+		return fmt.Sprintf("token-%d", calls), nil
+	})}
+
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// A stale token doesn't invalidate the cached one.
+	ts.invalidate("token-0")
+	token, err = ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	ts.invalidate("token-1")
+	token, err = ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
@@ -0,0 +1,344 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package update orchestrates the update of the system software of a rack: it checks that the
+// updates trust store has a root role, uploads the TUF repository of the new release, sets it as
+// the target release, and watches the update status until every component runs the new release.
+//
+// Every step starts by looking at the state of the rack, so an update that was interrupted is
+// resumed by running it again: a repository that was already uploaded isn't uploaded again, and
+// a target release that is already set isn't set again.
+//
+//	updater := update.New(client, update.Options{
+//		SystemVersion:  "18.0.0",
+//		RepositoryPath: "repo-18.0.0.zip",
+//		OnEvent: func(e update.Event) {
+//			log.Println(e)
+//		},
+//	})
+//	status, err := updater.Run(ctx)
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// defaultPollInterval is the time between two polls of the update status.
+const defaultPollInterval = 30 * time.Second
+
+// ErrContactSupport is returned when the update status reports conditions that require Oxide
+// support to resolve. The update is stopped, and must not be resumed before they are resolved.
+var ErrContactSupport = errors.New("the system requires Oxide support")
+
+// Step is a step of an update.
+type Step string
+
+const (
+	// StepCheckTrustRoots checks that the updates trust store has a root role, which is required
+	// to verify the repository.
+	StepCheckTrustRoots Step = "check_trust_roots"
+
+	// StepUpload uploads the TUF repository of the release.
+	StepUpload Step = "upload"
+
+	// StepSetTarget sets the release as the target release.
+	StepSetTarget Step = "set_target"
+
+	// StepWait watches the update status until every component runs the release.
+	StepWait Step = "wait"
+)
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	// EventStarted is emitted when a step starts.
+	EventStarted EventType = "started"
+
+	// EventProgress is emitted while a step runs: as the repository is uploaded, and every time
+	// the update status is polled.
+	EventProgress EventType = "progress"
+
+	// EventSkipped is emitted instead of EventStarted when a step was already done, e.g. when the
+	// repository was already uploaded.
+	EventSkipped EventType = "skipped"
+
+	// EventCompleted is emitted when a step completes.
+	EventCompleted EventType = "completed"
+)
+
+// Event reports the progress of an update.
+type Event struct {
+	// Step is the step the event is about.
+	Step Step
+
+	// Type is the type of the event.
+	Type EventType
+
+	// Upload is the progress of the upload, for the progress events of StepUpload.
+	Upload *oxide.UploadProgress
+
+	// Status is the last update status, for the progress events of StepWait.
+	Status *oxide.UpdateStatus
+}
+
+// String returns a short description of the event, for logging.
+func (e Event) String() string {
+	switch {
+	case e.Upload != nil:
+		return fmt.Sprintf(
+			"%s %s: %d/%d bytes",
+			e.Step,
+			e.Type,
+			e.Upload.SentBytes,
+			e.Upload.TotalBytes,
+		)
+	case e.Status != nil:
+		return fmt.Sprintf(
+			"%s %s: %s",
+			e.Step,
+			e.Type,
+			formatComponents(e.Status.ComponentsByReleaseVersion),
+		)
+	default:
+		return fmt.Sprintf("%s %s", e.Step, e.Type)
+	}
+}
+
+// Options configures an update.
+type Options struct {
+	// SystemVersion is the system version of the release to update to. Required.
+	SystemVersion string
+
+	// RepositoryPath is the path of the TUF repository of the release. It's only read if the
+	// repository wasn't uploaded yet, and is required in that case.
+	RepositoryPath string
+
+	// PollInterval is the time between two polls of the update status. Defaults to 30s.
+	PollInterval time.Duration
+
+	// OnEvent is called with the progress of the update.
+	OnEvent func(Event)
+}
+
+// Updater updates the system software of a rack.
+type Updater struct {
+	client *oxide.Client
+	opts   Options
+}
+
+// New returns an updater to the release described by opts.
+func New(client *oxide.Client, opts Options) *Updater {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	return &Updater{client: client, opts: opts}
+}
+
+// Run runs the update until every component runs the release, and returns the last update
+// status. It returns an error wrapping ErrContactSupport as soon as the update status reports
+// conditions that require Oxide support.
+func (u *Updater) Run(ctx context.Context) (*oxide.UpdateStatus, error) {
+	if u.opts.SystemVersion == "" {
+		return nil, errors.New("system version must be set")
+	}
+
+	steps := []struct {
+		step Step
+		run  func(ctx context.Context) (skipped bool, err error)
+	}{
+		{StepCheckTrustRoots, u.checkTrustRoots},
+		{StepUpload, u.upload},
+		{StepSetTarget, u.setTarget},
+	}
+	for _, s := range steps {
+		if err := u.runStep(ctx, s.step, s.run); err != nil {
+			return nil, err
+		}
+	}
+
+	var status *oxide.UpdateStatus
+	err := u.runStep(ctx, StepWait, func(ctx context.Context) (bool, error) {
+		var err error
+		status, err = u.wait(ctx)
+		return false, err
+	})
+	return status, err
+}
+
+// runStep runs a step and emits its events.
+func (u *Updater) runStep(
+	ctx context.Context,
+	step Step,
+	run func(ctx context.Context) (bool, error),
+) error {
+	skipped, err := run(ctx)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return fmt.Errorf("%s: %w", step, err)
+	}
+	if skipped {
+		u.emit(Event{Step: step, Type: EventSkipped})
+	} else {
+		u.emit(Event{Step: step, Type: EventCompleted})
+	}
+	return nil
+}
+
+func (u *Updater) emit(e Event) {
+	if u.opts.OnEvent != nil {
+		u.opts.OnEvent(e)
+	}
+}
+
+// checkTrustRoots checks that the updates trust store has a root role.
+func (u *Updater) checkTrustRoots(ctx context.Context) (bool, error) {
+	u.emit(Event{Step: StepCheckTrustRoots, Type: EventStarted})
+	page, err := u.client.SystemUpdateTrustRootList(
+		ctx,
+		oxide.SystemUpdateTrustRootListParams{Limit: oxide.NewPointer(1)},
+	)
+	if err != nil {
+		return false, err
+	}
+	if len(page.Items) == 0 {
+		return false, errors.New(
+			"the updates trust store is empty, add the root role of the release first",
+		)
+	}
+	return false, nil
+}
+
+// upload uploads the repository of the release, unless it already exists.
+func (u *Updater) upload(ctx context.Context) (bool, error) {
+	_, err := u.client.SystemUpdateRepositoryView(
+		ctx,
+		oxide.SystemUpdateRepositoryViewParams{SystemVersion: u.opts.SystemVersion},
+	)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, oxide.ErrObjectNotFound) {
+		return false, err
+	}
+	if u.opts.RepositoryPath == "" {
+		return false, fmt.Errorf(
+			"repository for system version %s doesn't exist, and no repository path is set",
+			u.opts.SystemVersion,
+		)
+	}
+
+	u.emit(Event{Step: StepUpload, Type: EventStarted})
+	_, err = u.client.UploadUpdateRepository(
+		ctx,
+		u.opts.RepositoryPath,
+		&oxide.UploadUpdateRepositoryOptions{
+			SystemVersion: u.opts.SystemVersion,
+			Progress: func(p oxide.UploadProgress) {
+				u.emit(Event{Step: StepUpload, Type: EventProgress, Upload: &p})
+			},
+		},
+	)
+	return false, err
+}
+
+// setTarget sets the release as the target release, unless it already is.
+func (u *Updater) setTarget(ctx context.Context) (bool, error) {
+	status, err := u.status(ctx)
+	if err != nil {
+		return false, err
+	}
+	if status.TargetRelease != nil && status.TargetRelease.Version == u.opts.SystemVersion {
+		return true, nil
+	}
+
+	u.emit(Event{Step: StepSetTarget, Type: EventStarted})
+	return false, u.client.TargetReleaseUpdate(ctx, oxide.TargetReleaseUpdateParams{
+		Body: &oxide.SetTargetReleaseParams{SystemVersion: u.opts.SystemVersion},
+	})
+}
+
+// wait polls the update status until every component runs the release.
+func (u *Updater) wait(ctx context.Context) (*oxide.UpdateStatus, error) {
+	u.emit(Event{Step: StepWait, Type: EventStarted})
+	for {
+		status, err := u.status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		u.emit(Event{Step: StepWait, Type: EventProgress, Status: status})
+		if status.TargetRelease == nil || status.TargetRelease.Version != u.opts.SystemVersion {
+			return status, fmt.Errorf(
+				"target release changed to %s while waiting for the update",
+				formatTarget(status.TargetRelease),
+			)
+		}
+		if converged(status, u.opts.SystemVersion) {
+			return status, nil
+		}
+
+		timer := time.NewTimer(u.opts.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// status returns the update status, or an error wrapping ErrContactSupport if it reports
+// conditions that require Oxide support.
+func (u *Updater) status(ctx context.Context) (*oxide.UpdateStatus, error) {
+	status, err := u.client.SystemUpdateStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if status.ContactSupport != nil && *status.ContactSupport {
+		return status, fmt.Errorf(
+			"%w: resolve the issues before resuming the update",
+			ErrContactSupport,
+		)
+	}
+	return status, nil
+}
+
+// converged reports whether every component runs version.
+func converged(status *oxide.UpdateStatus, version string) bool {
+	running := 0
+	for v, count := range status.ComponentsByReleaseVersion {
+		if count == nil || *count == 0 {
+			continue
+		}
+		if v != version {
+			return false
+		}
+		running += *count
+	}
+	return running > 0
+}
+
+func formatComponents(components map[string]*int) string {
+	counts := make(map[string]int, len(components))
+	for v, count := range components {
+		if count != nil {
+			counts[v] = *count
+		}
+	}
+	return fmt.Sprintf("components by release version %v", counts)
+}
+
+func formatTarget(target *oxide.TargetRelease) string {
+	if target == nil {
+		return "none"
+	}
+	return target.Version
+}
@@ -0,0 +1,278 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oxidecomputer/oxide.go/oxide"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRack emulates the update endpoints of a rack with 4 components. Once the target release is
+// set, every poll of the update status updates one more component.
+type fakeRack struct {
+	mu             sync.Mutex
+	trustRoots     int
+	repos          map[string]oxide.TufRepo
+	target         string
+	updated        int
+	contactSupport bool
+	uploads        int
+	targetUpdates  int
+}
+
+func newFakeRack(t *testing.T, rack *fakeRack) *oxide.Client {
+	t.Helper()
+
+	if rack.repos == nil {
+		rack.repos = make(map[string]oxide.TufRepo)
+	}
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"request_id":"1","error_code":"ObjectNotFound","message":"not found"}`))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /v1/system/update/trust-roots",
+		func(w http.ResponseWriter, r *http.Request) {
+			rack.mu.Lock()
+			defer rack.mu.Unlock()
+			page := oxide.UpdatesTrustRootResultsPage{Items: []oxide.UpdatesTrustRoot{}}
+			for range rack.trustRoots {
+				page.Items = append(page.Items, oxide.UpdatesTrustRoot{Id: "root"})
+			}
+			json.NewEncoder(w).Encode(page)
+		},
+	)
+	mux.HandleFunc(
+		"GET /v1/system/update/repositories/{version}",
+		func(w http.ResponseWriter, r *http.Request) {
+			rack.mu.Lock()
+			defer rack.mu.Unlock()
+			repo, ok := rack.repos[r.PathValue("version")]
+			if !ok {
+				notFound(w)
+				return
+			}
+			json.NewEncoder(w).Encode(repo)
+		},
+	)
+	mux.HandleFunc(
+		"PUT /v1/system/update/repositories",
+		func(w http.ResponseWriter, r *http.Request) {
+			h := sha256.New()
+			io.Copy(h, r.Body)
+
+			rack.mu.Lock()
+			defer rack.mu.Unlock()
+			rack.uploads++
+			repo := oxide.TufRepo{
+				FileName:      r.URL.Query().Get("file_name"),
+				Hash:          hex.EncodeToString(h.Sum(nil)),
+				SystemVersion: "18.0.0",
+			}
+			rack.repos[repo.SystemVersion] = repo
+			json.NewEncoder(w).Encode(oxide.TufRepoUpload{
+				Repo:   repo,
+				Status: oxide.TufRepoUploadStatusInserted,
+			})
+		},
+	)
+	mux.HandleFunc(
+		"PUT /v1/system/update/target-release",
+		func(w http.ResponseWriter, r *http.Request) {
+			var params oxide.SetTargetReleaseParams
+			json.NewDecoder(r.Body).Decode(&params)
+
+			rack.mu.Lock()
+			defer rack.mu.Unlock()
+			if _, ok := rack.repos[params.SystemVersion]; !ok {
+				notFound(w)
+				return
+			}
+			rack.targetUpdates++
+			rack.target = params.SystemVersion
+			w.WriteHeader(http.StatusNoContent)
+		},
+	)
+	mux.HandleFunc("GET /v1/system/update/status", func(w http.ResponseWriter, r *http.Request) {
+		rack.mu.Lock()
+		defer rack.mu.Unlock()
+		status := oxide.UpdateStatus{
+			ComponentsByReleaseVersion: map[string]*int{
+				"17.0.0": oxide.NewPointer(4 - rack.updated),
+				"18.0.0": oxide.NewPointer(rack.updated),
+			},
+			ContactSupport: oxide.NewPointer(rack.contactSupport),
+			Suspended:      oxide.NewPointer(false),
+		}
+		if rack.target != "" {
+			status.TargetRelease = &oxide.TargetRelease{Version: rack.target}
+		}
+		if rack.target == "18.0.0" && rack.updated < 4 {
+			rack.updated++
+		}
+		json.NewEncoder(w).Encode(status)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := oxide.NewClient(oxide.WithHost(server.URL), oxide.WithToken("foo"))
+	require.NoError(t, err)
+	return client
+}
+
+func testRepository(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "repo-18.0.0.zip")
+	require.NoError(t, os.WriteFile(path, []byte("a TUF repository"), 0o644))
+	return path
+}
+
+// recordEvents returns an OnEvent callback that records the events other than the progress
+// events.
+func recordEvents(events *[]string) func(Event) {
+	return func(e Event) {
+		if e.Type != EventProgress {
+			*events = append(*events, e.String())
+		}
+	}
+}
+
+func Test_Updater(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("updates the rack", func(t *testing.T) {
+		rack := &fakeRack{trustRoots: 1}
+		client := newFakeRack(t, rack)
+
+		var events []string
+		var polls int
+		status, err := New(client, Options{
+			SystemVersion:  "18.0.0",
+			RepositoryPath: testRepository(t),
+			PollInterval:   time.Millisecond,
+			OnEvent: func(e Event) {
+				if e.Step == StepWait && e.Type == EventProgress {
+					polls++
+				}
+				recordEvents(&events)(e)
+			},
+		}).Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 4, *status.ComponentsByReleaseVersion["18.0.0"])
+		assert.Equal(t, []string{
+			"check_trust_roots started",
+			"check_trust_roots completed",
+			"upload started",
+			"upload completed",
+			"set_target started",
+			"set_target completed",
+			"wait started",
+			"wait completed",
+		}, events)
+		assert.Equal(t, 1, rack.uploads)
+		assert.Equal(t, 1, rack.targetUpdates)
+		assert.Equal(t, 5, polls)
+	})
+
+	t.Run("resumes an update", func(t *testing.T) {
+		rack := &fakeRack{
+			trustRoots: 1,
+			repos:      map[string]oxide.TufRepo{"18.0.0": {SystemVersion: "18.0.0"}},
+			target:     "18.0.0",
+			updated:    2,
+		}
+		client := newFakeRack(t, rack)
+
+		var events []string
+		_, err := New(client, Options{
+			SystemVersion: "18.0.0",
+			PollInterval:  time.Millisecond,
+			OnEvent:       recordEvents(&events),
+		}).Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"check_trust_roots started",
+			"check_trust_roots completed",
+			"upload skipped",
+			"set_target skipped",
+			"wait started",
+			"wait completed",
+		}, events)
+		assert.Equal(t, 0, rack.uploads)
+		assert.Equal(t, 0, rack.targetUpdates)
+	})
+
+	t.Run("stops when support is required", func(t *testing.T) {
+		rack := &fakeRack{trustRoots: 1, contactSupport: true}
+		client := newFakeRack(t, rack)
+
+		_, err := New(client, Options{
+			SystemVersion:  "18.0.0",
+			RepositoryPath: testRepository(t),
+			PollInterval:   time.Millisecond,
+		}).Run(ctx)
+		assert.ErrorIs(t, err, ErrContactSupport)
+		assert.ErrorContains(t, err, "set_target: ")
+		assert.Equal(t, 0, rack.targetUpdates)
+	})
+
+	t.Run("requires a trust root", func(t *testing.T) {
+		client := newFakeRack(t, &fakeRack{})
+
+		_, err := New(client, Options{
+			SystemVersion:  "18.0.0",
+			RepositoryPath: testRepository(t),
+		}).Run(ctx)
+		assert.ErrorContains(t, err, "check_trust_roots: the updates trust store is empty")
+	})
+
+	t.Run("requires a repository to upload", func(t *testing.T) {
+		client := newFakeRack(t, &fakeRack{trustRoots: 1})
+
+		_, err := New(client, Options{SystemVersion: "18.0.0"}).Run(ctx)
+		assert.ErrorContains(
+			t,
+			err,
+			"upload: repository for system version 18.0.0 doesn't exist, and no repository path is set",
+		)
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		rack := &fakeRack{
+			trustRoots: 1,
+			repos:      map[string]oxide.TufRepo{"18.0.0": {SystemVersion: "18.0.0"}},
+		}
+		client := newFakeRack(t, rack)
+
+		ctx, cancel := context.WithCancel(ctx)
+		_, err := New(client, Options{
+			SystemVersion: "18.0.0",
+			PollInterval:  time.Hour,
+			OnEvent: func(e Event) {
+				if e.Step == StepWait && e.Type == EventProgress {
+					cancel()
+				}
+			},
+		}).Run(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
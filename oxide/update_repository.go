@@ -0,0 +1,281 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// uploadBaseTimeout is the part of the default timeout of an upload that doesn't depend on
+	// its size. It leaves time for the server to verify the repository once it's received.
+	uploadBaseTimeout = 10 * time.Minute
+
+	// uploadMinThroughput is the slowest throughput, in bytes per second, that the default
+	// timeout of an upload allows for.
+	uploadMinThroughput = 4 << 20
+)
+
+// UploadUpdateRepositoryOptions configures UploadUpdateRepository.
+type UploadUpdateRepositoryOptions struct {
+	// FileName is the file name reported to the server. Defaults to the base name of the file.
+	FileName string
+
+	// SystemVersion is the system version the repository is expected to have. The upload fails
+	// if the server reports another version. When set, the repository is also looked up by
+	// version if the response to the upload is lost, e.g. because the upload timed out while
+	// the server was still verifying the repository.
+	SystemVersion string
+
+	// Timeout is the timeout of the upload request. Defaults to 10 minutes plus the time it
+	// takes to send the file at 4 MiB/s, or to the timeout of the HTTP client if it's longer.
+	Timeout time.Duration
+
+	// Progress is called as the file is sent.
+	Progress func(UploadProgress)
+
+	// Wait configures the polling of the repository once it's uploaded.
+	Wait []WaitOption
+}
+
+// UploadProgress reports the progress of an upload.
+type UploadProgress struct {
+	// TotalBytes is the size of the file.
+	TotalBytes int64
+
+	// SentBytes is the number of bytes sent so far. It goes back when the upload is retried.
+	SentBytes int64
+}
+
+// UploadUpdateRepositoryResult is the result of UploadUpdateRepository.
+type UploadUpdateRepositoryResult struct {
+	// Repo is the uploaded repository.
+	Repo TufRepo
+
+	// Status tells whether the repository was inserted or already existed. It's empty when the
+	// response to the upload was lost and the repository was found by its version instead.
+	Status TufRepoUploadStatus
+
+	// SHA256 is the hex-encoded SHA-256 of the file, computed as it was sent.
+	SHA256 string
+}
+
+// UploadUpdateRepository uploads the TUF repository at path, and waits until the repository can be
+// fetched by its system version.
+//
+// Unlike SystemUpdateRepositoryUpload, the file is streamed with a timeout appropriate to its
+// size, and its SHA-256 is computed as it's sent and checked against the hash reported by the
+// server.
+func (c *Client) UploadUpdateRepository(
+	ctx context.Context,
+	path string,
+	opts *UploadUpdateRepositoryOptions,
+) (*UploadUpdateRepositoryResult, error) {
+	if opts == nil {
+		opts = &UploadUpdateRepositoryOptions{}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := opts.FileName
+	if fileName == "" {
+		fileName = filepath.Base(path)
+	}
+	body := &uploadReader{
+		r:        f,
+		size:     info.Size(),
+		hash:     sha256.New(),
+		progress: opts.Progress,
+	}
+
+	upload, err := c.uploadRepository(ctx, fileName, body, opts.Timeout)
+	if err != nil {
+		// The server may still insert the repository after the client gave up on the response,
+		// in which case it can be found by its version.
+		var httpErr *HTTPError
+		if opts.SystemVersion == "" || ctx.Err() != nil || errors.As(err, &httpErr) {
+			return nil, err
+		}
+		upload = &TufRepoUpload{Repo: TufRepo{SystemVersion: opts.SystemVersion}}
+	}
+
+	// The hash is checked against the repository, so it must cover the part of the file that
+	// wasn't sent too when the response was lost.
+	sum, err := body.sum()
+	if err != nil {
+		return nil, err
+	}
+	result := &UploadUpdateRepositoryResult{
+		Repo:   upload.Repo,
+		Status: upload.Status,
+		SHA256: hex.EncodeToString(sum),
+	}
+	if opts.SystemVersion != "" && result.Repo.SystemVersion != opts.SystemVersion {
+		return result, fmt.Errorf(
+			"uploaded repository has system version %q, want %q",
+			result.Repo.SystemVersion,
+			opts.SystemVersion,
+		)
+	}
+
+	repo, err := waitForState(
+		ctx,
+		"TufRepo",
+		"present",
+		nil,
+		opts.Wait,
+		func(ctx context.Context) (*TufRepo, string, error) {
+			repo, err := c.SystemUpdateRepositoryView(
+				ctx,
+				SystemUpdateRepositoryViewParams{SystemVersion: result.Repo.SystemVersion},
+			)
+			if errors.Is(err, ErrObjectNotFound) {
+				return nil, "missing", nil
+			}
+			if err != nil {
+				return nil, "", err
+			}
+			return repo, "present", nil
+		},
+	)
+	if err != nil {
+		return result, err
+	}
+	result.Repo = *repo
+
+	if !strings.EqualFold(result.Repo.Hash, result.SHA256) {
+		return result, fmt.Errorf(
+			"repository %s has hash %s, but the SHA-256 of the uploaded file is %s",
+			result.Repo.SystemVersion,
+			result.Repo.Hash,
+			result.SHA256,
+		)
+	}
+	return result, nil
+}
+
+// uploadRepository sends body to the repository upload endpoint. The request has its own
+// timeout, since the timeout of the HTTP client is meant for regular API calls.
+func (c *Client) uploadRepository(
+	ctx context.Context,
+	fileName string,
+	body *uploadReader,
+	timeout time.Duration,
+) (*TufRepoUpload, error) {
+	req, err := c.buildRequest(
+		withOperationID(ctx, "system_update_repository_upload"),
+		body,
+		"PUT",
+		resolveRelative(c.host, "/v1/system/update/repositories"),
+		map[string]string{},
+		map[string]string{
+			"file_name": fileName,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %v", err)
+	}
+	req.ContentLength = body.size
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	if timeout <= 0 {
+		timeout = uploadBaseTimeout + time.Duration(body.size/uploadMinThroughput)*time.Second
+		if c.client.Timeout == 0 {
+			timeout = 0
+		} else {
+			timeout = max(timeout, c.client.Timeout)
+		}
+	}
+	uc := *c
+	hc := *c.client
+	hc.Timeout = timeout
+	uc.client = &hc
+
+	resp, err := uc.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %v", err)
+	}
+	defer resp.Body.Close()
+	if err := NewHTTPError(resp); err != nil {
+		return nil, err
+	}
+
+	var upload TufRepoUpload
+	if err := json.NewDecoder(resp.Body).Decode(&upload); err != nil {
+		return nil, fmt.Errorf("error decoding response body: %v", err)
+	}
+	return &upload, nil
+}
+
+// uploadReader reads the file of an upload, and hashes it and reports the progress as it's read. It
+// can seek anywhere in the file, e.g. when the request is retried. The hash covers the bytes read
+// in order from the start of the file, so rereading a part of the file doesn't hash it twice.
+type uploadReader struct {
+	r    io.ReadSeeker
+	size int64
+	// pos is the position in the file, and hashed the number of bytes hashed from its start.
+	pos      int64
+	hashed   int64
+	hash     hash.Hash
+	progress func(UploadProgress)
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	if n > 0 {
+		if end := u.pos + int64(n); u.pos <= u.hashed && end > u.hashed {
+			u.hash.Write(p[u.hashed-u.pos : n])
+			u.hashed = end
+		}
+		u.pos += int64(n)
+		if u.progress != nil {
+			u.progress(UploadProgress{TotalBytes: u.size, SentBytes: u.pos})
+		}
+	}
+	return n, err
+}
+
+func (u *uploadReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := u.r.Seek(offset, whence)
+	if err != nil {
+		return u.pos, err
+	}
+	u.pos = pos
+	return pos, nil
+}
+
+// sum returns the SHA-256 of the file, reading the part of the file that wasn't hashed yet.
+func (u *uploadReader) sum() ([]byte, error) {
+	if u.hashed < u.size {
+		if _, err := u.r.Seek(u.hashed, io.SeekStart); err != nil {
+			return nil, err
+		}
+		n, err := io.Copy(u.hash, u.r)
+		u.hashed += n
+		if err != nil {
+			return nil, err
+		}
+	}
+	return u.hash.Sum(nil), nil
+}
@@ -0,0 +1,270 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUpdateRepositoryServer stores the repositories uploaded to it, under the version
+// "18.0.0". The client it returns is created with opts.
+type fakeUpdateRepositoryServer struct {
+	mu    sync.Mutex
+	repos map[string]TufRepo
+	// hash overrides the hash of the uploaded repositories when set.
+	hash string
+	// delay delays the responses to uploads, after the repository is stored.
+	delay time.Duration
+	// failures is the number of uploads that fail with 503 after the file is received.
+	failures int
+	// uploads holds the file name and the Content-Length of every upload.
+	uploads []string
+	lengths []int64
+}
+
+func newFakeUpdateRepositoryServer(
+	t *testing.T,
+	opts ...ClientOption,
+) (*Client, *fakeUpdateRepositoryServer) {
+	t.Helper()
+
+	s := &fakeUpdateRepositoryServer{repos: make(map[string]TufRepo)}
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"PUT /v1/system/update/repositories",
+		func(w http.ResponseWriter, r *http.Request) {
+			h := sha256.New()
+			if _, err := io.Copy(h, r.Body); err != nil {
+				return
+			}
+
+			s.mu.Lock()
+			s.uploads = append(s.uploads, r.URL.Query().Get("file_name"))
+			s.lengths = append(s.lengths, r.ContentLength)
+			if s.failures > 0 {
+				s.failures--
+				s.mu.Unlock()
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(serviceUnavailableBody))
+				return
+			}
+			repo := TufRepo{
+				FileName:      r.URL.Query().Get("file_name"),
+				Hash:          hex.EncodeToString(h.Sum(nil)),
+				SystemVersion: "18.0.0",
+			}
+			if s.hash != "" {
+				repo.Hash = s.hash
+			}
+			status := TufRepoUploadStatusInserted
+			if existing, ok := s.repos[repo.SystemVersion]; ok {
+				repo, status = existing, TufRepoUploadStatusAlreadyExists
+			}
+			s.repos[repo.SystemVersion] = repo
+			delay := s.delay
+			s.mu.Unlock()
+
+			time.Sleep(delay)
+			json.NewEncoder(w).Encode(TufRepoUpload{Repo: repo, Status: status})
+		},
+	)
+	mux.HandleFunc(
+		"GET /v1/system/update/repositories/{version}",
+		func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			repo, ok := s.repos[r.PathValue("version")]
+			s.mu.Unlock()
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write(
+					[]byte(
+						`{"request_id":"1","error_code":"ObjectNotFound","message":"not found"}`,
+					),
+				)
+				return
+			}
+			json.NewEncoder(w).Encode(repo)
+		},
+	)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(
+		append([]ClientOption{WithHost(server.URL), WithToken("foo")}, opts...)...)
+	require.NoError(t, err)
+	return client, s
+}
+
+// testRepositoryFile writes a fake repository and returns its path and SHA-256.
+func testRepositoryFile(t *testing.T) (string, string) {
+	t.Helper()
+
+	content := make([]byte, 1<<20)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	path := filepath.Join(t.TempDir(), "repo-18.0.0.zip")
+	require.NoError(t, os.WriteFile(path, content, 0o644))
+	sum := sha256.Sum256(content)
+	return path, hex.EncodeToString(sum[:])
+}
+
+func Test_UploadUpdateRepository(t *testing.T) {
+	ctx := context.Background()
+	path, sum := testRepositoryFile(t)
+	wait := []WaitOption{WithWaitBackoff(time.Millisecond, time.Millisecond)}
+
+	t.Run("uploads the repository", func(t *testing.T) {
+		client, server := newFakeUpdateRepositoryServer(t)
+
+		var progress []UploadProgress
+		result, err := client.UploadUpdateRepository(ctx, path, &UploadUpdateRepositoryOptions{
+			SystemVersion: "18.0.0",
+			Progress:      func(p UploadProgress) { progress = append(progress, p) },
+			Wait:          wait,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, TufRepoUploadStatusInserted, result.Status)
+		assert.Equal(t, sum, result.SHA256)
+		assert.Equal(t, "18.0.0", result.Repo.SystemVersion)
+		assert.Equal(t, []string{"repo-18.0.0.zip"}, server.uploads)
+		assert.Equal(t, []int64{1 << 20}, server.lengths)
+		require.NotEmpty(t, progress)
+		assert.Equal(
+			t,
+			UploadProgress{TotalBytes: 1 << 20, SentBytes: 1 << 20},
+			progress[len(progress)-1],
+		)
+
+		result, err = client.UploadUpdateRepository(ctx, path, &UploadUpdateRepositoryOptions{
+			FileName: "other.zip",
+			Wait:     wait,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, TufRepoUploadStatusAlreadyExists, result.Status)
+		assert.Equal(t, "repo-18.0.0.zip", result.Repo.FileName)
+	})
+
+	t.Run("resends the file on retries", func(t *testing.T) {
+		client, server := newFakeUpdateRepositoryServer(
+			t,
+			WithRetryPolicy(
+				RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+			),
+		)
+		server.failures = 1
+
+		result, err := client.UploadUpdateRepository(ctx, path, &UploadUpdateRepositoryOptions{
+			Wait: wait,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, sum, result.SHA256)
+		assert.Equal(t, sum, result.Repo.Hash)
+		assert.Len(t, server.uploads, 2)
+	})
+
+	t.Run("rejects unexpected versions", func(t *testing.T) {
+		client, _ := newFakeUpdateRepositoryServer(t)
+
+		_, err := client.UploadUpdateRepository(ctx, path, &UploadUpdateRepositoryOptions{
+			SystemVersion: "17.0.0",
+			Wait:          wait,
+		})
+		assert.EqualError(t, err, `uploaded repository has system version "18.0.0", want "17.0.0"`)
+	})
+
+	t.Run("rejects hash mismatches", func(t *testing.T) {
+		client, server := newFakeUpdateRepositoryServer(t)
+		server.hash = "abcd"
+
+		_, err := client.UploadUpdateRepository(ctx, path, &UploadUpdateRepositoryOptions{
+			Wait: wait,
+		})
+		assert.ErrorContains(t, err, "repository 18.0.0 has hash abcd, but the SHA-256")
+	})
+
+	t.Run("finds the repository when the response is lost", func(t *testing.T) {
+		client, server := newFakeUpdateRepositoryServer(t)
+		server.delay = 200 * time.Millisecond
+
+		result, err := client.UploadUpdateRepository(ctx, path, &UploadUpdateRepositoryOptions{
+			SystemVersion: "18.0.0",
+			Timeout:       100 * time.Millisecond,
+			Wait:          wait,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, result.Status)
+		assert.Equal(t, sum, result.SHA256)
+		assert.Equal(t, sum, result.Repo.Hash)
+	})
+
+	t.Run("fails when the file doesn't exist", func(t *testing.T) {
+		client, _ := newFakeUpdateRepositoryServer(t)
+
+		_, err := client.UploadUpdateRepository(ctx, filepath.Join(t.TempDir(), "missing"), nil)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func Test_uploadReader(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+	want := sha256.Sum256(content)
+	var progress []int64
+	u := &uploadReader{
+		r:        bytes.NewReader(content),
+		size:     int64(len(content)),
+		hash:     sha256.New(),
+		progress: func(p UploadProgress) { progress = append(progress, p.SentBytes) },
+	}
+
+	// Read part of the file, seek back and forth, then read it from an offset.
+	buf := make([]byte, 8)
+	_, err := io.ReadFull(u, buf)
+	require.NoError(t, err)
+	pos, err := u.Seek(-4, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), pos)
+	pos, err = u.Seek(12, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), pos)
+	_, err = io.ReadFull(u, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "cdefghij", string(buf))
+
+	pos, err = u.Seek(2, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), pos)
+	rest, err := io.ReadAll(u)
+	require.NoError(t, err)
+	assert.Equal(t, content[2:], rest)
+	assert.Equal(t, int64(len(content)), progress[len(progress)-1])
+
+	sum, err := u.sum()
+	require.NoError(t, err)
+	assert.Equal(t, want[:], sum)
+
+	// The part of the file that was never read is hashed by sum.
+	u = &uploadReader{r: bytes.NewReader(content), size: int64(len(content)), hash: sha256.New()}
+	_, err = io.ReadFull(u, buf)
+	require.NoError(t, err)
+	sum, err = u.sum()
+	require.NoError(t, err)
+	assert.Equal(t, want[:], sum)
+}
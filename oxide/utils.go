@@ -56,6 +56,13 @@ func resolveRelative(basestr, relstr string) string {
 	return us
 }
 
+// pathTemplate converts a path built for expandURL back to the form used by the OpenAPI spec, e.g.
+// "/v1/disks/{{.disk}}" becomes "/v1/disks/{disk}".
+func pathTemplate(path string) string {
+	path = strings.ReplaceAll(path, "{{.", "{")
+	return strings.ReplaceAll(path, "}}", "}")
+}
+
 // expandURL substitutes any {encoded} strings in the URL passed in using
 // the map supplied.
 func expandURL(u *url.URL, expansions map[string]string) error {
@@ -0,0 +1,151 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/oxidecomputer/oxide.go/internal/stage"
+)
+
+const (
+	// defaultWaitInitialInterval is the time waiters wait before polling a resource again the
+	// first time.
+	defaultWaitInitialInterval = time.Second
+
+	// defaultWaitMaxInterval is the longest time waiters wait between two polls.
+	defaultWaitMaxInterval = 10 * time.Second
+)
+
+// TerminalStateError is returned by the WaitForXxxState methods when the resource reaches a state
+// it can't leave before reaching the wanted state, e.g. an instance that fails while waiting for
+// it to be running.
+type TerminalStateError struct {
+	// Resource is the type of the resource, e.g. "Instance".
+	Resource string
+
+	// State is the terminal state the resource reached.
+	State string
+
+	// Want is the state that was waited for.
+	Want string
+}
+
+// Error implements the error interface.
+func (e *TerminalStateError) Error() string {
+	return fmt.Sprintf(
+		"%s reached terminal state %q while waiting for state %q",
+		e.Resource,
+		e.State,
+		e.Want,
+	)
+}
+
+// waitConfig holds the configuration of a waiter.
+type waitConfig struct {
+	initialInterval time.Duration
+	maxInterval     time.Duration
+}
+
+// WaitOption configures the WaitForXxxState methods.
+type WaitOption func(*waitConfig)
+
+// WithWaitBackoff sets the intervals between two polls of a resource. The first interval is
+// initial, and it doubles after every poll up to max. Non-positive values keep the defaults of 1s
+// and 10s.
+func WithWaitBackoff(initial, max time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		if initial > 0 {
+			cfg.initialInterval = initial
+		}
+		if max > 0 {
+			cfg.maxInterval = max
+		}
+	}
+}
+
+// waitForState polls a resource with view until it reaches the state want, and returns it. It
+// returns a *TerminalStateError if the resource reaches one of the terminal states instead. The
+// first error returned by view, or the context error once ctx is done, is returned as is.
+func waitForState[R any, S ~string](
+	ctx context.Context,
+	resource string,
+	want S,
+	terminal []S,
+	opts []WaitOption,
+	view func(ctx context.Context) (*R, S, error),
+) (*R, error) {
+	if want == "" {
+		return nil, errors.New("state must not be empty")
+	}
+
+	cfg := &waitConfig{
+		initialInterval: defaultWaitInitialInterval,
+		maxInterval:     defaultWaitMaxInterval,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	interval := min(cfg.initialInterval, cfg.maxInterval)
+	for {
+		r, state, err := view(ctx)
+		if err != nil {
+			return nil, stage.ContextErr(ctx, err)
+		}
+		if state == want {
+			return r, nil
+		}
+		if slices.Contains(terminal, state) {
+			return r, &TerminalStateError{
+				Resource: resource,
+				State:    string(state),
+				Want:     string(want),
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		interval = min(interval*2, cfg.maxInterval)
+	}
+}
+
+// StopInstanceAndWait stops an instance and waits until it's stopped. An instance that doesn't
+// exist, or that fails while stopping, counts as stopped, since it can be deleted or updated too.
+func (c *Client) StopInstanceAndWait(
+	ctx context.Context,
+	params InstanceStopParams,
+	opts ...WaitOption,
+) error {
+	_, err := c.InstanceStop(ctx, params)
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = c.WaitForInstanceState(
+		ctx,
+		InstanceViewParams{Project: params.Project, Instance: params.Instance},
+		InstanceStateStopped,
+		opts...,
+	)
+	var terminalErr *TerminalStateError
+	if errors.Is(err, ErrObjectNotFound) ||
+		(errors.As(err, &terminalErr) && terminalErr.State == string(InstanceStateFailed)) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatefulServer returns a client for a server that answers the nth request with the nth
+// response body, and repeats the last one afterwards.
+func newStatefulServer(t *testing.T, bodies ...string) (*Client, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		w.Write([]byte(bodies[min(n, len(bodies))-1]))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+	require.NoError(t, err)
+	return client, &requests
+}
+
+func Test_WaitForState(t *testing.T) {
+	ctx := context.Background()
+	params := InstanceViewParams{Instance: "my-instance"}
+	fast := WithWaitBackoff(time.Millisecond, 5*time.Millisecond)
+
+	t.Run("returns the resource in the wanted state", func(t *testing.T) {
+		client, requests := newStatefulServer(
+			t,
+			`{"name":"my-instance","run_state":"creating"}`,
+			`{"name":"my-instance","run_state":"starting"}`,
+			`{"name":"my-instance","run_state":"running"}`,
+		)
+
+		instance, err := client.WaitForInstanceState(ctx, params, InstanceStateRunning, fast)
+		require.NoError(t, err)
+		assert.Equal(t, InstanceStateRunning, instance.RunState)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("fails on a terminal state", func(t *testing.T) {
+		client, _ := newStatefulServer(
+			t,
+			`{"name":"my-instance","run_state":"starting"}`,
+			`{"name":"my-instance","run_state":"failed"}`,
+		)
+
+		instance, err := client.WaitForInstanceState(ctx, params, InstanceStateRunning, fast)
+		var stateErr *TerminalStateError
+		require.ErrorAs(t, err, &stateErr)
+		assert.Equal(t, &TerminalStateError{
+			Resource: "Instance",
+			State:    "failed",
+			Want:     "running",
+		}, stateErr)
+		assert.EqualError(
+			t,
+			err,
+			`Instance reached terminal state "failed" while waiting for state "running"`,
+		)
+		assert.Equal(t, InstanceStateFailed, instance.RunState)
+	})
+
+	t.Run("waits for a terminal state", func(t *testing.T) {
+		client, _ := newStatefulServer(
+			t,
+			`{"name":"my-instance","run_state":"stopping"}`,
+			`{"name":"my-instance","run_state":"destroyed"}`,
+		)
+
+		instance, err := client.WaitForInstanceState(ctx, params, InstanceStateDestroyed, fast)
+		require.NoError(t, err)
+		assert.Equal(t, InstanceStateDestroyed, instance.RunState)
+	})
+
+	t.Run("reads the state of tagged unions", func(t *testing.T) {
+		client, _ := newStatefulServer(
+			t,
+			`{"name":"my-disk","state":{"state":"creating"}}`,
+			`{"name":"my-disk","state":{"state":"attached","instance":"a"}}`,
+		)
+
+		disk, err := client.WaitForDiskState(
+			ctx,
+			DiskViewParams{Disk: "my-disk"},
+			DiskStateStateAttached,
+			fast,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, DiskStateStateAttached, disk.State.State())
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		client, _ := newStatefulServer(t, `{"name":"my-instance","run_state":"starting"}`)
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForInstanceState(ctx, params, InstanceStateRunning, fast)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("returns view errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(
+				[]byte(`{"request_id":"1","error_code":"ObjectNotFound","message":"not found"}`),
+			)
+		}))
+		defer server.Close()
+		client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+		require.NoError(t, err)
+
+		_, err = client.WaitForInstanceState(ctx, params, InstanceStateRunning, fast)
+		assert.ErrorIs(t, err, ErrObjectNotFound)
+	})
+}
+
+func Test_StopInstanceAndWait(t *testing.T) {
+	ctx := context.Background()
+	params := InstanceStopParams{Instance: "my-instance"}
+	fast := WithWaitBackoff(time.Millisecond, 5*time.Millisecond)
+
+	t.Run("waits until the instance is stopped", func(t *testing.T) {
+		client, requests := newStatefulServer(
+			t,
+			`{"name":"my-instance","run_state":"stopping"}`,
+			`{"name":"my-instance","run_state":"stopping"}`,
+			`{"name":"my-instance","run_state":"stopped"}`,
+		)
+
+		require.NoError(t, client.StopInstanceAndWait(ctx, params, fast))
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("counts a failed instance as stopped", func(t *testing.T) {
+		client, _ := newStatefulServer(
+			t,
+			`{"name":"my-instance","run_state":"stopping"}`,
+			`{"name":"my-instance","run_state":"failed"}`,
+		)
+
+		assert.NoError(t, client.StopInstanceAndWait(ctx, params, fast))
+	})
+
+	t.Run("counts a missing instance as stopped", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(
+				[]byte(`{"request_id":"1","error_code":"ObjectNotFound","message":"not found"}`),
+			)
+		}))
+		defer server.Close()
+		client, err := NewClient(WithHost(server.URL), WithToken("foo"))
+		require.NoError(t, err)
+
+		assert.NoError(t, client.StopInstanceAndWait(ctx, params, fast))
+	})
+}